@@ -27,6 +27,8 @@ const (
 	TokenClassificationTask    TaskType = "token-classification"
 	TextEncodingTask           TaskType = "text-encoding"
 	LanguageModelingTask       TaskType = "language-modeling"
+	RerankingTask              TaskType = "reranking"
+	MultipleChoiceTask         TaskType = "multiple-choice"
 )
 
 // TaskTypeValues is the list of supported task types.
@@ -38,6 +40,8 @@ var TaskTypeValues = []TaskType{
 	TokenClassificationTask,
 	TextEncodingTask,
 	LanguageModelingTask,
+	RerankingTask,
+	MultipleChoiceTask,
 }
 
 // ParseTaskType parses a task type.
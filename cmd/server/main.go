@@ -17,7 +17,9 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/server"
 	"github.com/nlpodyssey/cybertron/pkg/tasks"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/reranking"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textencoding"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
@@ -131,6 +133,10 @@ func loadModelForTask(conf *config) (m any, err error) {
 		return tasks.Load[textencoding.Interface](conf.loaderConfig)
 	case LanguageModelingTask:
 		return tasks.Load[languagemodeling.Interface](conf.loaderConfig)
+	case RerankingTask:
+		return tasks.Load[reranking.Interface](conf.loaderConfig)
+	case MultipleChoiceTask:
+		return tasks.Load[multiplechoice.Interface](conf.loaderConfig)
 	default:
 		return nil, fmt.Errorf("failed to load model/task type %s", conf.task)
 	}
@@ -0,0 +1,149 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package audioprocessing implements the decoding of audio files (WAV and
+// raw PCM) and the extraction of the log-mel spectrogram features for
+// speech models (e.g. Whisper), in the same way as the Hugging Face
+// feature extractors.
+package audioprocessing
+
+import (
+	"math"
+	"path/filepath"
+)
+
+// DefaultConfigFilename is the default filename of the feature extractor configuration.
+const DefaultConfigFilename = "preprocessor_config.json"
+
+// Audio contains the samples of an audio signal.
+type Audio struct {
+	// SampleRate is the number of samples per second.
+	SampleRate int
+	// Channels contains the samples of each channel, normalized in [-1, 1].
+	Channels [][]float64
+}
+
+// Mono returns the samples of the signal mixed down to a single channel,
+// averaging the channels.
+func (a Audio) Mono() []float64 {
+	if len(a.Channels) == 1 {
+		return a.Channels[0]
+	}
+	if len(a.Channels) == 0 {
+		return nil
+	}
+	out := make([]float64, len(a.Channels[0]))
+	for _, ch := range a.Channels {
+		for i, v := range ch {
+			out[i] += v
+		}
+	}
+	n := float64(len(a.Channels))
+	for i := range out {
+		out[i] /= n
+	}
+	return out
+}
+
+// Duration returns the duration of the signal, in seconds.
+func (a Audio) Duration() float64 {
+	if len(a.Channels) == 0 || a.SampleRate == 0 {
+		return 0
+	}
+	return float64(len(a.Channels[0])) / float64(a.SampleRate)
+}
+
+// FeatureExtractor converts audio signals into the log-mel spectrogram expected by a speech model.
+type FeatureExtractor struct {
+	// Config is the configuration of the feature extractor.
+	Config Config
+	// melFilters contains, for each mel filter, the weights of the frequency bins.
+	melFilters [][]float64
+	// window is the periodic Hann window.
+	window []float64
+	// twiddles is the table of the twiddle factors of the Fourier transform.
+	twiddles []complex128
+}
+
+// New returns a new FeatureExtractor.
+func New(config Config) *FeatureExtractor {
+	return &FeatureExtractor{
+		Config: config,
+		melFilters: melFilterBank(
+			config.NFFT/2+1,
+			config.FeatureSize,
+			0,
+			8000,
+			config.SamplingRate,
+		),
+		window:   hannWindow(config.NFFT),
+		twiddles: twiddles(config.NFFT),
+	}
+}
+
+// NewFromModelFolder returns a new FeatureExtractor loading the configuration from the model directory.
+func NewFromModelFolder(modelPath string) (*FeatureExtractor, error) {
+	config, err := ConfigFromFile(filepath.Join(modelPath, DefaultConfigFilename))
+	if err != nil {
+		return nil, err
+	}
+	return New(config), nil
+}
+
+// Extract returns the log-mel spectrogram of the samples, which must have the
+// sampling rate of the configuration. The samples are padded, or truncated, to
+// Config.NSamples. The result contains, for each frame, the value of each mel filter.
+func (e *FeatureExtractor) Extract(samples []float64) [][]float64 {
+	c := e.Config
+	padded := make([]float64, c.NSamples)
+	n := copy(padded, samples)
+	for i := n; i < len(padded); i++ {
+		padded[i] = c.PaddingValue
+	}
+
+	// The frames are centered, padding the signal with its reflection.
+	half := c.NFFT / 2
+	centered := make([]float64, len(padded)+2*half)
+	copy(centered[half:], padded)
+	for i := 0; i < half; i++ {
+		centered[half-1-i] = padded[i+1]
+		centered[half+len(padded)+i] = padded[len(padded)-2-i]
+	}
+
+	// The last frame is discarded, as in Whisper.
+	numFrames := (len(centered)-c.NFFT)/c.HopLength + 1 - 1
+	frames := make([][]float64, numFrames)
+	buf := make([]complex128, c.NFFT)
+	maxValue := math.Inf(-1)
+	for t := range frames {
+		offset := t * c.HopLength
+		for i := range buf {
+			buf[i] = complex(centered[offset+i]*e.window[i], 0)
+		}
+		spectrum := fftWithTwiddles(buf, e.twiddles)
+		power := make([]float64, c.NFFT/2+1)
+		for i := range power {
+			re, im := real(spectrum[i]), imag(spectrum[i])
+			power[i] = re*re + im*im
+		}
+		frame := make([]float64, len(e.melFilters))
+		for m, filter := range e.melFilters {
+			sum := 0.0
+			for i, w := range filter {
+				sum += w * power[i]
+			}
+			frame[m] = math.Log10(math.Max(sum, 1e-10))
+			maxValue = math.Max(maxValue, frame[m])
+		}
+		frames[t] = frame
+	}
+
+	// The dynamic range is limited to 80 dB, then the values are scaled.
+	for _, frame := range frames {
+		for i, v := range frame {
+			frame[i] = (math.Max(v, maxValue-8) + 4) / 4
+		}
+	}
+	return frames
+}
@@ -0,0 +1,178 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeWAV(t *testing.T) {
+	t.Run("16-bit stereo", func(t *testing.T) {
+		samples := []int16{0, 16384, -32768, 32767, 8192, -8192}
+		data := new(bytes.Buffer)
+		require.NoError(t, binary.Write(data, binary.LittleEndian, samples))
+		audio, err := DecodeWAV(bytes.NewReader(newWAV(wavFormatPCM, 2, 8000, 16, data.Bytes())))
+		require.NoError(t, err)
+
+		assert.Equal(t, 8000, audio.SampleRate)
+		assert.Equal(t, [][]float64{{0, -1, 0.25}, {0.5, 32767.0 / 32768, -0.25}}, audio.Channels)
+		assert.InDeltaSlice(t, []float64{0.25, -1.0 / 65536, 0}, audio.Mono(), 1e-12)
+		assert.Equal(t, 3.0/8000, audio.Duration())
+	})
+
+	t.Run("24-bit mono", func(t *testing.T) {
+		data := []byte{0x00, 0x00, 0x40, 0x00, 0x00, 0xc0, 0xff, 0xff, 0xff}
+		audio, err := DecodeWAV(bytes.NewReader(newWAV(wavFormatPCM, 1, 16000, 24, data)))
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{0.5, -0.5, -1.0 / 8388608}}, audio.Channels)
+	})
+
+	t.Run("float extensible", func(t *testing.T) {
+		data := new(bytes.Buffer)
+		require.NoError(t, binary.Write(data, binary.LittleEndian, []float32{0.5, -0.125}))
+		audio, err := DecodeWAV(bytes.NewReader(newWAV(wavFormatExtensible, 1, 16000, 32, data.Bytes())))
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{0.5, -0.125}}, audio.Channels)
+	})
+
+	t.Run("G.711", func(t *testing.T) {
+		audio, err := DecodeWAV(bytes.NewReader(newWAV(wavFormatMuLaw, 1, 8000, 8, []byte{0xff, 0x00, 0x80})))
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{0, -32124.0 / 32768, 32124.0 / 32768}}, audio.Channels)
+
+		audio, err = DecodeWAV(bytes.NewReader(newWAV(wavFormatALaw, 1, 8000, 8, []byte{0xd5, 0x55, 0xaa})))
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{8.0 / 32768, -8.0 / 32768, 32256.0 / 32768}}, audio.Channels)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := DecodeWAV(bytes.NewReader([]byte("not a wav file")))
+		assert.ErrorIs(t, err, ErrInvalidWAV)
+
+		_, err = DecodeWAV(bytes.NewReader(newWAV(0x0055, 1, 8000, 16, nil))) // MP3
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestDecodePCM(t *testing.T) {
+	audio, err := DecodePCM(bytes.NewReader([]byte{0, 128, 255, 64}), Format{
+		Encoding:      LinearPCM,
+		SampleRate:    8000,
+		Channels:      1,
+		BitsPerSample: 8,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{-1, 0, 127.0 / 128, -0.5}}, audio.Channels)
+
+	_, err = DecodePCM(bytes.NewReader(nil), Format{Encoding: MuLaw, SampleRate: 8000, Channels: 1, BitsPerSample: 16})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestResample(t *testing.T) {
+	const from, to = 8000, 16000
+	samples := make([]float64, from)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * 440 * float64(i) / from)
+	}
+	out := Resample(samples, from, to)
+	require.Len(t, out, to)
+	// away from the borders, the resampled signal matches the original sine
+	for i := 1000; i < to-1000; i += 97 {
+		assert.InDelta(t, math.Sin(2*math.Pi*440*float64(i)/to), out[i], 1e-2)
+	}
+
+	down := Resample(out, to, from)
+	require.Len(t, down, from)
+	for i := 500; i < from-500; i += 53 {
+		assert.InDelta(t, samples[i], down[i], 1e-2)
+	}
+}
+
+func TestFFT(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 16, 60, 400} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Sin(float64(i)*0.37)+float64(i%5), math.Cos(float64(i)*1.3))
+		}
+		got, want := fft(x), dft(x)
+		for k := range want {
+			assert.InDelta(t, 0, cmplx.Abs(got[k]-want[k]), 1e-9, "n=%d k=%d", n, k)
+		}
+	}
+}
+
+func TestFeatureExtractor_Extract(t *testing.T) {
+	config := baseConfig()
+	config.ChunkLength = 0
+	config.NSamples = 1600
+	config.NbMaxFrames = 10
+	e := New(config)
+
+	samples := make([]float64, 1200)
+	for i := range samples {
+		x := float64(i)
+		samples[i] = 0.5*math.Sin(2*math.Pi*440*x/16000) +
+			0.25*math.Sin(2*math.Pi*3000*x/16000+0.3) +
+			0.01*float64((i*7919)%13-6)
+	}
+	features := e.Extract(samples)
+	require.Len(t, features, 10)
+	require.Len(t, features[0], 80)
+
+	// reference values computed with the Hugging Face WhisperFeatureExtractor
+	tests := []struct {
+		frame, mel int
+		want       float64
+	}{
+		{0, 0, 0.993307374857532},
+		{0, 10, 1.3380974630033995},
+		{2, 8, -0.5617962471251103},
+		{4, 30, 0.4047494261760174},
+		{6, 48, 0.30402692679670085},
+		{7, 60, 0.4718424166422217},
+		{8, 12, 1.008736527458639},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, features[tt.frame][tt.mel], 1e-9, "frame %d, mel %d", tt.frame, tt.mel)
+	}
+}
+
+// newWAV returns a minimal WAV file with the given format and data.
+func newWAV(tag uint16, channels, sampleRate, bitsPerSample int, data []byte) []byte {
+	fmtChunk := new(bytes.Buffer)
+	blockAlign := channels * bitsPerSample / 8
+	fields := []any{
+		tag,
+		uint16(channels),
+		uint32(sampleRate),
+		uint32(sampleRate * blockAlign),
+		uint16(blockAlign),
+		uint16(bitsPerSample),
+	}
+	if tag == wavFormatExtensible {
+		fields = append(fields, uint16(22), uint16(bitsPerSample), uint32(0), uint16(wavFormatFloat), [14]byte{})
+	}
+	for _, field := range fields {
+		_ = binary.Write(fmtChunk, binary.LittleEndian, field)
+	}
+
+	out := new(bytes.Buffer)
+	out.WriteString("RIFF")
+	_ = binary.Write(out, binary.LittleEndian, uint32(4+8+fmtChunk.Len()+8+len(data)))
+	out.WriteString("WAVEfmt ")
+	_ = binary.Write(out, binary.LittleEndian, uint32(fmtChunk.Len()))
+	out.Write(fmtChunk.Bytes())
+	out.WriteString("data")
+	_ = binary.Write(out, binary.LittleEndian, uint32(len(data)))
+	out.Write(data)
+	return out.Bytes()
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the configuration of the feature extractor.
+// The configuration coincides with that of the Hugging Face Whisper feature extractor.
+type Config struct {
+	// ChunkLength is the length, in seconds, of the audio chunks processed by the model.
+	ChunkLength int `json:"chunk_length"`
+	// FeatureSize is the number of mel filters.
+	FeatureSize int `json:"feature_size"`
+	// HopLength is the number of samples between two consecutive frames.
+	HopLength int `json:"hop_length"`
+	// NFFT is the size of the Fourier transform, which is also the length of the frames.
+	NFFT int `json:"n_fft"`
+	// NSamples is the number of samples of each chunk.
+	NSamples int `json:"n_samples"`
+	// NbMaxFrames is the number of frames of each chunk.
+	NbMaxFrames int `json:"nb_max_frames"`
+	// PaddingValue is the value used to pad the chunks shorter than NSamples.
+	PaddingValue float64 `json:"padding_value"`
+	// SamplingRate is the sampling rate expected by the model, in Hz.
+	SamplingRate int `json:"sampling_rate"`
+}
+
+// ConfigFromFile loads a Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face Whisper feature extractor,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		ChunkLength:  30,
+		FeatureSize:  80,
+		HopLength:    160,
+		NFFT:         400,
+		NSamples:     480000,
+		NbMaxFrames:  3000,
+		PaddingValue: 0,
+		SamplingRate: 16000,
+	}
+}
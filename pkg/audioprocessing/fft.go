@@ -0,0 +1,93 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// fft returns the discrete Fourier transform of x, computed with the
+// mixed-radix Cooley-Tukey algorithm, so that any size is supported
+// (e.g. the 400 samples of the Whisper frames).
+func fft(x []complex128) []complex128 {
+	return fftWithTwiddles(x, twiddles(len(x)))
+}
+
+// fftWithTwiddles computes the transform of x, whose length must divide the
+// length of the twiddle factors table.
+func fftWithTwiddles(x []complex128, table []complex128) []complex128 {
+	n := len(x)
+	if n <= 1 {
+		return append([]complex128(nil), x...)
+	}
+	stride := len(table) / n
+	p := smallestFactor(n)
+	if p == n {
+		out := make([]complex128, n)
+		for k := range out {
+			var sum complex128
+			for j, v := range x {
+				sum += v * table[(j*k%n)*stride]
+			}
+			out[k] = sum
+		}
+		return out
+	}
+
+	// Split the input into p interleaved sub-sequences of length m.
+	m := n / p
+	subs := make([][]complex128, p)
+	for r := range subs {
+		sub := make([]complex128, m)
+		for k := range sub {
+			sub[k] = x[k*p+r]
+		}
+		subs[r] = fftWithTwiddles(sub, table)
+	}
+
+	out := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		var sum complex128
+		for r, sub := range subs {
+			sum += sub[k%m] * table[(r*k%n)*stride]
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// dft returns the discrete Fourier transform of x, computed by definition.
+func dft(x []complex128) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		var sum complex128
+		for j, v := range x {
+			sum += v * cmplx.Exp(complex(0, -2*math.Pi*float64(j*k%n)/float64(n)))
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// twiddles returns the table of the factors exp(-2πi k/n), for k in [0, n).
+func twiddles(n int) []complex128 {
+	table := make([]complex128, n)
+	for k := range table {
+		table[k] = cmplx.Exp(complex(0, -2*math.Pi*float64(k)/float64(n)))
+	}
+	return table
+}
+
+// smallestFactor returns the smallest prime factor of n.
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
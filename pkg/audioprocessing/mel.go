@@ -0,0 +1,71 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import "math"
+
+const (
+	// minLogHertz is the frequency where the Slaney mel scale becomes logarithmic.
+	minLogHertz = 1000.0
+	// minLogMel is the mel value corresponding to minLogHertz.
+	minLogMel = 15.0
+)
+
+// logStep is the step of the logarithmic region of the Slaney mel scale.
+var logStep = 27.0 / math.Log(6.4)
+
+// hertzToMel converts a frequency to the Slaney mel scale.
+func hertzToMel(f float64) float64 {
+	if f < minLogHertz {
+		return 3 * f / 200
+	}
+	return minLogMel + math.Log(f/minLogHertz)*logStep
+}
+
+// melToHertz converts a value of the Slaney mel scale to a frequency.
+func melToHertz(m float64) float64 {
+	if m < minLogMel {
+		return 200 * m / 3
+	}
+	return minLogHertz * math.Exp((m-minLogMel)/logStep)
+}
+
+// melFilterBank returns the triangular filters, normalized by their width
+// (Slaney normalization), which map the frequency bins of the spectrum to the
+// mel bins. The result contains, for each mel filter, the weight of each frequency bin.
+func melFilterBank(numFrequencyBins, numMelFilters int, minFrequency, maxFrequency float64, samplingRate int) [][]float64 {
+	melMin, melMax := hertzToMel(minFrequency), hertzToMel(maxFrequency)
+	filterFreqs := make([]float64, numMelFilters+2)
+	for i := range filterFreqs {
+		filterFreqs[i] = melToHertz(melMin + (melMax-melMin)*float64(i)/float64(numMelFilters+1))
+	}
+	fftFreqs := make([]float64, numFrequencyBins)
+	for i := range fftFreqs {
+		fftFreqs[i] = float64(samplingRate/2) * float64(i) / float64(numFrequencyBins-1)
+	}
+
+	filters := make([][]float64, numMelFilters)
+	for m := range filters {
+		lower, center, upper := filterFreqs[m], filterFreqs[m+1], filterFreqs[m+2]
+		norm := 2 / (upper - lower)
+		filter := make([]float64, numFrequencyBins)
+		for i, f := range fftFreqs {
+			down := (f - lower) / (center - lower)
+			up := (upper - f) / (upper - center)
+			filter[i] = math.Max(0, math.Min(down, up)) * norm
+		}
+		filters[m] = filter
+	}
+	return filters
+}
+
+// hannWindow returns the periodic Hann window of the given length.
+func hannWindow(n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+	}
+	return w
+}
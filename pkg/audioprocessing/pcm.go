@@ -0,0 +1,145 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrUnsupportedFormat means that the format of the audio samples is not supported.
+var ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+// Encoding is the encoding of the audio samples.
+type Encoding int
+
+const (
+	// LinearPCM is the linear pulse-code modulation, with little-endian signed
+	// integer samples (unsigned for 8 bits per sample).
+	LinearPCM Encoding = iota
+	// FloatPCM is the pulse-code modulation with little-endian IEEE 754 samples.
+	FloatPCM
+	// MuLaw is the G.711 μ-law companding, with 8 bits per sample.
+	MuLaw
+	// ALaw is the G.711 A-law companding, with 8 bits per sample.
+	ALaw
+)
+
+// Format describes the layout of raw audio samples.
+// The samples of multiple channels are interleaved.
+type Format struct {
+	// Encoding is the encoding of the samples.
+	Encoding Encoding
+	// SampleRate is the number of samples per second.
+	SampleRate int
+	// Channels is the number of channels.
+	Channels int
+	// BitsPerSample is the size of each sample, in bits.
+	BitsPerSample int
+}
+
+// validate returns an error if the format is not supported.
+func (f Format) validate() error {
+	if f.SampleRate <= 0 || f.Channels <= 0 {
+		return fmt.Errorf("%w: %d channels at %d Hz", ErrUnsupportedFormat, f.Channels, f.SampleRate)
+	}
+	switch {
+	case f.Encoding == LinearPCM && (f.BitsPerSample == 8 || f.BitsPerSample == 16 || f.BitsPerSample == 24 || f.BitsPerSample == 32),
+		f.Encoding == FloatPCM && (f.BitsPerSample == 32 || f.BitsPerSample == 64),
+		(f.Encoding == MuLaw || f.Encoding == ALaw) && f.BitsPerSample == 8:
+		return nil
+	default:
+		return fmt.Errorf("%w: encoding %d with %d bits per sample", ErrUnsupportedFormat, f.Encoding, f.BitsPerSample)
+	}
+}
+
+// DecodePCM decodes the raw (headerless) audio samples read from r, according to the given format.
+func DecodePCM(r io.Reader, format Format) (Audio, error) {
+	if err := format.validate(); err != nil {
+		return Audio{}, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Audio{}, err
+	}
+	return decodeSamples(data, format), nil
+}
+
+// decodeSamples decodes the interleaved samples, ignoring an incomplete trailing frame.
+func decodeSamples(data []byte, format Format) Audio {
+	sampleSize := format.BitsPerSample / 8
+	numFrames := len(data) / (sampleSize * format.Channels)
+	channels := make([][]float64, format.Channels)
+	for c := range channels {
+		channels[c] = make([]float64, numFrames)
+	}
+	for i := 0; i < numFrames; i++ {
+		for c, ch := range channels {
+			offset := (i*format.Channels + c) * sampleSize
+			ch[i] = decodeSample(data[offset:offset+sampleSize], format)
+		}
+	}
+	return Audio{
+		SampleRate: format.SampleRate,
+		Channels:   channels,
+	}
+}
+
+// decodeSample returns the value of a single sample, normalized in [-1, 1].
+func decodeSample(b []byte, format Format) float64 {
+	switch format.Encoding {
+	case FloatPCM:
+		if format.BitsPerSample == 32 {
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	case MuLaw:
+		return float64(decodeMuLaw(b[0])) / 32768
+	case ALaw:
+		return float64(decodeALaw(b[0])) / 32768
+	}
+	switch format.BitsPerSample {
+	case 8:
+		return (float64(b[0]) - 128) / 128
+	case 16:
+		return float64(int16(binary.LittleEndian.Uint16(b))) / 32768
+	case 24:
+		v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+		return float64(v) / 8388608
+	default:
+		return float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648
+	}
+}
+
+// decodeMuLaw expands a G.711 μ-law sample to a 16-bit linear sample.
+func decodeMuLaw(u byte) int16 {
+	u = ^u
+	t := (int(u&0x0f)<<3 + 0x84) << ((u & 0x70) >> 4)
+	if u&0x80 != 0 {
+		return int16(0x84 - t)
+	}
+	return int16(t - 0x84)
+}
+
+// decodeALaw expands a G.711 A-law sample to a 16-bit linear sample.
+func decodeALaw(a byte) int16 {
+	a ^= 0x55
+	t := int(a&0x0f) << 4
+	switch seg := (a & 0x70) >> 4; seg {
+	case 0:
+		t += 8
+	case 1:
+		t += 0x108
+	default:
+		t = (t + 0x108) << (seg - 1)
+	}
+	if a&0x80 != 0 {
+		return int16(t)
+	}
+	return int16(-t)
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import "math"
+
+// resampleZeroCrossings is the number of zero crossings of the sinc filter on each side.
+const resampleZeroCrossings = 16
+
+// Resample converts the samples from a sampling rate to another, using a
+// band-limited interpolation with a Hann-windowed sinc filter. When the
+// sampling rate is reduced, the cutoff of the filter is lowered to prevent aliasing.
+func Resample(samples []float64, from, to int) []float64 {
+	if from == to || len(samples) == 0 {
+		return samples
+	}
+	ratio := float64(to) / float64(from)
+	cutoff := math.Min(1, ratio)
+	halfWidth := float64(resampleZeroCrossings) / cutoff
+
+	out := make([]float64, int(math.Ceil(float64(len(samples))*ratio)))
+	for i := range out {
+		t := float64(i) / ratio // position in the input
+		first := max(0, int(math.Ceil(t-halfWidth)))
+		last := min(len(samples)-1, int(math.Floor(t+halfWidth)))
+		sum := 0.0
+		for k := first; k <= last; k++ {
+			d := t - float64(k)
+			window := 0.5 + 0.5*math.Cos(math.Pi*d/halfWidth)
+			sum += samples[k] * cutoff * sinc(cutoff*d) * window
+		}
+		out[i] = sum
+	}
+	return out
+}
+
+// sinc returns the normalized sinc function sin(πx)/(πx).
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audioprocessing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidWAV means that the data is not a well-formed WAV file.
+var ErrInvalidWAV = errors.New("invalid WAV file")
+
+// WAV format tags.
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatALaw       = 0x0006
+	wavFormatMuLaw      = 0x0007
+	wavFormatExtensible = 0xfffe
+)
+
+// DecodeWAV decodes a WAV (RIFF WAVE) file. It supports linear PCM samples
+// of 8, 16, 24 and 32 bits, IEEE float samples of 32 and 64 bits, and the
+// G.711 μ-law and A-law encodings, as well as the WAVE_FORMAT_EXTENSIBLE header.
+func DecodeWAV(r io.Reader) (Audio, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Audio{}, err
+	}
+	if len(data) < 12 || !bytes.Equal(data[0:4], []byte("RIFF")) || !bytes.Equal(data[8:12], []byte("WAVE")) {
+		return Audio{}, fmt.Errorf("%w: missing RIFF WAVE header", ErrInvalidWAV)
+	}
+
+	var format *Format
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := data[offset+8:]
+		if size > len(body) {
+			if id != "data" {
+				return Audio{}, fmt.Errorf("%w: truncated %q chunk", ErrInvalidWAV, id)
+			}
+			size = len(body) // tolerate streams whose data size was not finalized
+		}
+		body = body[:size]
+
+		switch id {
+		case "fmt ":
+			f, err := parseWAVFormat(body)
+			if err != nil {
+				return Audio{}, err
+			}
+			format = &f
+		case "data":
+			if format == nil {
+				return Audio{}, fmt.Errorf("%w: data chunk before fmt chunk", ErrInvalidWAV)
+			}
+			return decodeSamples(body, *format), nil
+		}
+		offset += 8 + size + size%2 // chunks are word-aligned
+	}
+	return Audio{}, fmt.Errorf("%w: missing data chunk", ErrInvalidWAV)
+}
+
+// parseWAVFormat parses the body of the "fmt " chunk.
+func parseWAVFormat(b []byte) (Format, error) {
+	if len(b) < 16 {
+		return Format{}, fmt.Errorf("%w: fmt chunk too short", ErrInvalidWAV)
+	}
+	tag := binary.LittleEndian.Uint16(b[0:2])
+	if tag == wavFormatExtensible {
+		if len(b) < 26 {
+			return Format{}, fmt.Errorf("%w: extensible fmt chunk too short", ErrInvalidWAV)
+		}
+		tag = binary.LittleEndian.Uint16(b[24:26]) // first bytes of the sub-format GUID
+	}
+	f := Format{
+		Channels:      int(binary.LittleEndian.Uint16(b[2:4])),
+		SampleRate:    int(binary.LittleEndian.Uint32(b[4:8])),
+		BitsPerSample: int(binary.LittleEndian.Uint16(b[14:16])),
+	}
+	switch tag {
+	case wavFormatPCM:
+		f.Encoding = LinearPCM
+	case wavFormatFloat:
+		f.Encoding = FloatPCM
+	case wavFormatMuLaw:
+		f.Encoding = MuLaw
+	case wavFormatALaw:
+		f.Encoding = ALaw
+	default:
+		return Format{}, fmt.Errorf("%w: WAV format tag %#04x", ErrUnsupportedFormat, tag)
+	}
+	if err := f.validate(); err != nil {
+		return Format{}, err
+	}
+	return f, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	multiplechoicev1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/multiplechoice/v1"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice"
+)
+
+var _ multiplechoice.Interface = &clientForMultipleChoice{}
+
+// clientForMultipleChoice is a client for multiple choice implementing multiplechoice.Interface
+type clientForMultipleChoice struct {
+	// target is the server endpoint.
+	target string
+	// opts is the gRPC options for the client.
+	opts Options
+}
+
+// NewClientForMultipleChoice creates a new client for multiple choice.
+func NewClientForMultipleChoice(target string, opts Options) multiplechoice.Interface {
+	return &clientForMultipleChoice{
+		target: target,
+		opts:   opts,
+	}
+}
+
+// Choose scores each choice as the continuation of (or the answer to) the given context.
+func (c *clientForMultipleChoice) Choose(ctx context.Context, text string, choices []string) (multiplechoice.Response, error) {
+	conn, err := Dial(ctx, c.target, c.opts)
+	if err != nil {
+		return multiplechoice.Response{}, fmt.Errorf("failed to dial %q: %w", c.target, err)
+	}
+	cc := multiplechoicev1.NewMultipleChoiceServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	response, err := cc.Choose(ctx, &multiplechoicev1.ChooseRequest{
+		Input:   text,
+		Choices: choices,
+	})
+	if err != nil {
+		return multiplechoice.Response{}, err
+	}
+	return multiplechoice.Response{
+		Scores: response.Scores,
+		Best:   int(response.Best),
+	}, nil
+}
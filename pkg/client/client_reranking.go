@@ -0,0 +1,62 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rerankingv1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/reranking/v1"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/reranking"
+)
+
+var _ reranking.Interface = &clientForReranking{}
+
+// clientForReranking is a client for reranking implementing reranking.Interface
+type clientForReranking struct {
+	// target is the server endpoint.
+	target string
+	// opts is the gRPC options for the client.
+	opts Options
+}
+
+// NewClientForReranking creates a new client for reranking.
+func NewClientForReranking(target string, opts Options) reranking.Interface {
+	return &clientForReranking{
+		target: target,
+		opts:   opts,
+	}
+}
+
+// Rerank sorts the given documents by relevance to the query.
+func (c *clientForReranking) Rerank(ctx context.Context, query string, documents []string, topK int) (reranking.Response, error) {
+	conn, err := Dial(ctx, c.target, c.opts)
+	if err != nil {
+		return reranking.Response{}, fmt.Errorf("failed to dial %q: %w", c.target, err)
+	}
+	cc := rerankingv1.NewRerankingServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	response, err := cc.Rerank(ctx, &rerankingv1.RerankRequest{
+		Query:     query,
+		Documents: documents,
+		TopK:      int32(topK),
+	})
+	if err != nil {
+		return reranking.Response{}, err
+	}
+	indices := make([]int, len(response.Indices))
+	for i, index := range response.Indices {
+		indices[i] = int(index)
+	}
+	return reranking.Response{
+		Documents: response.Documents,
+		Indices:   indices,
+		Scores:    response.Scores,
+	}, nil
+}
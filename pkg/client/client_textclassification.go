@@ -13,7 +13,10 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
 )
 
-var _ textclassification.Interface = &clientForTextClassification{}
+var (
+	_ textclassification.Interface     = &clientForTextClassification{}
+	_ textclassification.PairInterface = &clientForTextClassification{}
+)
 
 // clientForTextClassification is a client for text classification implementing textclassification.Interface
 type clientForTextClassification struct {
@@ -24,6 +27,7 @@ type clientForTextClassification struct {
 }
 
 // NewClientForTextClassification creates a new client for text classification.
+// The client also implements textclassification.PairInterface.
 func NewClientForTextClassification(target string, opts Options) textclassification.Interface {
 	return &clientForTextClassification{
 		target: target,
@@ -33,6 +37,20 @@ func NewClientForTextClassification(target string, opts Options) textclassificat
 
 // Classify classifies the given text.
 func (c *clientForTextClassification) Classify(ctx context.Context, text string) (textclassification.Response, error) {
+	return c.classify(ctx, &textclassificationv1.ClassifyRequest{
+		Input: text,
+	})
+}
+
+// ClassifyPair classifies the given pair of texts.
+func (c *clientForTextClassification) ClassifyPair(ctx context.Context, text, textPair string) (textclassification.Response, error) {
+	return c.classify(ctx, &textclassificationv1.ClassifyRequest{
+		Input:    text,
+		TextPair: &textPair,
+	})
+}
+
+func (c *clientForTextClassification) classify(ctx context.Context, req *textclassificationv1.ClassifyRequest) (textclassification.Response, error) {
 	conn, err := Dial(ctx, c.target, c.opts)
 	if err != nil {
 		return textclassification.Response{}, fmt.Errorf("failed to dial %q: %w", c.target, err)
@@ -42,9 +60,7 @@ func (c *clientForTextClassification) Classify(ctx context.Context, text string)
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	response, err := cc.Classify(ctx, &textclassificationv1.ClassifyRequest{
-		Input: text,
-	})
+	response, err := cc.Classify(ctx, req)
 	if err != nil {
 		return textclassification.Response{}, err
 	}
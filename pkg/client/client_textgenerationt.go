@@ -6,7 +6,9 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	textgenerationv1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/textgeneration/v1"
@@ -14,7 +16,10 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/utils/nullable"
 )
 
-var _ textgeneration.Interface = &clientForTextGeneration{}
+var (
+	_ textgeneration.Interface       = &clientForTextGeneration{}
+	_ textgeneration.StreamInterface = &clientForTextGeneration{}
+)
 
 // clientForTextGeneration is a client for text generation implementing textgeneration.Interface
 type clientForTextGeneration struct {
@@ -25,6 +30,7 @@ type clientForTextGeneration struct {
 }
 
 // NewClientForTextGeneration creates a new client for text generation.
+// The client also implements textgeneration.StreamInterface.
 func NewClientForTextGeneration(target string, opts Options) textgeneration.Interface {
 	return &clientForTextGeneration{
 		target: target,
@@ -34,14 +40,6 @@ func NewClientForTextGeneration(target string, opts Options) textgeneration.Inte
 
 // Generate generates text (e.g. translation, summarization, paraphrase) from the given input.
 func (c *clientForTextGeneration) Generate(ctx context.Context, text string, opts *textgeneration.Options) (textgeneration.Response, error) {
-	if opts == nil {
-		opts = textgeneration.DefaultOptions()
-	}
-	topK64 := nullable.Type[int64]{
-		Value: int64(opts.TopK.Value),
-		Valid: opts.TopK.Valid,
-	}
-
 	conn, err := Dial(ctx, c.target, c.opts)
 	if err != nil {
 		return textgeneration.Response{}, fmt.Errorf("failed to dial %q: %w", c.target, err)
@@ -51,15 +49,7 @@ func (c *clientForTextGeneration) Generate(ctx context.Context, text string, opt
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	response, err := cc.Generate(ctx, &textgenerationv1.GenerateRequest{
-		Input: text,
-		Parameters: &textgenerationv1.TextGenerationParameters{
-			Temperature: opts.Temperature.ValuePtr(),
-			DoSample:    opts.Sample.ValuePtr(),
-			TopK:        topK64.ValuePtr(),
-			TopP:        opts.TopP.ValuePtr(),
-		},
-	})
+	response, err := cc.Generate(ctx, generateRequest(text, opts))
 	if err != nil {
 		return textgeneration.Response{}, err
 	}
@@ -68,3 +58,121 @@ func (c *clientForTextGeneration) Generate(ctx context.Context, text string, opt
 		Scores: response.Scores,
 	}, nil
 }
+
+// GenerateStream generates text like Generate, calling fn with each token as
+// soon as it is received. Unlike Generate, no timeout is applied, since the
+// generation of long texts can take a while: it is up to the given context.
+func (c *clientForTextGeneration) GenerateStream(ctx context.Context, text string, opts *textgeneration.Options, fn textgeneration.StreamFunc) (textgeneration.Response, error) {
+	conn, err := Dial(ctx, c.target, c.opts)
+	if err != nil {
+		return textgeneration.Response{}, fmt.Errorf("failed to dial %q: %w", c.target, err)
+	}
+	defer conn.Close()
+	cc := textgenerationv1.NewTextGenerationServiceClient(conn)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := cc.GenerateStream(ctx, generateRequest(text, opts))
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return textgeneration.Response{}, errors.New("the stream ended without a result")
+		}
+		if err != nil {
+			return textgeneration.Response{}, err
+		}
+		if result := response.GetResult(); result != nil {
+			return textgeneration.Response{
+				Texts:  result.Texts,
+				Scores: result.Scores,
+			}, nil
+		}
+		token := response.GetToken()
+		if err := fn(textgeneration.Token{ID: int(token.GetId()), Text: token.GetText()}); err != nil {
+			return textgeneration.Response{}, err
+		}
+	}
+}
+
+// generateRequest returns the request for the given text and options.
+func generateRequest(text string, opts *textgeneration.Options) *textgenerationv1.GenerateRequest {
+	if opts == nil {
+		opts = textgeneration.DefaultOptions()
+	}
+	return &textgenerationv1.GenerateRequest{
+		Input: text,
+		Parameters: &textgenerationv1.TextGenerationParameters{
+			Temperature:        opts.Temperature.ValuePtr(),
+			DoSample:           opts.Sample.ValuePtr(),
+			TopK:               optionalInt64(opts.TopK),
+			TopP:               opts.TopP.ValuePtr(),
+			SourceLanguage:     optionalString(opts.SourceLanguage),
+			TargetLanguage:     optionalString(opts.TargetLanguage),
+			NumBeams:           optionalInt64(opts.NumBeams),
+			MinLength:          optionalInt64(opts.MinLength),
+			MaxLength:          optionalInt64(opts.MaxLength),
+			MaxNewTokens:       optionalInt64(opts.MaxNewTokens),
+			LengthPenalty:      opts.LengthPenalty.ValuePtr(),
+			NoRepeatNgramSize:  optionalInt64(opts.NoRepeatNGramSize),
+			EarlyStopping:      opts.EarlyStopping.ValuePtr(),
+			BadWordsIds:        tokenIDs(opts.BadWordsIDs),
+			NumReturnSequences: optionalInt64(opts.NumReturnSequences),
+			RepetitionPenalty:  opts.RepetitionPenalty.ValuePtr(),
+			PresencePenalty:    opts.PresencePenalty.ValuePtr(),
+			FrequencyPenalty:   opts.FrequencyPenalty.ValuePtr(),
+			LogitBias:          opts.LogitBias,
+			TypicalP:           opts.TypicalP.ValuePtr(),
+			MinP:               opts.MinP.ValuePtr(),
+			ForceWords:         phraseAlternatives(opts.ForceWords),
+		},
+	}
+}
+
+// optionalInt64 returns a pointer to the given value as int64, or nil if it is not valid.
+func optionalInt64(v nullable.Type[int]) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	value := int64(v.Value)
+	return &value
+}
+
+// tokenIDs converts the token ID sequences for the request.
+func tokenIDs(sequences [][]int) []*textgenerationv1.TokenIDs {
+	if len(sequences) == 0 {
+		return nil
+	}
+	result := make([]*textgenerationv1.TokenIDs, len(sequences))
+	for i, sequence := range sequences {
+		ids := make([]int64, len(sequence))
+		for j, id := range sequence {
+			ids[j] = int64(id)
+		}
+		result[i] = &textgenerationv1.TokenIDs{Ids: ids}
+	}
+	return result
+}
+
+// phraseAlternatives converts the forced words for the request.
+func phraseAlternatives(constraints [][]string) []*textgenerationv1.PhraseAlternatives {
+	if len(constraints) == 0 {
+		return nil
+	}
+	result := make([]*textgenerationv1.PhraseAlternatives, len(constraints))
+	for i, phrases := range constraints {
+		result[i] = &textgenerationv1.PhraseAlternatives{Phrases: phrases}
+	}
+	return result
+}
+
+// optionalString returns a pointer to the given string, or nil if it is empty.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -45,6 +45,7 @@ func (c *clientForTokenClassification) Classify(ctx context.Context, text string
 	response, err := cc.Classify(ctx, &tokenclassificationv1.ClassifyRequest{
 		Input:               text,
 		AggregationStrategy: grpcAggregationStrategy(parameters.AggregationStrategy),
+		DecodingStrategy:    grpcDecodingStrategy(parameters.DecodingStrategy),
 	})
 	if err != nil {
 		return tokenclassification.Response{}, err
@@ -78,3 +79,16 @@ func grpcAggregationStrategy(value tokenclassification.AggregationStrategy) toke
 		panic(fmt.Sprintf("client: invalid aggreagation strategy %v", value))
 	}
 }
+
+func grpcDecodingStrategy(value tokenclassification.DecodingStrategy) tokenclassificationv1.ClassifyRequest_DecodingStrategy {
+	switch value {
+	case "":
+		return tokenclassificationv1.ClassifyRequest_AUTO
+	case tokenclassification.DecodingStrategyArgmax:
+		return tokenclassificationv1.ClassifyRequest_ARGMAX
+	case tokenclassification.DecodingStrategyViterbi:
+		return tokenclassificationv1.ClassifyRequest_VITERBI
+	default:
+		panic(fmt.Sprintf("client: invalid decoding strategy %v", value))
+	}
+}
@@ -60,6 +60,25 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 		// Its value is left zero for other model, such as Marian.
 		config.Cybertron.PositionalEncoderOffset = 2
 	}
+	if config.ModelType == "mbart" {
+		// mBART is a pre-norm model with learned positions, offset by the
+		// padding as in Bart.
+		config.NormalizeBefore = true
+		config.FinalLayerNorm = true
+		config.NormalizeEmbedding = true
+		config.StaticPositionEmbeddings = false
+		config.Cybertron.PositionalEncoderOffset = 2
+	}
+	if config.ModelType == "m2m_100" {
+		// M2M100 and NLLB are pre-norm models with fairseq sinusoidal
+		// positions, offset by the padding as in Bart.
+		config.NormalizeBefore = true
+		config.FinalLayerNorm = true
+		config.NormalizeEmbedding = false
+		config.StaticPositionEmbeddings = true
+		config.Cybertron.PositionalEncoderOffset = 2
+		config.Cybertron.FairseqPositionalEncoding = true
+	}
 
 	pyParams := pytorch.NewParamsProvider[T]().
 		WithNameMapping(fixParamsName).
@@ -166,7 +185,7 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 			if err != nil {
 				return err
 			}
-		case "MarianMTModel", "PegasusForConditionalGeneration", "BartForConditionalGeneration":
+		case "MarianMTModel", "PegasusForConditionalGeneration", "BartForConditionalGeneration", "MBartForConditionalGeneration", "M2M100ForConditionalGeneration":
 			err := nn.DumpToFile(bartForConditionalGenertion, goModelFilename)
 			if err != nil {
 				return err
@@ -8,14 +8,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
 	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
 	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
 	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/mat/float"
 	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/crf"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -25,6 +28,12 @@ const (
 	defaultConfigFilename = "config.json"
 	// defaultVocabularyFile is the default BERT model's vocabulary filename.
 	defaultVocabularyFile = "vocab.txt"
+	// defaultBPEVocabularyFile is the default RoBERTa model's byte-level BPE vocabulary filename.
+	defaultBPEVocabularyFile = "vocab.json"
+	// defaultSentencePieceModelFile is the default XLM-RoBERTa model's sentence-piece model filename.
+	defaultSentencePieceModelFile = "sentencepiece.bpe.model"
+	// defaultALBERTSentencePieceModelFile is the default ALBERT model's sentence-piece model filename.
+	defaultALBERTSentencePieceModelFile = "spiece.model"
 	// defaultPyModelFilename is the default Bart PyTorch model filename.
 	defaultPyModelFilename = "pytorch_model.bin"
 	// defaultGoModelFilename is the default Bart spaGO model filename.
@@ -43,7 +52,6 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
 		pyModelFilename = filepath.Join(modelDir, defaultPyModelFilename)
 		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
-		vocabFilename   = filepath.Join(modelDir, defaultVocabularyFile)
 	)
 
 	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
@@ -56,7 +64,11 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 		return err
 	}
 
-	vocab, err := vocabulary.NewFromFile(vocabFilename)
+	if config.InnerGroupNum > 1 {
+		return fmt.Errorf("bert: unsupported inner_group_num %d (only 1 is supported)", config.InnerGroupNum)
+	}
+
+	vocab, err := loadVocabulary(modelDir, config.ModelType)
 	if err != nil {
 		return err
 	}
@@ -66,7 +78,11 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 		// (for example, for embeddings storage files).
 		config.Cybertron.Training = true
 
-		if config.ModelType == "bert" || config.EmbeddingsSize == 0 {
+		if config.EmbeddingsSize == 0 {
+			// ELECTRA names the factorized embeddings size "embedding_size"
+			config.EmbeddingsSize = config.EmbeddingSize
+		}
+		if config.ModelType == "bert" || config.ModelType == "roberta" || config.ModelType == "xlm-roberta" || config.EmbeddingsSize == 0 {
 			config.EmbeddingsSize = config.HiddenSize
 		}
 	}
@@ -83,6 +99,12 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 	baseModel := mapBaseModel[T](config, pyParams, params, vocab)
 	finalModel := mapSpecificArchitecture[T](baseModel, config.Architectures, params)
 
+	if m, ok := finalModel.(*bert.ModelForTokenClassification); ok && pyParams.Get("crf.transition_scores") != nil {
+		// The token classifier is followed by a linear-chain CRF layer.
+		m.CRF = crf.New[T](len(config.ID2Label))
+		mapCRF(m.CRF, params)
+	}
+
 	mapping := make(map[string]*mappingParam)
 	for k, v := range params {
 		mapping[k] = &mappingParam{value: v, matched: false}
@@ -133,6 +155,36 @@ func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
 	return nil
 }
 
+// loadVocabulary loads the vocabulary from the model directory, according to the model type.
+func loadVocabulary(modelDir, modelType string) (*vocabulary.Vocabulary, error) {
+	switch modelType {
+	case "roberta":
+		return vocabulary.NewFromJSONFile(filepath.Join(modelDir, defaultBPEVocabularyFile))
+	case "xlm-roberta":
+		tok, err := sentencepiece.NewFairseqFromFile(filepath.Join(modelDir, defaultSentencePieceModelFile), false)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int, tok.VocabSize())
+		for i := range ids {
+			ids[i] = i
+		}
+		return vocabulary.New(tok.IDsToTokens(ids)), nil
+	case "albert":
+		tok, err := sentencepiece.NewFromFile(filepath.Join(modelDir, defaultALBERTSentencePieceModelFile), true)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int, tok.VocabSize())
+		for i := range ids {
+			ids[i] = i
+		}
+		return vocabulary.New(tok.IDsToTokens(ids)), nil
+	default:
+		return vocabulary.NewFromFile(filepath.Join(modelDir, defaultVocabularyFile))
+	}
+}
+
 func mapBaseModel[T float.DType](config bert.Config, pyParams *pytorch.ParamsProvider[T], params paramsMap, vocab *vocabulary.Vocabulary) *bert.Model {
 	baseModel := bert.New[T](config)
 
@@ -151,7 +203,7 @@ func mapBaseModel[T float.DType](config bert.Config, pyParams *pytorch.ParamsPro
 		}
 	}
 
-	cols := config.HiddenSize
+	cols := config.EmbeddingsSize
 
 	{
 		source := pyParams.Pop("bert.embeddings.position_embeddings.weight")
@@ -173,6 +225,7 @@ func mapBaseModel[T float.DType](config bert.Config, pyParams *pytorch.ParamsPro
 
 	mapPooler(baseModel.Pooler, params)
 	mapEmbeddingsLayerNorm(baseModel.Embeddings.Norm, params)
+	mapEmbeddingsProjector(baseModel.Embeddings.Projector, params)
 	mapEncoderParams(baseModel.Encoder, params)
 
 	return baseModel
@@ -186,32 +239,82 @@ func mapSpecificArchitecture[T float.DType](baseModel *bert.Model, architectures
 	switch architectures[0] {
 	case "BertBase":
 		return baseModel
-	case "BertModel":
+	case "BertModel", "RobertaModel", "XLMRobertaModel", "ElectraModel", "AlbertModel":
 		return bert.NewModelForSequenceEncoding(baseModel)
-	case "BertForMaskedLM":
+	case "BertForMaskedLM", "RobertaForMaskedLM", "XLMRobertaForMaskedLM", "AlbertForMaskedLM":
 		m := bert.NewModelForMaskedLM[T](baseModel)
 		mapMaskedLM(m.Layers, params)
 		return m
-	case "BertForQuestionAnswering":
+	case "BertForQuestionAnswering", "RobertaForQuestionAnswering", "XLMRobertaForQuestionAnswering", "ElectraForQuestionAnswering", "AlbertForQuestionAnswering":
 		m := bert.NewModelForQuestionAnswering[T](baseModel)
 		mapQAClassifier(m.Classifier, params)
 		return m
-	case "BertForSequenceClassification":
+	case "BertForSequenceClassification", "RobertaForSequenceClassification", "XLMRobertaForSequenceClassification", "ElectraForSequenceClassification", "AlbertForSequenceClassification":
 		m := bert.NewModelForSequenceClassification[T](baseModel)
 		mapSeqClassifier(m.Classifier, params)
 		return m
-	case "BertForTokenClassification":
+	case "BertForMultipleChoice", "RobertaForMultipleChoice", "ElectraForMultipleChoice", "AlbertForMultipleChoice":
+		m := bert.NewModelForMultipleChoice[T](baseModel)
+		mapMultipleChoiceClassifier(m.Classifier, params)
+		return m
+	case "BertForIntentAndSlotClassification", "JointBERT":
+		m := bert.NewModelForIntentAndSlots[T](baseModel)
+		mapIntentAndSlotClassifiers(m.IntentClassifier, m.SlotClassifier, params)
+		return m
+	case "BertForTokenClassification", "RobertaForTokenClassification", "XLMRobertaForTokenClassification", "ElectraForTokenClassification", "AlbertForTokenClassification":
 		m := bert.NewModelForTokenClassification[T](baseModel)
 		mapTokenClassifier(m.Classifier, params)
 		return m
+	case "ElectraForPreTraining":
+		m := bert.NewModelForReplacedTokenDetection[T](baseModel)
+		mapDiscriminatorPredictions(m.Layers, params)
+		return m
 	default:
 		panic(fmt.Errorf("bert: unsupported architecture %s", architectures[0]))
 	}
 }
 
+// albertLayerGroup matches the prefix of the parameters of the ALBERT shared layer groups.
+var albertLayerGroup = regexp.MustCompile(`^bert\.encoder\.albert_layer_groups\.(\d+)\.albert_layers\.0\.`)
+
+// albertLayerReplacer maps the names of the ALBERT layer parameters to the BERT ones.
+var albertLayerReplacer = strings.NewReplacer(
+	"attention.query.", "attention.self.query.",
+	"attention.key.", "attention.self.key.",
+	"attention.value.", "attention.self.value.",
+	"attention.dense.", "attention.output.dense.",
+	"attention.LayerNorm.", "attention.output.LayerNorm.",
+	"ffn_output.", "output.dense.",
+	"ffn.", "intermediate.dense.",
+	"full_layer_layer_norm.", "output.LayerNorm.",
+)
+
+// albertPredictionsReplacer maps the names of the ALBERT masked language modeling
+// head parameters to the BERT ones.
+var albertPredictionsReplacer = strings.NewReplacer(
+	"predictions.dense.", "cls.predictions.transform.dense.",
+	"predictions.LayerNorm.", "cls.predictions.transform.LayerNorm.",
+	"predictions.decoder.", "cls.predictions.decoder.",
+	"predictions.bias", "cls.predictions.decoder.bias",
+)
+
 func fixParamsName(from string) (to string) {
 	to = from
+	to = strings.Replace(to, "albert.", "bert.", -1)
 	to = strings.Replace(to, "electra.", "bert.", -1)
+	to = strings.Replace(to, "roberta.", "bert.", -1)
+	// The RoBERTa classification head (dense + tanh + out_proj) is equivalent to the BERT pooler + classifier.
+	to = strings.Replace(to, "classifier.dense.", "bert.pooler.dense.", -1)
+	to = strings.Replace(to, "classifier.out_proj.", "classifier.", -1)
+	// The ELECTRA multiple choice head (sequence summary + classifier) is equivalent to the BERT pooler + classifier.
+	to = strings.Replace(to, "sequence_summary.summary.", "bert.pooler.dense.", -1)
+	// The JointBERT intent and slot classifiers wrap their linear layer.
+	to = strings.Replace(to, "intent_classifier.linear.", "intent_classifier.", -1)
+	to = strings.Replace(to, "slot_classifier.linear.", "slot_classifier.", -1)
+	to = strings.Replace(to, "lm_head.dense.", "cls.predictions.transform.dense.", -1)
+	to = strings.Replace(to, "lm_head.layer_norm.", "cls.predictions.transform.LayerNorm.", -1)
+	to = strings.Replace(to, "lm_head.decoder.", "cls.predictions.decoder.", -1)
+	to = strings.Replace(to, "lm_head.bias", "cls.predictions.decoder.bias", -1)
 	to = strings.Replace(to, ".gamma", ".weight", -1)
 	to = strings.Replace(to, ".beta", ".bias", -1)
 	if strings.HasPrefix(to, "embeddings.") {
@@ -223,5 +326,26 @@ func fixParamsName(from string) (to string) {
 	if strings.HasPrefix(to, "pooler.") {
 		to = fmt.Sprintf("bert.%s", to)
 	}
+	to = fixALBERTParamsName(to)
+	return
+}
+
+// fixALBERTParamsName maps the names of the ALBERT parameters to the BERT ones.
+// The shared layer groups are mapped to the encoder layers, which are then
+// reused across depth by the encoder.
+func fixALBERTParamsName(from string) (to string) {
+	to = from
+	to = strings.Replace(to, "bert.encoder.embedding_hidden_mapping_in.", "bert.embeddings_project.", -1)
+	if loc := albertLayerGroup.FindStringSubmatchIndex(to); loc != nil {
+		group := to[loc[2]:loc[3]]
+		to = fmt.Sprintf("bert.encoder.layer.%s.%s", group, albertLayerReplacer.Replace(to[loc[1]:]))
+	}
+	switch to {
+	case "bert.pooler.weight", "bert.pooler.bias":
+		to = strings.Replace(to, "bert.pooler.", "bert.pooler.dense.", 1)
+	}
+	if strings.HasPrefix(to, "predictions.") {
+		to = albertPredictionsReplacer.Replace(to)
+	}
 	return
 }
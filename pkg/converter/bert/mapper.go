@@ -10,6 +10,7 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/models/bert"
 	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/crf"
 	"github.com/nlpodyssey/spago/nn/linear"
 	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
 )
@@ -17,7 +18,7 @@ import (
 type paramsMap map[string]mat.Tensor
 
 func mapEncoderParams(encoder *bert.Encoder, params paramsMap) {
-	for i := 0; i < encoder.Config.NumHiddenLayers; i++ {
+	for i := 0; i < encoder.Config.NumEncoderLayers(); i++ {
 		layer := any(encoder.Layers[i]).(*bert.EncoderLayer)
 		prefixBase := fmt.Sprintf("bert.encoder.layer.%d", i)
 
@@ -53,6 +54,14 @@ func mapEmbeddingsLayerNorm(embeddingsNorm *layernorm.Model, params paramsMap) {
 	params["bert.embeddings.LayerNorm.bias"] = embeddingsNorm.B.Value()
 }
 
+func mapEmbeddingsProjector(projector *linear.Model, params paramsMap) {
+	if projector == nil {
+		return
+	}
+	params["bert.embeddings_project.weight"] = projector.W.Value()
+	params["bert.embeddings_project.bias"] = projector.B.Value()
+}
+
 func mapPooler(pooler *bert.Pooler, params paramsMap) {
 	params["bert.pooler.dense.weight"] = pooler.Model[0].(*linear.Model).W.Value()
 	params["bert.pooler.dense.bias"] = pooler.Model[0].(*linear.Model).B.Value()
@@ -63,11 +72,27 @@ func mapSeqClassifier(model *linear.Model, params paramsMap) {
 	params["classifier.bias"] = model.B.Value()
 }
 
+func mapMultipleChoiceClassifier(model *linear.Model, params paramsMap) {
+	params["classifier.weight"] = model.W.Value()
+	params["classifier.bias"] = model.B.Value()
+}
+
+func mapIntentAndSlotClassifiers(intent, slot *linear.Model, params paramsMap) {
+	params["intent_classifier.weight"] = intent.W.Value()
+	params["intent_classifier.bias"] = intent.B.Value()
+	params["slot_classifier.weight"] = slot.W.Value()
+	params["slot_classifier.bias"] = slot.B.Value()
+}
+
 func mapTokenClassifier(model *linear.Model, params paramsMap) {
 	params["classifier.weight"] = model.W.Value()
 	params["classifier.bias"] = model.B.Value()
 }
 
+func mapCRF(model *crf.Model, params paramsMap) {
+	params["crf.transition_scores"] = model.TransitionScores.Value()
+}
+
 // mapProjectionLayer maps the projection layer parameters.
 func mapQAClassifier(model *linear.Model, params paramsMap) {
 	params["qa_outputs.weight"] = model.W.Value()
@@ -82,3 +107,10 @@ func mapMaskedLM(layers []nn.StandardModel, params paramsMap) {
 	params["cls.predictions.decoder.weight"] = layers[3].(*linear.Model).W.Value()
 	params["cls.predictions.decoder.bias"] = layers[3].(*linear.Model).B.Value()
 }
+
+func mapDiscriminatorPredictions(layers []nn.StandardModel, params paramsMap) {
+	params["discriminator_predictions.dense.weight"] = layers[0].(*linear.Model).W.Value()
+	params["discriminator_predictions.dense.bias"] = layers[0].(*linear.Model).B.Value()
+	params["discriminator_predictions.dense_prediction.weight"] = layers[2].(*linear.Model).W.Value()
+	params["discriminator_predictions.dense_prediction.bias"] = layers[2].(*linear.Model).B.Value()
+}
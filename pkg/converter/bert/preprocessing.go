@@ -6,6 +6,7 @@ package bert
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
 	"github.com/nlpodyssey/cybertron/pkg/models/bert"
@@ -24,12 +25,14 @@ func fixAttentionLayers[T float.DType](c bert.Config) pytorch.PreProcessingFunc[
 			c:              c,
 		}
 		p.fixEncoderSelfAttention()
+		p.tieMaskedLMDecoder()
+		p.mergeCRFTransitions()
 		return nil
 	}
 }
 
 func (p *paramsPostProcessing[T]) fixEncoderSelfAttention() {
-	for i := 0; i < p.c.NumHiddenLayers; i++ {
+	for i := 0; i < p.c.NumEncoderLayers(); i++ {
 		prefix := fmt.Sprintf("bert.encoder.layer.%d.attention.self", i)
 		queryWeight := p.Pop(fmt.Sprintf("%s.query.weight", prefix))
 		queryBias := p.Pop(fmt.Sprintf("%s.query.bias", prefix))
@@ -53,3 +56,37 @@ func (p *paramsPostProcessing[T]) fixEncoderSelfAttention() {
 		}
 	}
 }
+
+// tieMaskedLMDecoder sets the masked language modeling decoder weights to the word
+// embeddings when the checkpoint omits them because they are tied.
+func (p *paramsPostProcessing[T]) tieMaskedLMDecoder() {
+	if len(p.c.Architectures) == 0 || !strings.HasSuffix(p.c.Architectures[0], "ForMaskedLM") {
+		return
+	}
+	if p.Get("cls.predictions.decoder.weight") != nil {
+		return
+	}
+	embeddings := p.Get("bert.embeddings.word_embeddings.weight")
+	p.Set("cls.predictions.decoder.weight", append([]T(nil), embeddings...))
+}
+
+// mergeCRFTransitions merges the start, end and pairwise transitions of a
+// linear-chain CRF layer (as in pytorch-crf) into a single matrix, where
+// the index 0 stands for both the start and the end state.
+func (p *paramsPostProcessing[T]) mergeCRFTransitions() {
+	transitions := p.Pop("crf.transitions")
+	start := p.Pop("crf.start_transitions")
+	end := p.Pop("crf.end_transitions")
+	if transitions == nil || len(start) != len(end) || len(start)*len(start) != len(transitions) {
+		return
+	}
+
+	n := len(start)
+	scores := make([]T, (n+1)*(n+1))
+	for i := 0; i < n; i++ {
+		scores[i+1] = start[i]
+		scores[(i+1)*(n+1)] = end[i]
+		copy(scores[(i+1)*(n+1)+1:(i+2)*(n+1)], transitions[i*n:(i+1)*n])
+	}
+	p.Set("crf.transition_scores", scores)
+}
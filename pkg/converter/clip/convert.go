@@ -0,0 +1,139 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/clip"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultConfigFilename is the default CLIP JSON configuration filename.
+	defaultConfigFilename = "config.json"
+	// defaultPyModelFilename is the default CLIP PyTorch model filename.
+	defaultPyModelFilename = "pytorch_model.bin"
+	// defaultGoModelFilename is the default CLIP spaGO model filename.
+	defaultGoModelFilename = "spago_model.bin"
+)
+
+// mappingParam is a mapping between a Hugging Face Transformers parameters and Cybertron parameters.
+type mappingParam struct {
+	value   mat.Tensor
+	matched bool
+}
+
+// Convert converts a CLIP PyTorch model to a Spago (Cybertron) model.
+func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
+	var (
+		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
+		pyModelFilename = filepath.Join(modelDir, defaultPyModelFilename)
+		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
+	)
+
+	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
+		log.Info().Str("model", goModelFilename).Msg("model file already exists, skipping conversion")
+		return nil
+	}
+
+	config, err := clip.ConfigFromFile(configFilename)
+	if err != nil {
+		return err
+	}
+	if len(config.Architectures) > 0 && config.Architectures[0] != "CLIPModel" {
+		return fmt.Errorf("clip: unsupported architecture %s", config.Architectures[0])
+	}
+
+	// Enable training mode, so that we have writing permissions
+	// (for example, for embeddings storage files).
+	config.Cybertron.Training = true
+
+	// The patch embeddings are stored as convolution kernels,
+	// and the logit scale as a scalar.
+	pyParams := pytorch.NewParamsProvider[T]().
+		WithAllTensors().
+		WithPreProcessing(fixAttention[T](config))
+
+	if err = pyParams.Load(pyModelFilename); err != nil {
+		return err
+	}
+
+	m := clip.New[T](config)
+	setEmbeddings(m.Text.Tokens, pyParams.Get("text_model.embeddings.token_embedding.weight"))
+	setEmbeddings(m.Text.Positions, pyParams.Get("text_model.embeddings.position_embedding.weight"))
+	setEmbeddings(m.Vision.Positions, pyParams.Get("vision_model.embeddings.position_embedding.weight"))
+
+	params := make(paramsMap)
+	mapTextModel(m.Text, params)
+	mapVisionModel(m.Vision, params)
+	mapProjections(m, params)
+
+	mapping := make(map[string]*mappingParam)
+	for k, v := range params {
+		mapping[k] = &mappingParam{value: v, matched: false}
+	}
+
+	err = pyParams.Iterate(func(name string, value []T) error {
+		param, ok := mapping[name]
+		if !ok {
+			return nil
+		}
+		if param.value.Size() != len(value) {
+			return fmt.Errorf("error setting %s: dim mismatch", name)
+		}
+		mat.SetData[T](param.value, value)
+		param.matched = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		log.Debug().Msg("Reporting possible conversion mapping anomalies")
+		for key, value := range mapping {
+			if !value.matched {
+				log.Debug().Str("parameter", key).Msg("parameter not initialized")
+			}
+		}
+		err = pyParams.Iterate(func(name string, _ []T) error {
+			if _, ok := mapping[name]; !ok {
+				log.Debug().Str("parameter", name).Msg("parameter not mapped")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Serializing model to \"%s\"... ", goModelFilename)
+	err = nn.DumpToFile(m, goModelFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Done.")
+
+	return nil
+}
+
+// setEmbeddings copies the source weights, row by row, into the embeddings.
+func setEmbeddings[T float.DType](dest *embedding.Model, source []T) {
+	size := dest.Dim
+	for i := 0; i < dest.Size; i++ {
+		item, _ := dest.Embedding(i)
+		item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*size : (i+1)*size])))
+	}
+}
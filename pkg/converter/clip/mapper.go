@@ -0,0 +1,69 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/clip"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapTextModel maps the parameters of the text encoder.
+func mapTextModel(m *clip.TextModel, params paramsMap) {
+	mapLayers(m.Layers, "text_model.encoder", params)
+	params["text_model.final_layer_norm.weight"] = m.LayerNorm.W.Value()
+	params["text_model.final_layer_norm.bias"] = m.LayerNorm.B.Value()
+}
+
+// mapVisionModel maps the parameters of the image encoder.
+// The patch projection has no bias, which is left to zero.
+func mapVisionModel(m *clip.VisionModel, params paramsMap) {
+	params["vision_model.embeddings.class_embedding"] = m.ClassToken.Value()
+	params["vision_model.embeddings.patch_embedding.weight"] = m.Patches.W.Value()
+	params["vision_model.pre_layrnorm.weight"] = m.PreLayerNorm.W.Value()
+	params["vision_model.pre_layrnorm.bias"] = m.PreLayerNorm.B.Value()
+	mapLayers(m.Layers, "vision_model.encoder", params)
+	params["vision_model.post_layernorm.weight"] = m.PostLayerNorm.W.Value()
+	params["vision_model.post_layernorm.bias"] = m.PostLayerNorm.B.Value()
+}
+
+// mapProjections maps the parameters of the projections to the shared space
+// and of the logit scale. The projections have no bias, which is left to zero.
+func mapProjections(m *clip.Model, params paramsMap) {
+	params["text_projection.weight"] = m.TextProjection.W.Value()
+	params["visual_projection.weight"] = m.VisualProjection.W.Value()
+	params["logit_scale"] = m.LogitScale.Value()
+}
+
+// mapLayers maps the parameters of the transformer layers of an encoder.
+func mapLayers(layers []*clip.EncoderLayer, encoderPrefix string, params paramsMap) {
+	for i, layer := range layers {
+		prefix := fmt.Sprintf("%s.layers.%d", encoderPrefix, i)
+		params[fmt.Sprintf("%s.layer_norm1.weight", prefix)] = layer.AttentionNorm.W.Value()
+		params[fmt.Sprintf("%s.layer_norm1.bias", prefix)] = layer.AttentionNorm.B.Value()
+		for j, head := range layer.Attention.Heads {
+			headPrefix := fmt.Sprintf("%s.self_attn.%d", prefix, j)
+			params[fmt.Sprintf("%s.q_proj.weight", headPrefix)] = head.Query.W.Value()
+			params[fmt.Sprintf("%s.q_proj.bias", headPrefix)] = head.Query.B.Value()
+			params[fmt.Sprintf("%s.k_proj.weight", headPrefix)] = head.Key.W.Value()
+			params[fmt.Sprintf("%s.k_proj.bias", headPrefix)] = head.Key.B.Value()
+			params[fmt.Sprintf("%s.v_proj.weight", headPrefix)] = head.Value.W.Value()
+			params[fmt.Sprintf("%s.v_proj.bias", headPrefix)] = head.Value.B.Value()
+		}
+		params[fmt.Sprintf("%s.self_attn.out_proj.weight", prefix)] = layer.Attention.OutputMerge.W.Value()
+		params[fmt.Sprintf("%s.self_attn.out_proj.bias", prefix)] = layer.Attention.OutputMerge.B.Value()
+		params[fmt.Sprintf("%s.layer_norm2.weight", prefix)] = layer.FFNorm.W.Value()
+		params[fmt.Sprintf("%s.layer_norm2.bias", prefix)] = layer.FFNorm.B.Value()
+		params[fmt.Sprintf("%s.mlp.fc1.weight", prefix)] = layer.FF[0].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.mlp.fc1.bias", prefix)] = layer.FF[0].(*linear.Model).B.Value()
+		params[fmt.Sprintf("%s.mlp.fc2.weight", prefix)] = layer.FF[2].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.mlp.fc2.bias", prefix)] = layer.FF[2].(*linear.Model).B.Value()
+	}
+}
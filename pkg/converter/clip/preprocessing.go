@@ -0,0 +1,47 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/clip"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+// fixAttention splits the query, key and value projections of the
+// self-attention of both encoders into separate projections for each head.
+func fixAttention[T float.DType](c clip.Config) pytorch.PreProcessingFunc[T] {
+	return func(p *pytorch.ParamsProvider[T]) error {
+		encoders := []struct {
+			prefix                    string
+			layers, heads, hiddenSize int
+		}{
+			{"text_model.encoder", c.TextConfig.NumHiddenLayers, c.TextConfig.NumAttentionHeads, c.TextConfig.HiddenSize},
+			{"vision_model.encoder", c.VisionConfig.NumHiddenLayers, c.VisionConfig.NumAttentionHeads, c.VisionConfig.HiddenSize},
+		}
+		for _, e := range encoders {
+			dim := e.hiddenSize / e.heads
+			for i := 0; i < e.layers; i++ {
+				prefix := fmt.Sprintf("%s.layers.%d.self_attn", e.prefix, i)
+				for _, name := range []string{"q_proj", "k_proj", "v_proj"} {
+					weight := p.Pop(fmt.Sprintf("%s.%s.weight", prefix, name))
+					bias := p.Pop(fmt.Sprintf("%s.%s.bias", prefix, name))
+					if weight == nil || bias == nil {
+						return fmt.Errorf("clip: missing %s.%s parameters", prefix, name)
+					}
+					for j := 0; j < e.heads; j++ {
+						from, to := j*dim, (j+1)*dim
+						newPrefix := fmt.Sprintf("%s.%d.%s", prefix, j, name)
+						p.Set(fmt.Sprintf("%s.weight", newPrefix), weight[from*e.hiddenSize:to*e.hiddenSize])
+						p.Set(fmt.Sprintf("%s.bias", newPrefix), bias[from:to])
+					}
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -9,6 +9,15 @@ import (
 
 	"github.com/nlpodyssey/cybertron/pkg/converter/bart"
 	"github.com/nlpodyssey/cybertron/pkg/converter/bert"
+	"github.com/nlpodyssey/cybertron/pkg/converter/clip"
+	"github.com/nlpodyssey/cybertron/pkg/converter/debertav2"
+	"github.com/nlpodyssey/cybertron/pkg/converter/distilbert"
+	"github.com/nlpodyssey/cybertron/pkg/converter/gpt2"
+	"github.com/nlpodyssey/cybertron/pkg/converter/llama"
+	"github.com/nlpodyssey/cybertron/pkg/converter/mpnet"
+	"github.com/nlpodyssey/cybertron/pkg/converter/t5"
+	"github.com/nlpodyssey/cybertron/pkg/converter/vit"
+	"github.com/nlpodyssey/cybertron/pkg/converter/whisper"
 	"github.com/nlpodyssey/cybertron/pkg/models"
 	"github.com/nlpodyssey/spago/mat/float"
 )
@@ -25,10 +34,28 @@ func Convert[T float.DType](modelPath string, overwriteIfExists bool) error {
 	}
 
 	switch modelType {
-	case "bert", "electra":
+	case "bert", "electra", "roberta", "xlm-roberta", "albert":
 		return bert.Convert[T](modelPath, overwriteIfExists)
-	case "bart", "marian", "pegasus":
+	case "deberta-v2":
+		return debertav2.Convert[T](modelPath, overwriteIfExists)
+	case "distilbert":
+		return distilbert.Convert[T](modelPath, overwriteIfExists)
+	case "mpnet":
+		return mpnet.Convert[T](modelPath, overwriteIfExists)
+	case "bart", "marian", "pegasus", "mbart", "m2m_100":
 		return bart.Convert[T](modelPath, overwriteIfExists)
+	case "gpt2":
+		return gpt2.Convert[T](modelPath, overwriteIfExists)
+	case "llama":
+		return llama.Convert[T](modelPath, overwriteIfExists)
+	case "t5":
+		return t5.Convert[T](modelPath, overwriteIfExists)
+	case "vit":
+		return vit.Convert[T](modelPath, overwriteIfExists)
+	case "clip":
+		return clip.Convert[T](modelPath, overwriteIfExists)
+	case "whisper":
+		return whisper.Convert[T](modelPath, overwriteIfExists)
 	default:
 		return fmt.Errorf("unsupported model type: %#v", modelType)
 	}
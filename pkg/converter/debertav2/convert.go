@@ -0,0 +1,169 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/debertav2"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultConfigFilename is the default DeBERTa-v2 JSON configuration filename.
+	defaultConfigFilename = "config.json"
+	// defaultPyModelFilename is the default DeBERTa-v2 PyTorch model filename.
+	defaultPyModelFilename = "pytorch_model.bin"
+	// defaultGoModelFilename is the default DeBERTa-v2 spaGO model filename.
+	defaultGoModelFilename = "spago_model.bin"
+)
+
+// mappingParam is a mapping between a Hugging Face Transformers parameters and Cybertron parameters.
+type mappingParam struct {
+	value   mat.Tensor
+	matched bool
+}
+
+// Convert converts a DeBERTa-v2 PyTorch model to a Spago (Cybertron) model.
+func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
+	var (
+		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
+		pyModelFilename = filepath.Join(modelDir, defaultPyModelFilename)
+		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
+	)
+
+	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
+		log.Info().Str("model", goModelFilename).Msg("model file already exists, skipping conversion")
+		return nil
+	}
+
+	config, err := debertav2.ConfigFromFile(configFilename)
+	if err != nil {
+		return err
+	}
+	if config.ConvKernelSize > 0 {
+		return fmt.Errorf("deberta-v2: the convolution layer is not supported")
+	}
+
+	// Enable training mode, so that we have writing permissions
+	// (for example, for embeddings storage files).
+	config.Cybertron.Training = true
+
+	pyParams := pytorch.NewParamsProvider[T]().
+		WithNameMapping(fixParamsName).
+		WithPreProcessing(fixAttentionLayers[T](config))
+
+	if err = pyParams.Load(pyModelFilename); err != nil {
+		return err
+	}
+
+	m := debertav2.New[T](config)
+	params := make(paramsMap)
+	mapEmbeddings(m.Embeddings, params)
+	mapEncoder(m.Encoder, params)
+
+	setEmbeddings(m.Embeddings.Tokens, pyParams.Get("embeddings.word_embeddings.weight"))
+	if m.Embeddings.Positions != nil {
+		setEmbeddings(m.Embeddings.Positions, pyParams.Get("embeddings.position_embeddings.weight"))
+	}
+	if m.Embeddings.TokenTypes != nil {
+		setEmbeddings(m.Embeddings.TokenTypes, pyParams.Get("embeddings.token_type_embeddings.weight"))
+	}
+	if m.Encoder.RelEmbeddings != nil {
+		setEmbeddings(m.Encoder.RelEmbeddings, pyParams.Get("encoder.rel_embeddings.weight"))
+	}
+
+	var model nn.Model
+	switch architecture := firstArchitecture(config); architecture {
+	case "DebertaV2ForSequenceClassification":
+		cls := debertav2.NewModelForSequenceClassification[T](m)
+		mapSequenceClassifier(cls, params)
+		model = cls
+	case "DebertaV2ForTokenClassification":
+		cls := debertav2.NewModelForTokenClassification[T](m)
+		mapTokenClassifier(cls, params)
+		model = cls
+	default:
+		return fmt.Errorf("deberta-v2: unsupported architecture %#v", architecture)
+	}
+
+	mapping := make(map[string]*mappingParam)
+	for k, v := range params {
+		mapping[k] = &mappingParam{value: v, matched: false}
+	}
+
+	err = pyParams.Iterate(func(name string, value []T) error {
+		param, ok := mapping[name]
+		if !ok {
+			return nil
+		}
+		if param.value.Size() != len(value) {
+			return fmt.Errorf("error setting %s: dim mismatch", name)
+		}
+		mat.SetData[T](param.value, value)
+		param.matched = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		log.Debug().Msg("Reporting possible conversion mapping anomalies")
+		for key, value := range mapping {
+			if !value.matched {
+				log.Debug().Str("parameter", key).Msg("parameter not initialized")
+			}
+		}
+		err = pyParams.Iterate(func(name string, _ []T) error {
+			if _, ok := mapping[name]; !ok {
+				log.Debug().Str("parameter", name).Msg("parameter not mapped")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Serializing model to \"%s\"... ", goModelFilename)
+	err = nn.DumpToFile(model, goModelFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Done.")
+
+	return nil
+}
+
+func firstArchitecture(config debertav2.Config) string {
+	if len(config.Architectures) == 0 {
+		return ""
+	}
+	return config.Architectures[0]
+}
+
+func fixParamsName(from string) string {
+	return strings.TrimPrefix(from, "deberta.")
+}
+
+// setEmbeddings copies the source weights, row by row, into the embeddings.
+func setEmbeddings[T float.DType](dest *embedding.Model, source []T) {
+	size := dest.Dim
+	for i := 0; i < dest.Size; i++ {
+		item, _ := dest.Embedding(i)
+		item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*size : (i+1)*size])))
+	}
+}
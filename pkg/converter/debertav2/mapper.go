@@ -0,0 +1,73 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/debertav2"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapLinear maps the weight and the bias of a linear layer.
+func mapLinear(m *linear.Model, prefix string, params paramsMap) {
+	params[prefix+".weight"] = m.W.Value()
+	params[prefix+".bias"] = m.B.Value()
+}
+
+// mapEmbeddings maps the parameters of the embeddings, except the embeddings themselves.
+func mapEmbeddings(m *debertav2.Embeddings, params paramsMap) {
+	if m.Projection != nil {
+		params["embeddings.embed_proj.weight"] = m.Projection.W.Value()
+	}
+	params["embeddings.LayerNorm.weight"] = m.Norm.W.Value()
+	params["embeddings.LayerNorm.bias"] = m.Norm.B.Value()
+}
+
+// mapEncoder maps the parameters of the encoder layers and of the
+// normalization of the relative position embeddings.
+func mapEncoder(m *debertav2.Encoder, params paramsMap) {
+	for i, layer := range m.Layers {
+		prefix := fmt.Sprintf("encoder.layer.%d", i)
+		att := layer.SelfAttention.Attention
+		for j := range att.Queries {
+			mapLinear(att.Queries[j], fmt.Sprintf("%s.attention.self.%d.query_proj", prefix, j), params)
+			mapLinear(att.Keys[j], fmt.Sprintf("%s.attention.self.%d.key_proj", prefix, j), params)
+			mapLinear(att.Values[j], fmt.Sprintf("%s.attention.self.%d.value_proj", prefix, j), params)
+		}
+		for j, posQuery := range att.PosQueries {
+			mapLinear(posQuery, fmt.Sprintf("%s.attention.self.%d.pos_query_proj", prefix, j), params)
+		}
+		for j, posKey := range att.PosKeys {
+			mapLinear(posKey, fmt.Sprintf("%s.attention.self.%d.pos_key_proj", prefix, j), params)
+		}
+		mapLinear(layer.SelfAttention.OutputMerge, prefix+".attention.output.dense", params)
+		params[prefix+".attention.output.LayerNorm.weight"] = layer.SelfAttention.Norm.W.Value()
+		params[prefix+".attention.output.LayerNorm.bias"] = layer.SelfAttention.Norm.B.Value()
+		mapLinear(layer.FF.MLP[0].(*linear.Model), prefix+".intermediate.dense", params)
+		mapLinear(layer.FF.MLP[2].(*linear.Model), prefix+".output.dense", params)
+		params[prefix+".output.LayerNorm.weight"] = layer.FF.Norm.W.Value()
+		params[prefix+".output.LayerNorm.bias"] = layer.FF.Norm.B.Value()
+	}
+	if m.RelNorm != nil {
+		params["encoder.LayerNorm.weight"] = m.RelNorm.W.Value()
+		params["encoder.LayerNorm.bias"] = m.RelNorm.B.Value()
+	}
+}
+
+// mapSequenceClassifier maps the parameters of the pooler and of the classifier.
+func mapSequenceClassifier(m *debertav2.ModelForSequenceClassification, params paramsMap) {
+	mapLinear(m.Pooler.Model[0].(*linear.Model), "pooler.dense", params)
+	mapLinear(m.Classifier, "classifier", params)
+}
+
+// mapTokenClassifier maps the parameters of the classifier.
+func mapTokenClassifier(m *debertav2.ModelForTokenClassification, params paramsMap) {
+	mapLinear(m.Classifier, "classifier", params)
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/debertav2"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+type paramsPostProcessing[T float.DType] struct {
+	*pytorch.ParamsProvider[T]
+	c debertav2.Config
+}
+
+// fixAttentionLayers splits the projections of the disentangled attention
+// into separate projections for each head.
+func fixAttentionLayers[T float.DType](c debertav2.Config) pytorch.PreProcessingFunc[T] {
+	return func(params *pytorch.ParamsProvider[T]) error {
+		p := paramsPostProcessing[T]{
+			ParamsProvider: params,
+			c:              c,
+		}
+		for i := 0; i < c.NumHiddenLayers; i++ {
+			prefix := fmt.Sprintf("encoder.layer.%d.attention.self", i)
+			for _, name := range []string{"query_proj", "key_proj", "value_proj", "pos_query_proj", "pos_key_proj"} {
+				p.splitHeads(prefix, name)
+			}
+		}
+		return nil
+	}
+}
+
+// splitHeads splits the weights and the biases of a projection, stacked by
+// head, into one projection for each head.
+func (p *paramsPostProcessing[T]) splitHeads(prefix, name string) {
+	weight := p.Pop(fmt.Sprintf("%s.%s.weight", prefix, name))
+	bias := p.Pop(fmt.Sprintf("%s.%s.bias", prefix, name))
+	if weight == nil {
+		return
+	}
+	headDim := p.c.HeadDim()
+	size := headDim * p.c.HiddenSize
+	for j := 0; j < p.c.NumAttentionHeads; j++ {
+		p.Set(fmt.Sprintf("%s.%d.%s.weight", prefix, j, name), weight[j*size:(j+1)*size])
+		if bias != nil {
+			p.Set(fmt.Sprintf("%s.%d.%s.bias", prefix, j, name), bias[j*headDim:(j+1)*headDim])
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/distilbert"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultConfigFilename is the default DistilBERT JSON configuration filename.
+	defaultConfigFilename = "config.json"
+	// defaultVocabularyFile is the default DistilBERT model's vocabulary filename.
+	defaultVocabularyFile = "vocab.txt"
+	// defaultPyModelFilename is the default DistilBERT PyTorch model filename.
+	defaultPyModelFilename = "pytorch_model.bin"
+	// defaultGoModelFilename is the default DistilBERT spaGO model filename.
+	defaultGoModelFilename = "spago_model.bin"
+)
+
+// mappingParam is a mapping between a Hugging Face Transformers parameters and Cybertron parameters.
+type mappingParam struct {
+	value   mat.Tensor
+	matched bool
+}
+
+// Convert converts a DistilBERT PyTorch model to a Spago (Cybertron) model.
+func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
+	var (
+		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
+		pyModelFilename = filepath.Join(modelDir, defaultPyModelFilename)
+		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
+		vocabFilename   = filepath.Join(modelDir, defaultVocabularyFile)
+	)
+
+	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
+		log.Info().Str("model", goModelFilename).Msg("model file already exists, skipping conversion")
+		return nil
+	}
+
+	config, err := distilbert.ConfigFromFile(configFilename)
+	if err != nil {
+		return err
+	}
+	if config.SinusoidalPosEmbds {
+		return fmt.Errorf("distilbert: sinusoidal position embeddings are not supported")
+	}
+
+	vocab, err := vocabulary.NewFromFile(vocabFilename)
+	if err != nil {
+		return err
+	}
+
+	// Enable training mode, so that we have writing permissions
+	// (for example, for embeddings storage files).
+	config.Cybertron.Training = true
+
+	pyParams := pytorch.NewParamsProvider[T]().
+		WithNameMapping(fixParamsName).
+		WithPreProcessing(fixAttentionLayers[T](config))
+
+	if err = pyParams.Load(pyModelFilename); err != nil {
+		return err
+	}
+
+	params := make(paramsMap)
+	baseModel := mapBaseModel[T](config, pyParams, params, vocab)
+	finalModel := mapSpecificArchitecture[T](baseModel, config.Architectures, params)
+
+	mapping := make(map[string]*mappingParam)
+	for k, v := range params {
+		mapping[k] = &mappingParam{value: v, matched: false}
+	}
+
+	err = pyParams.Iterate(func(name string, value []T) error {
+		param, ok := mapping[name]
+		if !ok {
+			return nil
+		}
+		if param.value.Size() != len(value) {
+			return fmt.Errorf("error setting %s: dim mismatch", name)
+		}
+		mat.SetData[T](param.value, value)
+		param.matched = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		log.Debug().Msg("Reporting possible conversion mapping anomalies")
+		for key, value := range mapping {
+			if !value.matched {
+				log.Debug().Str("parameter", key).Msg("parameter not initialized")
+			}
+		}
+		err = pyParams.Iterate(func(name string, _ []T) error {
+			if _, ok := mapping[name]; !ok {
+				log.Debug().Str("parameter", name).Msg("parameter not mapped")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Serializing model to \"%s\"... ", goModelFilename)
+	err = nn.DumpToFile(finalModel, goModelFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Done.")
+
+	return nil
+}
+
+func mapBaseModel[T float.DType](config distilbert.Config, pyParams *pytorch.ParamsProvider[T], params paramsMap, vocab *vocabulary.Vocabulary) *distilbert.Model {
+	baseModel := distilbert.New[T](config)
+	baseModel.Embeddings.Vocab = vocab
+
+	cols := config.Dim
+
+	{
+		source := pyParams.Pop("distilbert.embeddings.word_embeddings.weight")
+		for i := 0; i < config.VocabSize; i++ {
+			item, _ := baseModel.Embeddings.Tokens.Embedding(i)
+			item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*cols : (i+1)*cols])))
+		}
+	}
+
+	{
+		source := pyParams.Pop("distilbert.embeddings.position_embeddings.weight")
+		for i := 0; i < config.MaxPositionEmbeddings; i++ {
+			item, _ := baseModel.Embeddings.Positions.Embedding(i)
+			item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*cols : (i+1)*cols])))
+		}
+	}
+
+	mapEmbeddingsLayerNorm(baseModel.Embeddings.Norm, params)
+	mapEncoderParams(baseModel.Encoder, params)
+
+	return baseModel
+}
+
+func mapSpecificArchitecture[T float.DType](baseModel *distilbert.Model, architectures []string, params paramsMap) nn.Model {
+	if architectures == nil {
+		architectures = append(architectures, "DistilBertBase")
+	}
+
+	switch architectures[0] {
+	case "DistilBertBase":
+		return baseModel
+	case "DistilBertModel":
+		return distilbert.NewModelForSequenceEncoding(baseModel)
+	case "DistilBertForMaskedLM":
+		m := distilbert.NewModelForMaskedLM[T](baseModel)
+		mapMaskedLM(m.Layers, params)
+		return m
+	case "DistilBertForQuestionAnswering":
+		m := distilbert.NewModelForQuestionAnswering[T](baseModel)
+		mapQAClassifier(m.Classifier, params)
+		return m
+	case "DistilBertForSequenceClassification":
+		m := distilbert.NewModelForSequenceClassification[T](baseModel)
+		mapSeqClassifier(m.Classifier, params)
+		return m
+	case "DistilBertForTokenClassification":
+		m := distilbert.NewModelForTokenClassification[T](baseModel)
+		mapTokenClassifier(m.Classifier, params)
+		return m
+	default:
+		panic(fmt.Errorf("distilbert: unsupported architecture %s", architectures[0]))
+	}
+}
+
+func fixParamsName(from string) (to string) {
+	to = from
+	if strings.HasPrefix(to, "embeddings.") || strings.HasPrefix(to, "transformer.") {
+		to = fmt.Sprintf("distilbert.%s", to)
+	}
+	return
+}
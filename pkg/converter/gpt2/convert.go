@@ -0,0 +1,142 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/gpt2"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultConfigFilename is the default GPT-2 JSON configuration filename.
+	defaultConfigFilename = "config.json"
+	// defaultPyModelFilename is the default GPT-2 PyTorch model filename.
+	defaultPyModelFilename = "pytorch_model.bin"
+	// defaultGoModelFilename is the default GPT-2 spaGO model filename.
+	defaultGoModelFilename = "spago_model.bin"
+)
+
+// mappingParam is a mapping between a Hugging Face Transformers parameters and Cybertron parameters.
+type mappingParam struct {
+	value   mat.Tensor
+	matched bool
+}
+
+// Convert converts a GPT-2 PyTorch model to a Spago (Cybertron) model.
+func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
+	var (
+		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
+		pyModelFilename = filepath.Join(modelDir, defaultPyModelFilename)
+		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
+	)
+
+	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
+		log.Info().Str("model", goModelFilename).Msg("model file already exists, skipping conversion")
+		return nil
+	}
+
+	config, err := gpt2.ConfigFromFile(configFilename)
+	if err != nil {
+		return err
+	}
+	if len(config.Architectures) > 0 && config.Architectures[0] != "GPT2LMHeadModel" {
+		return fmt.Errorf("gpt2: unsupported architecture %s", config.Architectures[0])
+	}
+
+	// Enable training mode, so that we have writing permissions
+	// (for example, for embeddings storage files).
+	config.Cybertron.Training = true
+
+	pyParams := pytorch.NewParamsProvider[T]().
+		WithNameMapping(fixParamsName).
+		WithPreProcessing(fixLayers[T](config))
+
+	if err = pyParams.Load(pyModelFilename); err != nil {
+		return err
+	}
+
+	m := gpt2.New[T](config)
+	gpt2ForCausalLM := gpt2.NewModelForCausalLM[T](m)
+
+	setEmbeddings(m.Tokens, pyParams.Get("wte.weight"))
+	setEmbeddings(m.Positions, pyParams.Get("wpe.weight"))
+
+	params := make(paramsMap)
+	mapBlocks(m, params)
+	mapProjectionLayer(gpt2ForCausalLM.Projection, params)
+
+	mapping := make(map[string]*mappingParam)
+	for k, v := range params {
+		mapping[k] = &mappingParam{value: v, matched: false}
+	}
+
+	err = pyParams.Iterate(func(name string, value []T) error {
+		param, ok := mapping[name]
+		if !ok {
+			return nil
+		}
+		if param.value.Size() != len(value) {
+			return fmt.Errorf("error setting %s: dim mismatch", name)
+		}
+		mat.SetData[T](param.value, value)
+		param.matched = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		log.Debug().Msg("Reporting possible conversion mapping anomalies")
+		for key, value := range mapping {
+			if !value.matched {
+				log.Debug().Str("parameter", key).Msg("parameter not initialized")
+			}
+		}
+		err = pyParams.Iterate(func(name string, _ []T) error {
+			if _, ok := mapping[name]; !ok {
+				log.Debug().Str("parameter", name).Msg("parameter not mapped")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Serializing model to \"%s\"... ", goModelFilename)
+	err = nn.DumpToFile(gpt2ForCausalLM, goModelFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Done.")
+
+	return nil
+}
+
+func fixParamsName(from string) string {
+	return strings.TrimPrefix(from, "transformer.")
+}
+
+// setEmbeddings copies the source weights, row by row, into the embeddings.
+func setEmbeddings[T float.DType](dest *embedding.Model, source []T) {
+	size := dest.Dim
+	for i := 0; i < dest.Size; i++ {
+		item, _ := dest.Embedding(i)
+		item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*size : (i+1)*size])))
+	}
+}
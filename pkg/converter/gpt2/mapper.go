@@ -0,0 +1,49 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/gpt2"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapProjectionLayer maps the projection layer parameters.
+func mapProjectionLayer(model *linear.Model, params paramsMap) {
+	params["lm_head.weight"] = model.W.Value()
+}
+
+// mapBlocks maps the parameters of the transformer blocks and of the final normalization.
+func mapBlocks(m *gpt2.Model, params paramsMap) {
+	for i, layer := range m.Layers {
+		prefix := fmt.Sprintf("h.%d", i)
+		params[fmt.Sprintf("%s.ln_1.weight", prefix)] = layer.AttentionNorm.W.Value()
+		params[fmt.Sprintf("%s.ln_1.bias", prefix)] = layer.AttentionNorm.B.Value()
+		for j, head := range layer.Attention.Heads {
+			headPrefix := fmt.Sprintf("%s.attn.%d", prefix, j)
+			params[fmt.Sprintf("%s.query.weight", headPrefix)] = head.Query.W.Value()
+			params[fmt.Sprintf("%s.query.bias", headPrefix)] = head.Query.B.Value()
+			params[fmt.Sprintf("%s.key.weight", headPrefix)] = head.Key.W.Value()
+			params[fmt.Sprintf("%s.key.bias", headPrefix)] = head.Key.B.Value()
+			params[fmt.Sprintf("%s.value.weight", headPrefix)] = head.Value.W.Value()
+			params[fmt.Sprintf("%s.value.bias", headPrefix)] = head.Value.B.Value()
+		}
+		params[fmt.Sprintf("%s.attn.c_proj.weight", prefix)] = layer.Attention.OutputMerge.W.Value()
+		params[fmt.Sprintf("%s.attn.c_proj.bias", prefix)] = layer.Attention.OutputMerge.B.Value()
+		params[fmt.Sprintf("%s.ln_2.weight", prefix)] = layer.FFNorm.W.Value()
+		params[fmt.Sprintf("%s.ln_2.bias", prefix)] = layer.FFNorm.B.Value()
+		params[fmt.Sprintf("%s.mlp.c_fc.weight", prefix)] = layer.FF[0].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.mlp.c_fc.bias", prefix)] = layer.FF[0].(*linear.Model).B.Value()
+		params[fmt.Sprintf("%s.mlp.c_proj.weight", prefix)] = layer.FF[2].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.mlp.c_proj.bias", prefix)] = layer.FF[2].(*linear.Model).B.Value()
+	}
+	params["ln_f.weight"] = m.LayerNorm.W.Value()
+	params["ln_f.bias"] = m.LayerNorm.B.Value()
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/gpt2"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+type paramsPostProcessing[T float.DType] struct {
+	*pytorch.ParamsProvider[T]
+	c gpt2.Config
+}
+
+// fixLayers adapts the GPT-2 Conv1D layers, whose weights are stored as
+// (input, output) matrices, to linear layers, and splits the fused query,
+// key and value projections into separate projections for each head.
+func fixLayers[T float.DType](c gpt2.Config) pytorch.PreProcessingFunc[T] {
+	return func(params *pytorch.ParamsProvider[T]) error {
+		p := paramsPostProcessing[T]{
+			ParamsProvider: params,
+			c:              c,
+		}
+		for i := 0; i < c.NLayer; i++ {
+			p.fixAttention(i)
+			p.transpose(fmt.Sprintf("h.%d.attn.c_proj.weight", i), c.NEmbd, c.NEmbd)
+			p.transpose(fmt.Sprintf("h.%d.mlp.c_fc.weight", i), c.NEmbd, c.NInner)
+			p.transpose(fmt.Sprintf("h.%d.mlp.c_proj.weight", i), c.NInner, c.NEmbd)
+		}
+		p.tieProjection()
+		return nil
+	}
+}
+
+func (p *paramsPostProcessing[T]) fixAttention(layer int) {
+	prefix := fmt.Sprintf("h.%d.attn", layer)
+	weight := p.Pop(fmt.Sprintf("%s.c_attn.weight", prefix))
+	bias := p.Pop(fmt.Sprintf("%s.c_attn.bias", prefix))
+
+	n := p.c.NEmbd
+	dim := n / p.c.NHead
+	names := []string{"query", "key", "value"}
+	for j := 0; j < p.c.NHead; j++ {
+		newPrefix := fmt.Sprintf("%s.%d", prefix, j)
+		for s, name := range names {
+			offset := s*n + j*dim
+			w := make([]T, dim*n)
+			for r := 0; r < dim; r++ {
+				for c := 0; c < n; c++ {
+					w[r*n+c] = weight[c*3*n+offset+r]
+				}
+			}
+			p.Set(fmt.Sprintf("%s.%s.weight", newPrefix, name), w)
+			p.Set(fmt.Sprintf("%s.%s.bias", newPrefix, name), bias[offset:offset+dim])
+		}
+	}
+}
+
+// transpose replaces the (rows x cols) weights with the given name with their transpose.
+func (p *paramsPostProcessing[T]) transpose(name string, rows, cols int) {
+	weight := p.Get(name)
+	if weight == nil {
+		return
+	}
+	t := make([]T, len(weight))
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			t[c*rows+r] = weight[r*cols+c]
+		}
+	}
+	p.Set(name, t)
+}
+
+// tieProjection sets the language modeling head weights to the token
+// embeddings when the checkpoint omits them because they are tied.
+func (p *paramsPostProcessing[T]) tieProjection() {
+	if p.Get("lm_head.weight") != nil {
+		return
+	}
+	embeddings := p.Get("wte.weight")
+	p.Set("lm_head.weight", append([]T(nil), embeddings...))
+}
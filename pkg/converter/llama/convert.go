@@ -0,0 +1,173 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/converter/safetensors"
+	"github.com/nlpodyssey/cybertron/pkg/models/llama"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultConfigFilename is the default Llama JSON configuration filename.
+	defaultConfigFilename = "config.json"
+	// defaultSafetensorsFilename is the default Llama safetensors model filename.
+	defaultSafetensorsFilename = "model.safetensors"
+	// defaultSafetensorsIndexFilename is the default index filename of a Llama
+	// model sharded in multiple safetensors files.
+	defaultSafetensorsIndexFilename = "model.safetensors.index.json"
+	// defaultPyModelFilename is the default Llama PyTorch model filename.
+	defaultPyModelFilename = "pytorch_model.bin"
+	// defaultGoModelFilename is the default Llama spaGO model filename.
+	defaultGoModelFilename = "spago_model.bin"
+)
+
+// mappingParam is a mapping between a Hugging Face Transformers parameters and Cybertron parameters.
+type mappingParam struct {
+	value   mat.Tensor
+	matched bool
+}
+
+// Convert converts a Llama model to a Spago (Cybertron) model.
+// The weights are read from safetensors files, possibly sharded, or else
+// from a PyTorch model file.
+func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
+	var (
+		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
+		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
+	)
+
+	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
+		log.Info().Str("model", goModelFilename).Msg("model file already exists, skipping conversion")
+		return nil
+	}
+
+	config, err := llama.ConfigFromFile(configFilename)
+	if err != nil {
+		return err
+	}
+	if len(config.Architectures) > 0 && config.Architectures[0] != "LlamaForCausalLM" {
+		return fmt.Errorf("llama: unsupported architecture %s", config.Architectures[0])
+	}
+
+	// Enable training mode, so that we have writing permissions
+	// (for example, for embeddings storage files).
+	config.Cybertron.Training = true
+
+	pyParams := pytorch.NewParamsProvider[T]().
+		WithNameMapping(fixParamsName).
+		WithPreProcessing(fixLayers[T](config))
+
+	if err = loadParams(pyParams, modelDir); err != nil {
+		return err
+	}
+
+	m := llama.New[T](config)
+	llamaForCausalLM := llama.NewModelForCausalLM[T](m)
+
+	setEmbeddings(m.Embeddings, pyParams.Get("embed_tokens.weight"))
+
+	params := make(paramsMap)
+	mapLayers(m, params)
+	mapProjectionLayer(llamaForCausalLM.Projection, params)
+
+	mapping := make(map[string]*mappingParam)
+	for k, v := range params {
+		mapping[k] = &mappingParam{value: v, matched: false}
+	}
+
+	err = pyParams.Iterate(func(name string, value []T) error {
+		param, ok := mapping[name]
+		if !ok {
+			return nil
+		}
+		if param.value.Size() != len(value) {
+			return fmt.Errorf("error setting %s: dim mismatch", name)
+		}
+		mat.SetData[T](param.value, value)
+		param.matched = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		log.Debug().Msg("Reporting possible conversion mapping anomalies")
+		for key, value := range mapping {
+			if !value.matched {
+				log.Debug().Str("parameter", key).Msg("parameter not initialized")
+			}
+		}
+		err = pyParams.Iterate(func(name string, _ []T) error {
+			if _, ok := mapping[name]; !ok {
+				log.Debug().Str("parameter", name).Msg("parameter not mapped")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Serializing model to \"%s\"... ", goModelFilename)
+	err = nn.DumpToFile(llamaForCausalLM, goModelFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Done.")
+
+	return nil
+}
+
+// loadParams loads the parameters from the first available model file:
+// a single safetensors file, an index of safetensors shards, or a PyTorch model.
+func loadParams[T float.DType](params *pytorch.ParamsProvider[T], modelDir string) error {
+	if filename := filepath.Join(modelDir, defaultSafetensorsFilename); fileExists(filename) {
+		return params.LoadSafetensors(filename)
+	}
+	if filename := filepath.Join(modelDir, defaultSafetensorsIndexFilename); fileExists(filename) {
+		index, err := safetensors.ReadIndex(filename)
+		if err != nil {
+			return err
+		}
+		filenames := index.Filenames()
+		for i, name := range filenames {
+			filenames[i] = filepath.Join(modelDir, name)
+		}
+		return params.LoadSafetensors(filenames...)
+	}
+	return params.Load(filepath.Join(modelDir, defaultPyModelFilename))
+}
+
+func fileExists(filename string) bool {
+	info, err := os.Stat(filename)
+	return err == nil && !info.IsDir()
+}
+
+func fixParamsName(from string) string {
+	return strings.TrimPrefix(from, "model.")
+}
+
+// setEmbeddings copies the source weights, row by row, into the embeddings.
+func setEmbeddings[T float.DType](dest *embedding.Model, source []T) {
+	size := dest.Dim
+	for i := 0; i < dest.Size; i++ {
+		item, _ := dest.Embedding(i)
+		item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*size : (i+1)*size])))
+	}
+}
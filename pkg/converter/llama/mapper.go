@@ -0,0 +1,44 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/llama"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapProjectionLayer maps the projection layer parameters.
+func mapProjectionLayer(model *linear.Model, params paramsMap) {
+	params["lm_head.weight"] = model.W.Value()
+}
+
+// mapLayers maps the parameters of the decoder layers and of the final normalization.
+func mapLayers(m *llama.Model, params paramsMap) {
+	for i, layer := range m.Layers {
+		prefix := fmt.Sprintf("layers.%d", i)
+		params[fmt.Sprintf("%s.input_layernorm.weight", prefix)] = layer.AttentionNorm.W.Value()
+		for j, query := range layer.Attention.Queries {
+			params[fmt.Sprintf("%s.self_attn.%d.q_proj.weight", prefix, j)] = query.W.Value()
+		}
+		for j, key := range layer.Attention.Keys {
+			params[fmt.Sprintf("%s.self_attn.%d.k_proj.weight", prefix, j)] = key.W.Value()
+		}
+		for j, value := range layer.Attention.Values {
+			params[fmt.Sprintf("%s.self_attn.%d.v_proj.weight", prefix, j)] = value.W.Value()
+		}
+		params[fmt.Sprintf("%s.self_attn.o_proj.weight", prefix)] = layer.Attention.OutputMerge.W.Value()
+		params[fmt.Sprintf("%s.post_attention_layernorm.weight", prefix)] = layer.MLPNorm.W.Value()
+		params[fmt.Sprintf("%s.mlp.gate_proj.weight", prefix)] = layer.MLP.Gate.W.Value()
+		params[fmt.Sprintf("%s.mlp.up_proj.weight", prefix)] = layer.MLP.Up.W.Value()
+		params[fmt.Sprintf("%s.mlp.down_proj.weight", prefix)] = layer.MLP.Down.W.Value()
+	}
+	params["norm.weight"] = m.Norm.W.Value()
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/llama"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+type paramsPostProcessing[T float.DType] struct {
+	*pytorch.ParamsProvider[T]
+	c llama.Config
+}
+
+// fixLayers splits the query, key and value projections into separate
+// projections for each head, and ties the language modeling head to the
+// token embeddings when needed.
+func fixLayers[T float.DType](c llama.Config) pytorch.PreProcessingFunc[T] {
+	return func(params *pytorch.ParamsProvider[T]) error {
+		p := paramsPostProcessing[T]{
+			ParamsProvider: params,
+			c:              c,
+		}
+		for i := 0; i < c.NumHiddenLayers; i++ {
+			prefix := fmt.Sprintf("layers.%d.self_attn", i)
+			p.splitHeads(prefix, "q_proj", c.NumAttentionHeads)
+			p.splitHeads(prefix, "k_proj", c.NumKeyValueHeads)
+			p.splitHeads(prefix, "v_proj", c.NumKeyValueHeads)
+		}
+		p.tieProjection()
+		return nil
+	}
+}
+
+// splitHeads splits the weights of a projection, stacked by head, into one
+// weight matrix for each head.
+func (p *paramsPostProcessing[T]) splitHeads(prefix, name string, numHeads int) {
+	weight := p.Pop(fmt.Sprintf("%s.%s.weight", prefix, name))
+	if weight == nil {
+		return
+	}
+	size := p.c.HeadDim() * p.c.HiddenSize
+	for j := 0; j < numHeads; j++ {
+		p.Set(fmt.Sprintf("%s.%d.%s.weight", prefix, j, name), weight[j*size:(j+1)*size])
+	}
+}
+
+// tieProjection sets the language modeling head weights to the token
+// embeddings when the checkpoint omits them because they are tied.
+func (p *paramsPostProcessing[T]) tieProjection() {
+	if p.Get("lm_head.weight") != nil {
+		return
+	}
+	embeddings := p.Get("embed_tokens.weight")
+	p.Set("lm_head.weight", append([]T(nil), embeddings...))
+}
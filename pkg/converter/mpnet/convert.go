@@ -0,0 +1,177 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/mpnet"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultConfigFilename is the default MPNet JSON configuration filename.
+	defaultConfigFilename = "config.json"
+	// defaultVocabularyFile is the default MPNet model's vocabulary filename.
+	defaultVocabularyFile = "vocab.txt"
+	// defaultPyModelFilename is the default MPNet PyTorch model filename.
+	defaultPyModelFilename = "pytorch_model.bin"
+	// defaultGoModelFilename is the default MPNet spaGO model filename.
+	defaultGoModelFilename = "spago_model.bin"
+)
+
+// mappingParam is a mapping between a Hugging Face Transformers parameters and Cybertron parameters.
+type mappingParam struct {
+	value   mat.Tensor
+	matched bool
+}
+
+// Convert converts a MPNet PyTorch model to a Spago (Cybertron) model.
+func Convert[T float.DType](modelDir string, overwriteIfExist bool) error {
+	var (
+		configFilename  = filepath.Join(modelDir, defaultConfigFilename)
+		pyModelFilename = filepath.Join(modelDir, defaultPyModelFilename)
+		goModelFilename = filepath.Join(modelDir, defaultGoModelFilename)
+		vocabFilename   = filepath.Join(modelDir, defaultVocabularyFile)
+	)
+
+	if info, err := os.Stat(goModelFilename); !overwriteIfExist && err == nil && !info.IsDir() {
+		log.Info().Str("model", goModelFilename).Msg("model file already exists, skipping conversion")
+		return nil
+	}
+
+	config, err := mpnet.ConfigFromFile(configFilename)
+	if err != nil {
+		return err
+	}
+
+	vocab, err := vocabulary.NewFromFile(vocabFilename)
+	if err != nil {
+		return err
+	}
+
+	// Enable training mode, so that we have writing permissions
+	// (for example, for embeddings storage files).
+	config.Cybertron.Training = true
+
+	pyParams := pytorch.NewParamsProvider[T]().
+		WithNameMapping(fixParamsName).
+		WithPreProcessing(fixAttentionLayers[T](config))
+
+	if err = pyParams.Load(pyModelFilename); err != nil {
+		return err
+	}
+
+	params := make(paramsMap)
+	baseModel := mapBaseModel[T](config, pyParams, params, vocab)
+	finalModel := mapSpecificArchitecture[T](baseModel, config.Architectures, params)
+
+	mapping := make(map[string]*mappingParam)
+	for k, v := range params {
+		mapping[k] = &mappingParam{value: v, matched: false}
+	}
+
+	err = pyParams.Iterate(func(name string, value []T) error {
+		param, ok := mapping[name]
+		if !ok {
+			return nil
+		}
+		if param.value.Size() != len(value) {
+			return fmt.Errorf("error setting %s: dim mismatch", name)
+		}
+		mat.SetData[T](param.value, value)
+		param.matched = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		log.Debug().Msg("Reporting possible conversion mapping anomalies")
+		for key, value := range mapping {
+			if !value.matched {
+				log.Debug().Str("parameter", key).Msg("parameter not initialized")
+			}
+		}
+		err = pyParams.Iterate(func(name string, _ []T) error {
+			if _, ok := mapping[name]; !ok {
+				log.Debug().Str("parameter", name).Msg("parameter not mapped")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Serializing model to \"%s\"... ", goModelFilename)
+	err = nn.DumpToFile(finalModel, goModelFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Done.")
+
+	return nil
+}
+
+func mapBaseModel[T float.DType](config mpnet.Config, pyParams *pytorch.ParamsProvider[T], params paramsMap, vocab *vocabulary.Vocabulary) *mpnet.Model {
+	baseModel := mpnet.New[T](config)
+	baseModel.Embeddings.Vocab = vocab
+
+	setEmbeddings(baseModel.Embeddings.Tokens, pyParams.Pop("mpnet.embeddings.word_embeddings.weight"))
+	setEmbeddings(baseModel.Embeddings.Positions, pyParams.Pop("mpnet.embeddings.position_embeddings.weight"))
+	setEmbeddings(baseModel.Encoder.PositionBias.Embeddings, pyParams.Pop("mpnet.encoder.relative_attention_bias.weight"))
+
+	mapEmbeddingsLayerNorm(baseModel.Embeddings.Norm, params)
+	mapEncoderParams(baseModel.Encoder, params)
+	mapPooler(baseModel.Pooler, params)
+
+	return baseModel
+}
+
+func mapSpecificArchitecture[T float.DType](baseModel *mpnet.Model, architectures []string, params paramsMap) nn.Model {
+	if architectures == nil {
+		architectures = append(architectures, "MPNetBase")
+	}
+
+	switch architectures[0] {
+	case "MPNetBase":
+		return baseModel
+	case "MPNetModel":
+		return mpnet.NewModelForSequenceEncoding(baseModel)
+	default:
+		panic(fmt.Errorf("mpnet: unsupported architecture %s", architectures[0]))
+	}
+}
+
+// setEmbeddings sets the embeddings of dest from the source weights, row by row.
+func setEmbeddings[T float.DType](dest *embedding.Model, source []T) {
+	size := dest.Dim
+	for i := 0; i < dest.Size; i++ {
+		item, _ := dest.Embedding(i)
+		item.ReplaceValue(mat.NewDense[T](mat.WithBacking(source[i*size : (i+1)*size])))
+	}
+}
+
+func fixParamsName(from string) (to string) {
+	to = from
+	if strings.HasPrefix(to, "embeddings.") || strings.HasPrefix(to, "encoder.") || strings.HasPrefix(to, "pooler.") {
+		// The sentence-transformers checkpoints omit the "mpnet." prefix.
+		to = fmt.Sprintf("mpnet.%s", to)
+	}
+	return
+}
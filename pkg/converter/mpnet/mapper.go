@@ -0,0 +1,54 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/mpnet"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+type paramsMap map[string]mat.Tensor
+
+func mapEncoderParams(encoder *mpnet.Encoder, params paramsMap) {
+	for i, layer := range encoder.Layers {
+		prefixBase := fmt.Sprintf("mpnet.encoder.layer.%d", i)
+
+		for j, head := range layer.Attention.Heads {
+			prefix := fmt.Sprintf("%s.%d.attention.attn", prefixBase, j)
+			params[fmt.Sprintf("%s.q.weight", prefix)] = head.Query.W.Value()
+			params[fmt.Sprintf("%s.q.bias", prefix)] = head.Query.B.Value()
+			params[fmt.Sprintf("%s.k.weight", prefix)] = head.Key.W.Value()
+			params[fmt.Sprintf("%s.k.bias", prefix)] = head.Key.B.Value()
+			params[fmt.Sprintf("%s.v.weight", prefix)] = head.Value.W.Value()
+			params[fmt.Sprintf("%s.v.bias", prefix)] = head.Value.B.Value()
+		}
+		params[fmt.Sprintf("%s.attention.attn.o.weight", prefixBase)] = layer.Attention.OutputMerge.W.Value()
+		params[fmt.Sprintf("%s.attention.attn.o.bias", prefixBase)] = layer.Attention.OutputMerge.B.Value()
+		params[fmt.Sprintf("%s.attention.LayerNorm.weight", prefixBase)] = layer.AttentionNorm.W.Value()
+		params[fmt.Sprintf("%s.attention.LayerNorm.bias", prefixBase)] = layer.AttentionNorm.B.Value()
+
+		block := layer.FF
+		params[fmt.Sprintf("%s.intermediate.dense.weight", prefixBase)] = block.MLP[0].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.intermediate.dense.bias", prefixBase)] = block.MLP[0].(*linear.Model).B.Value()
+		params[fmt.Sprintf("%s.output.dense.weight", prefixBase)] = block.MLP[2].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.output.dense.bias", prefixBase)] = block.MLP[2].(*linear.Model).B.Value()
+		params[fmt.Sprintf("%s.output.LayerNorm.weight", prefixBase)] = block.Norm.W.Value()
+		params[fmt.Sprintf("%s.output.LayerNorm.bias", prefixBase)] = block.Norm.B.Value()
+	}
+}
+
+func mapEmbeddingsLayerNorm(embeddingsNorm *layernorm.Model, params paramsMap) {
+	params["mpnet.embeddings.LayerNorm.weight"] = embeddingsNorm.W.Value()
+	params["mpnet.embeddings.LayerNorm.bias"] = embeddingsNorm.B.Value()
+}
+
+func mapPooler(pooler *mpnet.Pooler, params paramsMap) {
+	params["mpnet.pooler.dense.weight"] = pooler.Model[0].(*linear.Model).W.Value()
+	params["mpnet.pooler.dense.bias"] = pooler.Model[0].(*linear.Model).B.Value()
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/mpnet"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+type paramsPostProcessing[T float.DType] struct {
+	*pytorch.ParamsProvider[T]
+	c mpnet.Config
+}
+
+func fixAttentionLayers[T float.DType](c mpnet.Config) pytorch.PreProcessingFunc[T] {
+	return func(params *pytorch.ParamsProvider[T]) error {
+		p := paramsPostProcessing[T]{
+			ParamsProvider: params,
+			c:              c,
+		}
+		p.fixEncoderSelfAttention()
+		return nil
+	}
+}
+
+// fixEncoderSelfAttention splits the query, key and value projections by head.
+func (p *paramsPostProcessing[T]) fixEncoderSelfAttention() {
+	for i := 0; i < p.c.NumHiddenLayers; i++ {
+		prefix := fmt.Sprintf("mpnet.encoder.layer.%d.attention.attn", i)
+		for _, name := range []string{"q", "k", "v"} {
+			weight := p.Pop(fmt.Sprintf("%s.%s.weight", prefix, name))
+			bias := p.Pop(fmt.Sprintf("%s.%s.bias", prefix, name))
+
+			dim := len(bias) / p.c.NumAttentionHeads
+			dim2 := len(bias)
+			for j := 0; j < p.c.NumAttentionHeads; j++ {
+				from := j * dim
+				to := (j + 1) * dim
+				newPrefix := fmt.Sprintf("mpnet.encoder.layer.%d.%d.attention.attn", i, j)
+				p.Set(fmt.Sprintf("%s.%s.weight", newPrefix, name), weight[from*dim2:to*dim2])
+				p.Set(fmt.Sprintf("%s.%s.bias", newPrefix, name), bias[from:to])
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ type ParamsProvider[T float.DType] struct {
 	paramsData    map[string][]T
 	nameMapping   MappingFunc
 	preProcessing PreProcessingFunc[T]
+	allTensors    bool
 }
 
 // MappingFunc is a function that maps a parameter name to another name.
@@ -36,6 +37,13 @@ func (p *ParamsProvider[T]) WithNameMapping(fn MappingFunc) *ParamsProvider[T] {
 	return p
 }
 
+// WithAllTensors enables the loading of scalars and higher-dimensional tensors
+// (e.g. convolution kernels), flattened in row-major order, which are skipped by default.
+func (p *ParamsProvider[T]) WithAllTensors() *ParamsProvider[T] {
+	p.allTensors = true
+	return p
+}
+
 // WithPreProcessing sets the parameters pre-processing function.
 func (p *ParamsProvider[T]) WithPreProcessing(fn PreProcessingFunc[T]) *ParamsProvider[T] {
 	p.preProcessing = fn
@@ -50,9 +58,17 @@ func (p *ParamsProvider[T]) Load(filename string) error {
 	}
 	fn := func(name string, tensor *pytorch.Tensor) {
 		if _, ok := tensor.Source.(*pytorch.FloatStorage); ok {
+			if !p.allTensors && (len(tensor.Size) == 0 || len(tensor.Size) > 2) {
+				// Skip scalars and higher-dimensional buffers, such as pre-computed attention masks.
+				return
+			}
 			if p.nameMapping != nil {
 				name = p.nameMapping(name)
 			}
+			if len(tensor.Size) == 0 || len(tensor.Size) > 2 {
+				p.paramsData[name] = flatData[T](tensor)
+				return
+			}
 			p.paramsData[name] = data[T](tensor)
 		}
 	}
@@ -129,6 +145,26 @@ func data[T float.DType](t *pytorch.Tensor) []T {
 	return data
 }
 
+// flatData returns the underlying values of a scalar or a higher-dimensional
+// PyTorch tensor as a T slice, in row-major order.
+// Only contiguous tensors are supported.
+func flatData[T float.DType](t *pytorch.Tensor) []T {
+	size, stride := 1, 1
+	for i := len(t.Size) - 1; i >= 0; i-- {
+		if t.Size[i] > 1 && t.Stride[i] != stride {
+			panic("gopickleutils: non-contiguous tensors not supported")
+		}
+		stride *= t.Size[i]
+		size *= t.Size[i]
+	}
+	orig := t.Source.(*pytorch.FloatStorage).Data[t.StorageOffset : t.StorageOffset+size]
+	data := make([]T, len(orig))
+	for i, val := range orig {
+		data[i] = T(val)
+	}
+	return data
+}
+
 // Iterate iterates over all the parameters in the provider.
 func (p *ParamsProvider[T]) Iterate(fn func(name string, data []T) error) error {
 	for name, data := range p.paramsData {
@@ -0,0 +1,35 @@
+// Copyright 2022 spaGO Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pytorch
+
+import (
+	"github.com/nlpodyssey/cybertron/pkg/converter/safetensors"
+)
+
+// LoadSafetensors loads parameters from one or more safetensors files,
+// such as the shards of a single checkpoint.
+// The pre-processing function is applied once, after all files are loaded.
+func (p *ParamsProvider[T]) LoadSafetensors(filenames ...string) error {
+	for _, filename := range filenames {
+		err := safetensors.Read[T](filename, func(name string, shape []int, data []T) error {
+			if !p.allTensors && (len(shape) == 0 || len(shape) > 2) {
+				// Skip scalars and higher-dimensional buffers, as in Load.
+				return nil
+			}
+			if p.nameMapping != nil {
+				name = p.nameMapping(name)
+			}
+			p.paramsData[name] = data
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if p.preProcessing == nil {
+		return nil
+	}
+	return p.preProcessing(p)
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package safetensors implements a reader for the safetensors format.
+// https://github.com/huggingface/safetensors
+package safetensors
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+// metadataKey is the header key reserved for free-form metadata.
+const metadataKey = "__metadata__"
+
+// TensorInfo describes a tensor stored in a safetensors file.
+type TensorInfo struct {
+	// DType is the data type of the tensor (e.g. "F32", "F16", "BF16").
+	DType string `json:"dtype"`
+	// Shape is the shape of the tensor.
+	Shape []int `json:"shape"`
+	// DataOffsets are the begin and end offsets of the tensor data, relative
+	// to the beginning of the byte buffer following the header.
+	DataOffsets [2]int64 `json:"data_offsets"`
+}
+
+// Index is the content of an index file of a checkpoint sharded in multiple
+// safetensors files (e.g. "model.safetensors.index.json").
+type Index struct {
+	// WeightMap maps each tensor name to the name of the file containing it.
+	WeightMap map[string]string `json:"weight_map"`
+}
+
+// ReadIndex reads the index of a sharded checkpoint from file.
+func ReadIndex(filename string) (Index, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return Index{}, err
+	}
+	defer f.Close()
+
+	var index Index
+	if err = json.NewDecoder(f).Decode(&index); err != nil {
+		return Index{}, fmt.Errorf("safetensors: error decoding index %#v: %w", filename, err)
+	}
+	return index, nil
+}
+
+// Filenames returns the sorted, distinct names of the files referenced by the index.
+func (idx Index) Filenames() []string {
+	set := make(map[string]struct{})
+	for _, filename := range idx.WeightMap {
+		set[filename] = struct{}{}
+	}
+	filenames := make([]string, 0, len(set))
+	for filename := range set {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+// Read reads a safetensors file, calling fn for each tensor, in the order
+// in which they are stored. The data is converted to T, in row-major order.
+// The tensors are read one at a time, so that the whole file never needs to
+// be kept in memory.
+func Read[T float.DType](filename string, fn func(name string, shape []int, data []T) error) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	header, dataOffset, err := readHeader(f)
+	if err != nil {
+		return fmt.Errorf("safetensors: error reading %#v: %w", filename, err)
+	}
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return header[names[i]].DataOffsets[0] < header[names[j]].DataOffsets[0]
+	})
+
+	var buf []byte
+	for _, name := range names {
+		info := header[name]
+		begin, end := info.DataOffsets[0], info.DataOffsets[1]
+		if end < begin {
+			return fmt.Errorf("safetensors: invalid data offsets for tensor %#v", name)
+		}
+		if n := int(end - begin); cap(buf) < n {
+			buf = make([]byte, n)
+		} else {
+			buf = buf[:n]
+		}
+		if _, err = f.ReadAt(buf, dataOffset+begin); err != nil {
+			return fmt.Errorf("safetensors: error reading tensor %#v: %w", name, err)
+		}
+		data, err := decode[T](info, buf)
+		if err != nil {
+			return fmt.Errorf("safetensors: tensor %#v: %w", name, err)
+		}
+		if err = fn(name, info.Shape, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readHeader reads the JSON header, returning the tensors descriptions
+// and the absolute offset of the byte buffer.
+func readHeader(r io.Reader) (map[string]TensorInfo, int64, error) {
+	var size uint64
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, 0, err
+	}
+	raw := make([]byte, size)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, 0, err
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, 0, err
+	}
+	header := make(map[string]TensorInfo, len(entries))
+	for name, entry := range entries {
+		if name == metadataKey {
+			continue
+		}
+		var info TensorInfo
+		if err := json.Unmarshal(entry, &info); err != nil {
+			return nil, 0, fmt.Errorf("error decoding tensor %#v: %w", name, err)
+		}
+		header[name] = info
+	}
+	return header, int64(8 + size), nil
+}
+
+// decode converts the little-endian raw data of a tensor to T.
+func decode[T float.DType](info TensorInfo, raw []byte) ([]T, error) {
+	size := 1
+	for _, dim := range info.Shape {
+		size *= dim
+	}
+	var width int
+	switch info.DType {
+	case "F64":
+		width = 8
+	case "F32":
+		width = 4
+	case "F16", "BF16":
+		width = 2
+	default:
+		return nil, fmt.Errorf("unsupported data type %s", info.DType)
+	}
+	if len(raw) != size*width {
+		return nil, fmt.Errorf("data size mismatch: %d bytes for %d %s values", len(raw), size, info.DType)
+	}
+
+	data := make([]T, size)
+	for i := range data {
+		b := raw[i*width : (i+1)*width]
+		switch info.DType {
+		case "F64":
+			data[i] = T(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		case "F32":
+			data[i] = T(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case "F16":
+			data[i] = T(float16ToFloat32(binary.LittleEndian.Uint16(b)))
+		case "BF16":
+			data[i] = T(math.Float32frombits(uint32(binary.LittleEndian.Uint16(b)) << 16))
+		}
+	}
+	return data, nil
+}
+
+// float16ToFloat32 converts an IEEE 754 half-precision value to float32.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+
+	switch {
+	case exp == 0x1f: // infinity or NaN
+		return math.Float32frombits(sign | 0x7f800000 | frac<<13)
+	case exp == 0 && frac == 0: // signed zero
+		return math.Float32frombits(sign)
+	case exp == 0: // subnormal: normalize it
+		e := uint32(127 - 15 + 1)
+		for frac&0x400 == 0 {
+			frac <<= 1
+			e--
+		}
+		return math.Float32frombits(sign | e<<23 | (frac&0x3ff)<<13)
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | frac<<13)
+	}
+}
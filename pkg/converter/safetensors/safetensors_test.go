@@ -0,0 +1,69 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safetensors
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	header := `{"__metadata__":{"format":"pt"},` +
+		`"b":{"dtype":"BF16","shape":[2],"data_offsets":[12,16]},` +
+		`"a":{"dtype":"F32","shape":[1,2],"data_offsets":[0,8]},` +
+		`"c":{"dtype":"F16","shape":[2],"data_offsets":[8,12]}}`
+
+	var data []byte
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(header)))
+	data = append(data, header...)
+	data = binary.LittleEndian.AppendUint32(data, math.Float32bits(1.5))
+	data = binary.LittleEndian.AppendUint32(data, math.Float32bits(-2))
+	data = binary.LittleEndian.AppendUint16(data, 0x3c00) // F16 1.0
+	data = binary.LittleEndian.AppendUint16(data, 0xc100) // F16 -2.5
+	data = binary.LittleEndian.AppendUint16(data, 0x4040) // BF16 3.0
+	data = binary.LittleEndian.AppendUint16(data, 0xbf80) // BF16 -1.0
+
+	filename := filepath.Join(t.TempDir(), "model.safetensors")
+	require.NoError(t, os.WriteFile(filename, data, 0644))
+
+	var names []string
+	values := make(map[string][]float32)
+	shapes := make(map[string][]int)
+	err := Read[float32](filename, func(name string, shape []int, data []float32) error {
+		names = append(names, name)
+		values[name], shapes[name] = data, shape
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "c", "b"}, names)
+	assert.Equal(t, []float32{1.5, -2}, values["a"])
+	assert.Equal(t, []int{1, 2}, shapes["a"])
+	assert.Equal(t, []float32{1, -2.5}, values["c"])
+	assert.Equal(t, []float32{3, -1}, values["b"])
+}
+
+func TestFloat16ToFloat32(t *testing.T) {
+	tests := []struct {
+		h uint16
+		f float32
+	}{
+		{0x0000, 0},
+		{0x3c00, 1},
+		{0x3555, 0.333251953125},
+		{0x7bff, 65504},
+		{0x0001, 5.960464477539063e-08},
+		{0xfc00, float32(math.Inf(-1))},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.f, float16ToFloat32(tt.h), "0x%04x", tt.h)
+	}
+}
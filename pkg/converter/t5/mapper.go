@@ -0,0 +1,68 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/t5"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapProjectionLayer maps the projection layer parameters.
+func mapProjectionLayer(model *linear.Model, params paramsMap) {
+	params["lm_head.weight"] = model.W.Value()
+}
+
+// mapEncoderParams maps the encoder parameters.
+func mapEncoderParams(encoder *t5.Encoder, params paramsMap) {
+	for i, layer := range encoder.Layers {
+		prefix := fmt.Sprintf("encoder.block.%d", i)
+		mapAttention(layer.SelfAttention, fmt.Sprintf("%s.layer.0.SelfAttention", prefix), params)
+		params[fmt.Sprintf("%s.layer.0.layer_norm.weight", prefix)] = layer.SelfAttentionNorm.W.Value()
+		mapFeedForward(layer.FF, fmt.Sprintf("%s.layer.1.DenseReluDense", prefix), params)
+		params[fmt.Sprintf("%s.layer.1.layer_norm.weight", prefix)] = layer.FFNorm.W.Value()
+	}
+	params["encoder.final_layer_norm.weight"] = encoder.LayerNorm.W.Value()
+}
+
+// mapDecoderParams maps the decoder parameters.
+func mapDecoderParams(decoder *t5.Decoder, params paramsMap) {
+	for i, layer := range decoder.Layers {
+		prefix := fmt.Sprintf("decoder.block.%d", i)
+		mapAttention(layer.SelfAttention, fmt.Sprintf("%s.layer.0.SelfAttention", prefix), params)
+		params[fmt.Sprintf("%s.layer.0.layer_norm.weight", prefix)] = layer.SelfAttentionNorm.W.Value()
+		mapAttention(layer.CrossAttention, fmt.Sprintf("%s.layer.1.EncDecAttention", prefix), params)
+		params[fmt.Sprintf("%s.layer.1.layer_norm.weight", prefix)] = layer.CrossAttentionNorm.W.Value()
+		mapFeedForward(layer.FF, fmt.Sprintf("%s.layer.2.DenseReluDense", prefix), params)
+		params[fmt.Sprintf("%s.layer.2.layer_norm.weight", prefix)] = layer.FFNorm.W.Value()
+	}
+	params["decoder.final_layer_norm.weight"] = decoder.LayerNorm.W.Value()
+}
+
+// mapAttention maps the parameters of the attention identified by prefix.
+func mapAttention(attention *t5.Attention, prefix string, params paramsMap) {
+	for j, head := range attention.Heads {
+		params[fmt.Sprintf("%s.%d.q.weight", prefix, j)] = head.Query.W.Value()
+		params[fmt.Sprintf("%s.%d.k.weight", prefix, j)] = head.Key.W.Value()
+		params[fmt.Sprintf("%s.%d.v.weight", prefix, j)] = head.Value.W.Value()
+	}
+	params[fmt.Sprintf("%s.o.weight", prefix)] = attention.OutputMerge.W.Value()
+}
+
+// mapFeedForward maps the parameters of the feed-forward layer identified by prefix.
+func mapFeedForward(ff *t5.FeedForward, prefix string, params paramsMap) {
+	if ff.Gate != nil {
+		params[fmt.Sprintf("%s.wi_0.weight", prefix)] = ff.Input.W.Value()
+		params[fmt.Sprintf("%s.wi_1.weight", prefix)] = ff.Gate.W.Value()
+	} else {
+		params[fmt.Sprintf("%s.wi.weight", prefix)] = ff.Input.W.Value()
+	}
+	params[fmt.Sprintf("%s.wo.weight", prefix)] = ff.Output.W.Value()
+}
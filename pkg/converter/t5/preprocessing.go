@@ -0,0 +1,65 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/t5"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+type paramsPostProcessing[T float.DType] struct {
+	*pytorch.ParamsProvider[T]
+	c t5.Config
+}
+
+func fixAttentionLayers[T float.DType](c t5.Config) pytorch.PreProcessingFunc[T] {
+	return func(params *pytorch.ParamsProvider[T]) error {
+		p := paramsPostProcessing[T]{
+			ParamsProvider: params,
+			c:              c,
+		}
+		for i := 0; i < c.NumLayers; i++ {
+			p.splitAttentionHeads(fmt.Sprintf("encoder.block.%d.layer.0.SelfAttention", i))
+		}
+		for i := 0; i < c.NumDecoderLayers; i++ {
+			p.splitAttentionHeads(fmt.Sprintf("decoder.block.%d.layer.0.SelfAttention", i))
+			p.splitAttentionHeads(fmt.Sprintf("decoder.block.%d.layer.1.EncDecAttention", i))
+		}
+		p.tieProjection()
+		return nil
+	}
+}
+
+// splitAttentionHeads splits the query, key and value weights of the attention
+// identified by prefix into one set of weights for each head.
+func (p *paramsPostProcessing[T]) splitAttentionHeads(prefix string) {
+	queryWeight := p.Pop(fmt.Sprintf("%s.q.weight", prefix))
+	keyWeight := p.Pop(fmt.Sprintf("%s.k.weight", prefix))
+	valueWeight := p.Pop(fmt.Sprintf("%s.v.weight", prefix))
+
+	dim := p.c.DKV
+	dim2 := p.c.DModel
+	for j := 0; j < p.c.NumHeads; j++ {
+		from := j * dim
+		to := (j + 1) * dim
+		newPrefix := fmt.Sprintf("%s.%d", prefix, j)
+		p.Set(fmt.Sprintf("%s.q.weight", newPrefix), queryWeight[from*dim2:to*dim2])
+		p.Set(fmt.Sprintf("%s.k.weight", newPrefix), keyWeight[from*dim2:to*dim2])
+		p.Set(fmt.Sprintf("%s.v.weight", newPrefix), valueWeight[from*dim2:to*dim2])
+	}
+}
+
+// tieProjection sets the language modeling head weights to the shared
+// embeddings when the checkpoint omits them because they are tied.
+func (p *paramsPostProcessing[T]) tieProjection() {
+	if p.Get("lm_head.weight") != nil {
+		return
+	}
+	embeddings := p.Get("shared.weight")
+	p.Set("lm_head.weight", append([]T(nil), embeddings...))
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/vit"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapEmbeddings maps the parameters of the class token and of the patch projection.
+func mapEmbeddings(m *vit.Embeddings, params paramsMap) {
+	params["embeddings.cls_token"] = m.ClassToken.Value()
+	params["embeddings.patch_embeddings.projection.weight"] = m.Patches.W.Value()
+	params["embeddings.patch_embeddings.projection.bias"] = m.Patches.B.Value()
+}
+
+// mapEncoder maps the parameters of the encoder layers and of the final normalization.
+func mapEncoder(m *vit.Model, params paramsMap) {
+	for i, layer := range m.Layers {
+		prefix := fmt.Sprintf("encoder.layer.%d", i)
+		params[fmt.Sprintf("%s.layernorm_before.weight", prefix)] = layer.AttentionNorm.W.Value()
+		params[fmt.Sprintf("%s.layernorm_before.bias", prefix)] = layer.AttentionNorm.B.Value()
+		for j, head := range layer.Attention.Heads {
+			headPrefix := fmt.Sprintf("%s.attention.%d", prefix, j)
+			params[fmt.Sprintf("%s.query.weight", headPrefix)] = head.Query.W.Value()
+			params[fmt.Sprintf("%s.query.bias", headPrefix)] = head.Query.B.Value()
+			params[fmt.Sprintf("%s.key.weight", headPrefix)] = head.Key.W.Value()
+			params[fmt.Sprintf("%s.key.bias", headPrefix)] = head.Key.B.Value()
+			params[fmt.Sprintf("%s.value.weight", headPrefix)] = head.Value.W.Value()
+			params[fmt.Sprintf("%s.value.bias", headPrefix)] = head.Value.B.Value()
+		}
+		params[fmt.Sprintf("%s.attention.output.dense.weight", prefix)] = layer.Attention.OutputMerge.W.Value()
+		params[fmt.Sprintf("%s.attention.output.dense.bias", prefix)] = layer.Attention.OutputMerge.B.Value()
+		params[fmt.Sprintf("%s.layernorm_after.weight", prefix)] = layer.FFNorm.W.Value()
+		params[fmt.Sprintf("%s.layernorm_after.bias", prefix)] = layer.FFNorm.B.Value()
+		params[fmt.Sprintf("%s.intermediate.dense.weight", prefix)] = layer.FF[0].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.intermediate.dense.bias", prefix)] = layer.FF[0].(*linear.Model).B.Value()
+		params[fmt.Sprintf("%s.output.dense.weight", prefix)] = layer.FF[2].(*linear.Model).W.Value()
+		params[fmt.Sprintf("%s.output.dense.bias", prefix)] = layer.FF[2].(*linear.Model).B.Value()
+	}
+	params["layernorm.weight"] = m.LayerNorm.W.Value()
+	params["layernorm.bias"] = m.LayerNorm.B.Value()
+}
+
+// mapClassifier maps the parameters of the image classification head.
+func mapClassifier(m *vit.ModelForImageClassification, params paramsMap) {
+	params["classifier.weight"] = m.Classifier.W.Value()
+	params["classifier.bias"] = m.Classifier.B.Value()
+}
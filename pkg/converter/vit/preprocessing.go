@@ -0,0 +1,38 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/vit"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+// fixAttention splits the query, key and value projections of the
+// self-attention into separate projections for each head.
+func fixAttention[T float.DType](c vit.Config) pytorch.PreProcessingFunc[T] {
+	return func(p *pytorch.ParamsProvider[T]) error {
+		for i := 0; i < c.NumHiddenLayers; i++ {
+			prefix := fmt.Sprintf("encoder.layer.%d.attention", i)
+			for _, name := range []string{"query", "key", "value"} {
+				weight := p.Pop(fmt.Sprintf("%s.attention.%s.weight", prefix, name))
+				bias := p.Pop(fmt.Sprintf("%s.attention.%s.bias", prefix, name))
+				if weight == nil || bias == nil {
+					return fmt.Errorf("vit: missing %s parameters of layer %d", name, i)
+				}
+				dim := c.HiddenSize / c.NumAttentionHeads
+				for j := 0; j < c.NumAttentionHeads; j++ {
+					from, to := j*dim, (j+1)*dim
+					newPrefix := fmt.Sprintf("%s.%d.%s", prefix, j, name)
+					p.Set(fmt.Sprintf("%s.weight", newPrefix), weight[from*c.HiddenSize:to*c.HiddenSize])
+					p.Set(fmt.Sprintf("%s.bias", newPrefix), bias[from:to])
+				}
+			}
+		}
+		return nil
+	}
+}
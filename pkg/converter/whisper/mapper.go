@@ -0,0 +1,115 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whisper
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/cybertron/pkg/models/whisper"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+// paramsMap is a map of parameters.
+type paramsMap map[string]mat.Tensor
+
+// mapEncoder maps the parameters of the audio encoder.
+func mapEncoder(m *whisper.Encoder, params paramsMap) {
+	params["model.encoder.conv1.weight"] = m.Conv1.W.Value()
+	params["model.encoder.conv1.bias"] = m.Conv1.B.Value()
+	params["model.encoder.conv2.weight"] = m.Conv2.W.Value()
+	params["model.encoder.conv2.bias"] = m.Conv2.B.Value()
+	for i, layer := range m.Layers {
+		prefix := fmt.Sprintf("model.encoder.layers.%d", i)
+		selfAttention := resolveSelfAttentionBlock(layer.SelfAttention)
+		mapAttention(selfAttention.Attention, fmt.Sprintf("%s.self_attn", prefix), params)
+		mapNorm(selfAttention.Norm, fmt.Sprintf("%s.self_attn_layer_norm", prefix), params)
+		mapFeedForward(resolveFeedForwardBlock(layer.FF), prefix, params)
+	}
+	mapNorm(m.LayerNorm, "model.encoder.layer_norm", params)
+}
+
+// mapDecoder maps the parameters of the text decoder.
+func mapDecoder(m *bart.Decoder, params paramsMap) {
+	for i, layer := range m.Layers {
+		prefix := fmt.Sprintf("model.decoder.layers.%d", i)
+		selfAttention := resolveSelfAttentionBlock(layer.SelfAttention)
+		mapAttention(selfAttention.Attention, fmt.Sprintf("%s.self_attn", prefix), params)
+		mapNorm(selfAttention.Norm, fmt.Sprintf("%s.self_attn_layer_norm", prefix), params)
+		crossAttention := resolveCrossAttentionBlock(layer.CrossAttention)
+		mapAttention(crossAttention.Attention, fmt.Sprintf("%s.encoder_attn", prefix), params)
+		mapNorm(crossAttention.Norm, fmt.Sprintf("%s.encoder_attn_layer_norm", prefix), params)
+		mapFeedForward(resolveFeedForwardBlock(layer.FF), prefix, params)
+	}
+	mapNorm(m.LayerNorm, "model.decoder.layer_norm", params)
+}
+
+// mapAttention maps the parameters of an attention layer, split by head.
+func mapAttention(m *multiheadattention.Model, prefix string, params paramsMap) {
+	for j, head := range m.Heads {
+		headPrefix := fmt.Sprintf("%s.%d", prefix, j)
+		params[fmt.Sprintf("%s.q_proj.weight", headPrefix)] = head.Query.W.Value()
+		params[fmt.Sprintf("%s.q_proj.bias", headPrefix)] = head.Query.B.Value()
+		params[fmt.Sprintf("%s.k_proj.weight", headPrefix)] = head.Key.W.Value()
+		params[fmt.Sprintf("%s.v_proj.weight", headPrefix)] = head.Value.W.Value()
+		params[fmt.Sprintf("%s.v_proj.bias", headPrefix)] = head.Value.B.Value()
+	}
+	params[fmt.Sprintf("%s.out_proj.weight", prefix)] = m.OutputMerge.W.Value()
+	params[fmt.Sprintf("%s.out_proj.bias", prefix)] = m.OutputMerge.B.Value()
+}
+
+// mapFeedForward maps the parameters of a feed-forward block.
+func mapFeedForward(m *bart.FeedForwardBlock, prefix string, params paramsMap) {
+	params[fmt.Sprintf("%s.fc1.weight", prefix)] = m.FFN[0].(*linear.Model).W.Value()
+	params[fmt.Sprintf("%s.fc1.bias", prefix)] = m.FFN[0].(*linear.Model).B.Value()
+	params[fmt.Sprintf("%s.fc2.weight", prefix)] = m.FFN[2].(*linear.Model).W.Value()
+	params[fmt.Sprintf("%s.fc2.bias", prefix)] = m.FFN[2].(*linear.Model).B.Value()
+	mapNorm(m.Norm, fmt.Sprintf("%s.final_layer_norm", prefix), params)
+}
+
+// mapNorm maps the parameters of a layer normalization.
+func mapNorm(m *layernorm.Model, prefix string, params paramsMap) {
+	params[fmt.Sprintf("%s.weight", prefix)] = m.W.Value()
+	params[fmt.Sprintf("%s.bias", prefix)] = m.B.Value()
+}
+
+// resolveSelfAttentionBlock resolves the self attention block of the given layer.
+func resolveSelfAttentionBlock(m bart.ResidualNormSelfAttention) *bart.SelfAttentionBlock {
+	switch m := m.(type) {
+	case bart.PreNormSelfAttentionBlock:
+		return m.SelfAttentionBlock
+	case bart.PostNormSelfAttentionBlock:
+		return m.SelfAttentionBlock
+	default:
+		panic("unknown model")
+	}
+}
+
+// resolveCrossAttentionBlock resolves the cross attention block of the given layer.
+func resolveCrossAttentionBlock(m bart.ResidualNormCrossAttention) *bart.CrossAttentionBlock {
+	switch m := m.(type) {
+	case bart.PreNormCrossAttentionBlock:
+		return m.CrossAttentionBlock
+	case bart.PostNormCrossAttentionBlock:
+		return m.CrossAttentionBlock
+	default:
+		panic("unknown model")
+	}
+}
+
+// resolveFeedForwardBlock resolves the feed-forward block of the given layer.
+func resolveFeedForwardBlock(m bart.ResidualNormFeedForward) *bart.FeedForwardBlock {
+	switch m := m.(type) {
+	case bart.PreNormFeedForwardBlock:
+		return m.FeedForwardBlock
+	case bart.PostNormFeedForwardBlock:
+		return m.FeedForwardBlock
+	default:
+		panic("unknown model")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whisper
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/converter/pytorch"
+	"github.com/nlpodyssey/cybertron/pkg/models/whisper"
+	"github.com/nlpodyssey/spago/mat/float"
+)
+
+// preProcess rearranges the PyTorch parameters to match the spaGO model.
+func preProcess[T float.DType](c whisper.Config) pytorch.PreProcessingFunc[T] {
+	return func(p *pytorch.ParamsProvider[T]) error {
+		if err := fixConvolutions(p); err != nil {
+			return err
+		}
+		return fixAttention(p, c)
+	}
+}
+
+// fixConvolutions transposes the convolution kernels from the PyTorch order
+// [output channel, input channel, kernel position] to the order
+// [output channel, kernel position, input channel], so that each convolution
+// is a projection of the concatenated frames of a window.
+func fixConvolutions[T float.DType](p *pytorch.ParamsProvider[T]) error {
+	for _, name := range []string{"model.encoder.conv1.weight", "model.encoder.conv2.weight"} {
+		bias := p.Get(fmt.Sprintf("%s.bias", name[:len(name)-len(".weight")]))
+		weight := p.Pop(name)
+		if weight == nil || bias == nil {
+			return fmt.Errorf("whisper: missing %s parameters", name)
+		}
+		outChannels := len(bias)
+		kernelSize := 3
+		inChannels := len(weight) / (outChannels * kernelSize)
+		fixed := make([]T, len(weight))
+		for o := 0; o < outChannels; o++ {
+			for i := 0; i < inChannels; i++ {
+				for k := 0; k < kernelSize; k++ {
+					fixed[(o*kernelSize+k)*inChannels+i] = weight[(o*inChannels+i)*kernelSize+k]
+				}
+			}
+		}
+		p.Set(name, fixed)
+	}
+	return nil
+}
+
+// fixAttention splits the query, key and value projections of the attention
+// layers into separate projections for each head. The key projections have no bias.
+func fixAttention[T float.DType](p *pytorch.ParamsProvider[T], c whisper.Config) error {
+	blocks := []struct {
+		prefix        string
+		layers, heads int
+		attention     []string
+	}{
+		{"model.encoder", c.EncoderLayers, c.EncoderAttentionHeads, []string{"self_attn"}},
+		{"model.decoder", c.DecoderLayers, c.DecoderAttentionHeads, []string{"self_attn", "encoder_attn"}},
+	}
+	dim2 := c.DModel
+	for _, b := range blocks {
+		dim := dim2 / b.heads
+		for i := 0; i < b.layers; i++ {
+			for _, attention := range b.attention {
+				prefix := fmt.Sprintf("%s.layers.%d.%s", b.prefix, i, attention)
+				for _, name := range []string{"q_proj", "k_proj", "v_proj"} {
+					weight := p.Pop(fmt.Sprintf("%s.%s.weight", prefix, name))
+					bias := p.Pop(fmt.Sprintf("%s.%s.bias", prefix, name))
+					if weight == nil || (bias == nil && name != "k_proj") {
+						return fmt.Errorf("whisper: missing %s.%s parameters", prefix, name)
+					}
+					for j := 0; j < b.heads; j++ {
+						from, to := j*dim, (j+1)*dim
+						newPrefix := fmt.Sprintf("%s.%d.%s", prefix, j, name)
+						p.Set(fmt.Sprintf("%s.weight", newPrefix), weight[from*dim2:to*dim2])
+						if bias != nil {
+							p.Set(fmt.Sprintf("%s.bias", newPrefix), bias[from:to])
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -5,12 +5,14 @@
 package downloader
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 
+	"github.com/nlpodyssey/cybertron/pkg/converter/safetensors"
 	"github.com/nlpodyssey/cybertron/pkg/models"
 	"github.com/rs/zerolog/log"
 )
@@ -26,13 +28,52 @@ const (
 // supportedModelsFiles contains the set of all supported model types as keys,
 // mapped with the set of all related files to download.
 var supportedModelsFiles = map[string][]string{
-	"bart":    {"pytorch_model.bin", "vocab.json", "merges.txt"},
-	"pegasus": {"pytorch_model.bin", "spiece.model"},
-	"marian":  {"pytorch_model.bin", "vocab.json", "source.spm", "target.spm"},
-	"bert":    {"pytorch_model.bin", "vocab.txt", "tokenizer_config.json"},
-	"electra": {"pytorch_model.bin", "vocab.txt", "tokenizer_config.json"},
+	"bart":        {"pytorch_model.bin", "vocab.json", "merges.txt"},
+	"pegasus":     {"pytorch_model.bin", "spiece.model"},
+	"marian":      {"pytorch_model.bin", "vocab.json", "source.spm", "target.spm"},
+	"m2m_100":     {"pytorch_model.bin", "sentencepiece.bpe.model", "special_tokens_map.json"},
+	"mbart":       {"pytorch_model.bin", "sentencepiece.bpe.model", "special_tokens_map.json"},
+	"bert":        {"pytorch_model.bin", "vocab.txt", "tokenizer_config.json"},
+	"electra":     {"pytorch_model.bin", "vocab.txt", "tokenizer_config.json"},
+	"roberta":     {"pytorch_model.bin", "vocab.json", "merges.txt"},
+	"xlm-roberta": {"pytorch_model.bin", "sentencepiece.bpe.model"},
+	"deberta-v2":  {"pytorch_model.bin", "spm.model"},
+	"distilbert":  {"pytorch_model.bin", "vocab.txt", "tokenizer_config.json"},
+	"albert":      {"pytorch_model.bin", "spiece.model"},
+	"mpnet":       {"pytorch_model.bin", "vocab.txt", "tokenizer_config.json"},
+	"t5":          {"pytorch_model.bin", "spiece.model"},
+	"gpt2":        {"pytorch_model.bin", "vocab.json", "merges.txt"},
+	"llama":       {"tokenizer.model"},
+	"vit":         {"pytorch_model.bin", "preprocessor_config.json"},
+	"clip":        {"pytorch_model.bin", "vocab.json", "merges.txt", "preprocessor_config.json"},
+	"whisper":     {"pytorch_model.bin", "vocab.json", "preprocessor_config.json"},
 }
 
+// optionalModelsFiles contains, for some model types, the set of related
+// files which are downloaded only if the repository provides them
+// (e.g. M2M100 comes with a "vocab.json", while NLLB does not).
+var optionalModelsFiles = map[string][]string{
+	"m2m_100": {"vocab.json"},
+	"whisper": {"added_tokens.json"},
+}
+
+// safetensorsModels contains the set of model types whose weights are
+// downloaded in the safetensors format, either as a single file or as
+// multiple shards listed in an index file.
+var safetensorsModels = map[string]bool{
+	"llama": true,
+}
+
+const (
+	// safetensorsFilename is the name of a single-file safetensors model.
+	safetensorsFilename = "model.safetensors"
+	// safetensorsIndexFilename is the name of the index of a sharded safetensors model.
+	safetensorsIndexFilename = "model.safetensors.index.json"
+)
+
+// errNotFound is returned when a file does not exist in the repository.
+var errNotFound = errors.New("file not found")
+
 // Download downloads a supported pre-trained model from huggingface.co
 // repositories.
 //
@@ -102,6 +143,37 @@ func (d downloader) downloadModelSpecificFiles(modelType string) error {
 			return err
 		}
 	}
+	for _, filename := range optionalModelsFiles[modelType] {
+		if err := d.downloadFile(filename); err != nil && !errors.Is(err, errNotFound) {
+			return err
+		}
+	}
+	if safetensorsModels[modelType] {
+		return d.downloadSafetensors()
+	}
+	return nil
+}
+
+// downloadSafetensors downloads the shards listed in the safetensors index
+// file, if the repository provides one, or else the single safetensors file.
+func (d downloader) downloadSafetensors() error {
+	err := d.downloadFile(safetensorsIndexFilename)
+	if errors.Is(err, errNotFound) {
+		return d.downloadFile(safetensorsFilename)
+	}
+	if err != nil {
+		return err
+	}
+
+	index, err := safetensors.ReadIndex(filepath.Join(d.modelPath, safetensorsIndexFilename))
+	if err != nil {
+		return err
+	}
+	for _, filename := range index.Filenames() {
+		if err := d.downloadFile(filename); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -115,16 +187,6 @@ func (d downloader) downloadFile(name string) (err error) {
 	url := d.bucketURL(name)
 	log.Debug().Str("url", url).Str("destination", fPath).Msg("downloading")
 
-	f, err := os.Create(fPath)
-	if err != nil {
-		return fmt.Errorf("error creating file %#v: %w", fPath, err)
-	}
-	defer func() {
-		if e := f.Close(); e != nil && err == nil {
-			err = fmt.Errorf("error closing file %#v: %w", fPath, e)
-		}
-	}()
-
 	resp, err := d.httpGet(url)
 	if err != nil {
 		return fmt.Errorf("error getting %#v: %w", url, err)
@@ -135,10 +197,23 @@ func (d downloader) downloadFile(name string) (err error) {
 		}
 	}()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%#v responded with %s: %w", url, resp.Status, errNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("%#v responded with %s", url, resp.Status)
 	}
 
+	f, err := os.Create(fPath)
+	if err != nil {
+		return fmt.Errorf("error creating file %#v: %w", fPath, err)
+	}
+	defer func() {
+		if e := f.Close(); e != nil && err == nil {
+			err = fmt.Errorf("error closing file %#v: %w", fPath, e)
+		}
+	}()
+
 	prog := newDownloadProgress(int(resp.ContentLength))
 	prog.Start()
 	defer prog.Stop()
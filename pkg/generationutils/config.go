@@ -6,6 +6,8 @@
 
 package generationutils
 
+import "github.com/nlpodyssey/cybertron/pkg/utils/nullable"
+
 // Config provides configuration options for the decoding search algorithm.
 type Config struct {
 	// NumBeams is the number of beams for decoding search.
@@ -40,4 +42,38 @@ type Config struct {
 	// When set to a positive value, generated n-grams of this size will
 	// only occur once.
 	NoRepeatNGramSize int
+	// RepetitionPenalty, when positive and other than 1.0, multiplies the
+	// log-probability of the tokens already generated. Since log-probabilities
+	// are never positive, values > 1.0 discourage repetitions.
+	// Like the presence and frequency penalties, it only considers the
+	// generated tokens, after the first one of the sequence (i.e. the decoder
+	// start token, or the last prompt token for decoder-only models).
+	RepetitionPenalty float64
+	// PresencePenalty is subtracted from the log-probability of each token
+	// that has already been generated at least once.
+	PresencePenalty float64
+	// FrequencyPenalty is subtracted from the log-probability of each token
+	// as many times as it has already been generated.
+	FrequencyPenalty float64
+	// ForcedBOSTokenID is the ID of the token forced to be generated right
+	// after the decoder start token (e.g. the target language token of
+	// multilingual translation models).
+	ForcedBOSTokenID nullable.Type[int]
+	// ForcedDecoderIDs maps the positions of the generated sequence, where
+	// the decoder start token is at position 0, to the tokens forced to be
+	// generated at those positions (e.g. the language and task tokens of Whisper).
+	ForcedDecoderIDs map[int]int
+	// Constraints, when not empty, enable the constrained beam search, which
+	// generates sequences fulfilling all of them (the decoder start token
+	// excluded), unless none of the beams can make it within MaxLength.
+	Constraints []Constraint
+}
+
+// StreamingConfig returns a copy of the configuration suitable for streaming
+// the generated tokens with BeamSearchDecoder.OnToken, decoding a single beam
+// which stops as soon as the end-of-sequence token is selected.
+func (c Config) StreamingConfig() Config {
+	c.NumBeams = 1
+	c.EarlyStopping = true
+	return c
 }
@@ -0,0 +1,168 @@
+// Copyright 2021 spaGO Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Additional copyright notes in the package README.
+
+package generationutils
+
+import (
+	"math"
+	"sort"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+// Constraint is a list of alternative token sequences, one of which must
+// appear in the generated sequence. A single alternative forces a phrase,
+// while more alternatives make a disjunctive constraint.
+// Empty alternatives are ignored, and a constraint without any other
+// alternative is always fulfilled.
+type Constraint [][]int
+
+// PrefixAllowedTokensFunc returns the tokens allowed to follow the given
+// sequence, which starts with the decoder start token. If it returns nil,
+// any token is allowed.
+type PrefixAllowedTokensFunc func(sequence []int) []int
+
+// progress returns the number of tokens of the constraint matched by the
+// sequence, and whether the constraint is fulfilled.
+func (c Constraint) progress(sequence []int) (int, bool) {
+	best, empty := 0, true
+	for _, alternative := range c {
+		if len(alternative) == 0 {
+			continue
+		}
+		empty = false
+		if containsSequence(sequence, alternative) {
+			return len(alternative), true
+		}
+		for _, n := range c.partialMatches(sequence, alternative) {
+			best = max(best, n)
+		}
+	}
+	return best, empty
+}
+
+// nextTokens returns the tokens that advance the constraint after the
+// sequence, that is the first token of each alternative and the token
+// following each alternative prefix the sequence ends with. If the
+// constraint is already fulfilled, it returns nil.
+func (c Constraint) nextTokens(sequence []int) []int {
+	if _, fulfilled := c.progress(sequence); fulfilled {
+		return nil
+	}
+	var result []int
+	for _, alternative := range c {
+		if len(alternative) == 0 {
+			continue
+		}
+		result = append(result, alternative[0])
+		for _, n := range c.partialMatches(sequence, alternative) {
+			result = append(result, alternative[n])
+		}
+	}
+	return result
+}
+
+// partialMatches returns the lengths of the proper prefixes of the alternative
+// which the sequence ends with.
+func (c Constraint) partialMatches(sequence, alternative []int) []int {
+	var result []int
+	for n := min(len(alternative)-1, len(sequence)); n > 0; n-- {
+		if intSliceEqual(sequence[len(sequence)-n:], alternative[:n]) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// containsSequence reports whether the sequence contains the subsequence.
+func containsSequence(sequence, subsequence []int) bool {
+	for i := 0; i+len(subsequence) <= len(sequence); i++ {
+		if intSliceEqual(sequence[i:i+len(subsequence)], subsequence) {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintsProgress returns the number of tokens of the constraints matched
+// by the sequence, excluding the decoder start token, and whether all the
+// constraints are fulfilled.
+func (b *BeamSearchDecoder) constraintsProgress(sequence []int) (int, bool) {
+	total, allFulfilled := 0, true
+	for _, c := range b.Config.Constraints {
+		n, fulfilled := c.progress(sequence[1:])
+		total += n
+		allFulfilled = allFulfilled && fulfilled
+	}
+	return total, allFulfilled
+}
+
+// constrainSelection extends the selected tokens with the best one of each
+// beam and the ones advancing its constraints, then orders them alternating
+// among the banks of candidates with the same progress, from the most advanced
+// one, so that the beams making progress are kept along with the best scoring ones.
+// The end-of-sequence token is discarded until the constraints are fulfilled.
+func (b *BeamSearchDecoder) constrainSelection(inputIDs [][]int, scores []mat.Matrix, selected []*ScoredToken) []*ScoredToken {
+	type beamToken struct{ beam, token int }
+	seen := make(map[beamToken]bool, len(selected))
+	candidates := make([]*ScoredToken, 0, len(selected))
+	for _, st := range selected {
+		seen[beamToken{st.BeamIndex, st.TokenIndex}] = true
+		candidates = append(candidates, st)
+	}
+	for beamIndex, sequence := range inputIDs {
+		tokenIDs := []int{scores[beamIndex].ArgMax()}
+		for _, c := range b.Config.Constraints {
+			tokenIDs = append(tokenIDs, c.nextTokens(sequence[1:])...)
+		}
+		for _, tokenID := range tokenIDs {
+			key := beamToken{beamIndex, tokenID}
+			if seen[key] || tokenID >= scores[beamIndex].Size() {
+				continue
+			}
+			seen[key] = true
+			score := scores[beamIndex].ScalarAt(tokenID).F64()
+			if math.IsInf(score, -1) || math.IsNaN(score) {
+				continue
+			}
+			candidates = append(candidates, &ScoredToken{BeamIndex: beamIndex, TokenIndex: tokenID, Score: score})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	banks := make(map[int][]*ScoredToken)
+	maxProgress := 0
+	for _, st := range candidates {
+		sequence := inputIDs[st.BeamIndex]
+		if st.TokenIndex == b.Config.EOSTokenID {
+			if _, fulfilled := b.constraintsProgress(sequence); !fulfilled {
+				continue
+			}
+		}
+		extended := make([]int, len(sequence), len(sequence)+1)
+		copy(extended, sequence)
+		progress, _ := b.constraintsProgress(append(extended, st.TokenIndex))
+		banks[progress] = append(banks[progress], st)
+		maxProgress = max(maxProgress, progress)
+	}
+
+	result := make([]*ScoredToken, 0, len(candidates))
+	for i := 0; len(result) < len(candidates); i++ {
+		added := false
+		for progress := maxProgress; progress >= 0; progress-- {
+			if bank := banks[progress]; i < len(bank) {
+				result = append(result, bank[i])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 spaGO Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Additional copyright notes in the package README.
+
+package generationutils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func TestConstraint_progress(t *testing.T) {
+	tests := []struct {
+		name          string
+		constraint    Constraint
+		sequence      []int
+		wantProgress  int
+		wantFulfilled bool
+	}{
+		{"not started", Constraint{{4, 5}}, []int{2, 3}, 0, false},
+		{"partial", Constraint{{4, 5}}, []int{2, 4}, 1, false},
+		{"fulfilled", Constraint{{4, 5}}, []int{4, 5, 2}, 2, true},
+		{"interrupted", Constraint{{4, 5}}, []int{4, 2}, 0, false},
+		{"disjunctive", Constraint{{3}, {4, 5, 6}}, []int{4, 5}, 2, false},
+		{"disjunctive fulfilled", Constraint{{3}, {4, 5, 6}}, []int{2, 3}, 1, true},
+		{"empty alternative", Constraint{{}, {4, 5}}, []int{4}, 1, false},
+		{"only empty alternatives", Constraint{{}}, []int{2}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, fulfilled := tt.constraint.progress(tt.sequence)
+			if progress != tt.wantProgress || fulfilled != tt.wantFulfilled {
+				t.Errorf("got (%d, %v), want (%d, %v)", progress, fulfilled, tt.wantProgress, tt.wantFulfilled)
+			}
+		})
+	}
+}
+
+func TestConstraint_nextTokens(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint Constraint
+		sequence   []int
+		want       []int
+	}{
+		{"not started", Constraint{{4, 5}}, []int{2}, []int{4}},
+		{"partial", Constraint{{4, 5}}, []int{2, 4}, []int{4, 5}},
+		{"fulfilled", Constraint{{4, 5}}, []int{4, 5}, nil},
+		{"disjunctive", Constraint{{3}, {4, 5}}, []int{4}, []int{3, 4, 5}},
+		{"empty alternative", Constraint{{}, {4, 5}}, []int{2}, []int{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.constraint.nextTokens(tt.sequence); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeamSearchDecoder_Decode_constraints(t *testing.T) {
+	const (
+		startTokenID = 0
+		eosTokenID   = 1
+	)
+	// The model always prefers to end the sequence, then token 2, while the
+	// tokens of the constraints are the least probable ones.
+	predictNext := func(inputIDs [][]int, _ []int) []mat.Matrix {
+		scores := make([]mat.Matrix, len(inputIDs))
+		for i := range scores {
+			scores[i] = logProbs(0.01, 0.6, 0.2, 0.07, 0.06, 0.06)
+		}
+		return scores
+	}
+	// Without length penalty, the shortest sequences fulfilling the
+	// constraints are the best ones.
+	newDecoder := func(constraints ...Constraint) *BeamSearchDecoder {
+		return &BeamSearchDecoder{
+			Config: Config{
+				NumBeams:            4,
+				MaxLength:           12,
+				EOSTokenID:          eosTokenID,
+				DecoderStartTokenID: startTokenID,
+				LengthPenalty:       0,
+				Constraints:         constraints,
+			},
+			PredictNext: predictNext,
+			SelectNext:  SelectNextTopK,
+		}
+	}
+
+	sequences, _ := newDecoder().Decode(context.Background())
+	if want := []int{startTokenID, eosTokenID}; !reflect.DeepEqual(sequences[0], want) {
+		t.Fatalf("without constraints: got %v, want %v", sequences[0], want)
+	}
+
+	tests := []struct {
+		name        string
+		constraints []Constraint
+		want        []int
+	}{
+		{"forced phrase", []Constraint{{{4, 5}}}, []int{startTokenID, 4, 5, eosTokenID}},
+		{"disjunctive", []Constraint{{{3, 3}, {5, 4, 3}}}, []int{startTokenID, 3, 3, eosTokenID}},
+		{"both", []Constraint{{{4, 5}}, {{3, 3}, {5, 4, 3}}}, nil},
+		{"empty alternative", []Constraint{{{}, {4, 5}}}, []int{startTokenID, 4, 5, eosTokenID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sequences, _ := newDecoder(tt.constraints...).Decode(context.Background())
+			if len(sequences) == 0 {
+				t.Fatal("got no sequences")
+			}
+			if tt.want != nil && !reflect.DeepEqual(sequences[0], tt.want) {
+				t.Errorf("got best sequence %v, want %v", sequences[0], tt.want)
+			}
+			if best := sequences[0]; best[len(best)-1] != eosTokenID {
+				t.Errorf("best sequence %v is not finished", best)
+			}
+			for _, sequence := range sequences {
+				for _, c := range tt.constraints {
+					if _, fulfilled := c.progress(sequence[1:]); !fulfilled {
+						t.Errorf("sequence %v does not fulfill the constraint %v", sequence, c)
+					}
+				}
+				// The end-of-sequence token is held back until the constraints are fulfilled.
+				for _, tokenID := range sequence[:len(sequence)-1] {
+					if tokenID == eosTokenID {
+						t.Errorf("sequence %v ends before its last token", sequence)
+					}
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,18 @@ type BeamSearchDecoder struct {
 	PredictNext PredictNextFunc
 	// SelectNext is a function that selects the next tokens given the current tokens.
 	SelectNext DecodingStrategyFunc
+	// OnToken, if not nil, is called with each token appended to the best
+	// sequence, as soon as it is selected. The tokens are final only with a
+	// single beam and early stopping, which make the decoding greedy (or
+	// sampled): see StreamingConfig.
+	OnToken func(tokenID int)
+	// PrefixAllowedTokens, if not nil, limits the tokens that can be
+	// generated after each sequence (e.g. to the ones of a controlled vocabulary).
+	PrefixAllowedTokens PrefixAllowedTokensFunc
+	// ProcessScores, if not nil, processes the scores of the next tokens of each
+	// sequence (e.g. with the temperature or the top-k and top-p filters), after
+	// the penalties and the other adjustments of the configuration, as in Hugging Face.
+	ProcessScores ScoreProcessor
 }
 
 // PredictNextFunc is a function that predicts the next token scores for a given input.
@@ -53,6 +65,10 @@ Loop:
 	for curLen := 1; curLen < b.Config.MaxLength; curLen++ {
 		candidates := b.generateCandidates(inputIDs, beamIndices, sumLogProbs)
 		selected := b.SelectNext(candidates, b.Config.NumBeams*2)
+		bestScore := selected[0].Score
+		if len(b.Config.Constraints) > 0 {
+			selected = b.constrainSelection(inputIDs, candidates, selected)
+		}
 		inputIDs, beamIndices, sumLogProbs = b.process(inputIDs, selected, func(sequence []int, sumLogProb float64) {
 			// add to hypothesis if end of sentence
 			hs.insert(&hypothesis{
@@ -60,9 +76,13 @@ Loop:
 				score:    sumLogProb / math.Pow(float64(len(sequence)), b.Config.LengthPenalty),
 			})
 		})
-		if isDone = hs.isDone(selected[0].Score, curLen); isDone {
+		if isDone = hs.isDone(bestScore, curLen); isDone {
 			break
 		}
+		if b.OnToken != nil {
+			sequence := inputIDs[0]
+			b.OnToken(sequence[len(sequence)-1])
+		}
 
 		select {
 		case <-ctx.Done():
@@ -73,19 +93,31 @@ Loop:
 	}
 
 	if !isDone {
-		// add remaining hypotheses
-		for beamID := 0; beamID < b.Config.NumBeams; beamID++ {
-			sequence := inputIDs[beamID]
-			hs.insert(&hypothesis{
-				sequence: sequence,
-				score:    sumLogProbs[beamID] / math.Pow(float64(len(sequence)), b.Config.LengthPenalty),
-			})
+		// add remaining hypotheses, preferring the ones fulfilling the constraints
+		b.insertRemaining(hs, inputIDs, sumLogProbs, true)
+		if len(hs.items) == 0 {
+			b.insertRemaining(hs, inputIDs, sumLogProbs, false)
 		}
 	}
 
 	return hs.prepareOutput()
 }
 
+// insertRemaining adds the sequences being generated to the hypotheses. If
+// onlyFulfilled is true, the sequences not fulfilling the constraints are skipped.
+func (b *BeamSearchDecoder) insertRemaining(hs *hypotheses, inputIDs [][]int, sumLogProbs []float64, onlyFulfilled bool) {
+	for beamID := 0; beamID < b.Config.NumBeams; beamID++ {
+		sequence := inputIDs[beamID]
+		if _, fulfilled := b.constraintsProgress(sequence); onlyFulfilled && !fulfilled {
+			continue
+		}
+		hs.insert(&hypothesis{
+			sequence: sequence,
+			score:    sumLogProbs[beamID] / math.Pow(float64(len(sequence)), b.Config.LengthPenalty),
+		})
+	}
+}
+
 func (b *BeamSearchDecoder) generateCandidates(inputIDs [][]int, beamIndices []int, beamScores []float64) []mat.Matrix {
 	tokensScores := b.PredictNext(inputIDs, beamIndices)
 	tokensScores = b.adjustPrediction(inputIDs, tokensScores)
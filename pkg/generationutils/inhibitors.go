@@ -17,6 +17,21 @@ import (
 var floatNegInf = float.Interface(math.Inf(-1))
 
 func (b *BeamSearchDecoder) adjustPrediction(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
+	if b.PrefixAllowedTokens != nil {
+		scores = b.processPrefixAllowedTokensScores(inputIDs, scores)
+	}
+	if b.Config.RepetitionPenalty > 0 && b.Config.RepetitionPenalty != 1 {
+		scores = b.processRepetitionPenaltyScores(inputIDs, scores)
+	}
+	if b.Config.PresencePenalty != 0 || b.Config.FrequencyPenalty != 0 {
+		scores = b.processPresenceFrequencyScores(inputIDs, scores)
+	}
+	if b.Config.ForcedBOSTokenID.Valid {
+		scores = b.processForcedBOSScores(inputIDs, scores)
+	}
+	if len(b.Config.ForcedDecoderIDs) > 0 {
+		scores = b.processForcedDecoderScores(inputIDs, scores)
+	}
 	if b.Config.MinLength >= 0 && b.Config.EOSTokenID >= 0 {
 		scores = b.processMinLengthScores(inputIDs, scores)
 	}
@@ -26,6 +41,64 @@ func (b *BeamSearchDecoder) adjustPrediction(inputIDs [][]int, scores []mat.Matr
 	if b.Config.NoRepeatNGramSize > 0 {
 		scores = b.processNoRepeatNGramScores(inputIDs, scores)
 	}
+	if b.ProcessScores != nil {
+		for i := range scores {
+			scores[i] = b.ProcessScores(scores[i])
+		}
+	}
+	return scores
+}
+
+func (b *BeamSearchDecoder) processPrefixAllowedTokensScores(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
+	for i, sequence := range inputIDs {
+		allowed := b.PrefixAllowedTokens(sequence)
+		if allowed == nil {
+			continue
+		}
+		isAllowed := make(map[int]bool, len(allowed))
+		for _, tokenID := range allowed {
+			isAllowed[tokenID] = true
+		}
+		for j := 0; j < scores[i].Size(); j++ {
+			if !isAllowed[j] {
+				scores[i].SetScalar(floatNegInf, j)
+			}
+		}
+	}
+	return scores
+}
+
+// generatedTokenCounts returns how many times each token occurs in the
+// sequence, excluding the decoder start token.
+func generatedTokenCounts(sequence []int) map[int]int {
+	counts := make(map[int]int, len(sequence))
+	for _, id := range sequence[1:] {
+		counts[id]++
+	}
+	return counts
+}
+
+// processRepetitionPenaltyScores multiplies the log-probabilities of the
+// generated tokens by the repetition penalty.
+func (b *BeamSearchDecoder) processRepetitionPenaltyScores(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
+	penalty := b.Config.RepetitionPenalty
+	for i, sequence := range inputIDs {
+		for tokenID := range generatedTokenCounts(sequence) {
+			score := scores[i].ScalarAt(tokenID).F64() * penalty
+			scores[i].SetScalar(float.Interface(score), tokenID)
+		}
+	}
+	return scores
+}
+
+func (b *BeamSearchDecoder) processPresenceFrequencyScores(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
+	for i, sequence := range inputIDs {
+		for tokenID, count := range generatedTokenCounts(sequence) {
+			score := scores[i].ScalarAt(tokenID).F64()
+			score -= b.Config.PresencePenalty + b.Config.FrequencyPenalty*float64(count)
+			scores[i].SetScalar(float.Interface(score), tokenID)
+		}
+	}
 	return scores
 }
 
@@ -87,6 +160,35 @@ func (b *BeamSearchDecoder) processMinLengthScores(inputIDs [][]int, scores []ma
 	return scores
 }
 
+func (b *BeamSearchDecoder) processForcedBOSScores(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
+	if len(inputIDs[0]) != 1 {
+		return scores
+	}
+
+	return forceToken(scores, b.Config.ForcedBOSTokenID.Value)
+}
+
+func (b *BeamSearchDecoder) processForcedDecoderScores(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
+	forcedTokenID, ok := b.Config.ForcedDecoderIDs[len(inputIDs[0])]
+	if !ok {
+		return scores
+	}
+	return forceToken(scores, forcedTokenID)
+}
+
+// forceToken sets the scores so that only the given token can be generated.
+func forceToken(scores []mat.Matrix, forcedTokenID int) []mat.Matrix {
+	for _, n := range scores {
+		for i := 0; i < n.Size(); i++ {
+			if i != forcedTokenID {
+				n.SetScalar(floatNegInf, i)
+			}
+		}
+		n.SetScalar(float.Interface(0.0), forcedTokenID)
+	}
+	return scores
+}
+
 func (b *BeamSearchDecoder) processNoRepeatNGramScores(inputIDs [][]int, scores []mat.Matrix) []mat.Matrix {
 	numBatchHypotheses := len(scores)
 	curLen := len(inputIDs[0])
@@ -0,0 +1,94 @@
+// Copyright 2021 spaGO Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Additional copyright notes in the package README.
+
+package generationutils
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func newScores(values ...float64) mat.Matrix {
+	return mat.NewDense[float64](mat.WithBacking(values))
+}
+
+func TestBeamSearchDecoder_processRepetitionPenaltyScores(t *testing.T) {
+	// The first token of the sequence (i.e. the decoder start token) is not
+	// penalized, while the repeated token is penalized only once.
+	tests := []struct {
+		name     string
+		penalty  float64
+		sequence []int
+		want     []float64
+	}{
+		{"discourage", 2, []int{0, 2, 2}, []float64{-1, -2, -6, -4}},
+		{"encourage", 0.5, []int{0, 1, 3}, []float64{-1, -1, -3, -2}},
+		{"nothing generated", 2, []int{0}, []float64{-1, -2, -3, -4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamSearchDecoder{Config: Config{RepetitionPenalty: tt.penalty}}
+			scores := []mat.Matrix{newScores(-1, -2, -3, -4)}
+			got := b.processRepetitionPenaltyScores([][]int{tt.sequence}, scores)
+			if data := got[0].Data().F64(); !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("got %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeamSearchDecoder_processPresenceFrequencyScores(t *testing.T) {
+	tests := []struct {
+		name      string
+		presence  float64
+		frequency float64
+		want      []float64
+	}{
+		{"presence", 0.5, 0, []float64{-1, -2.5, -3, -4.5}},
+		{"frequency", 0, 0.25, []float64{-1, -2.25, -3, -4.5}},
+		{"both", 0.5, 0.25, []float64{-1, -2.75, -3, -5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamSearchDecoder{Config: Config{PresencePenalty: tt.presence, FrequencyPenalty: tt.frequency}}
+			scores := []mat.Matrix{newScores(-1, -2, -3, -4)}
+			got := b.processPresenceFrequencyScores([][]int{{0, 1, 3, 3}}, scores)
+			if data := got[0].Data().F64(); !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("got %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeamSearchDecoder_adjustPrediction(t *testing.T) {
+	// The repeated token 0 is the only one surviving the top-p filter, unless
+	// it is penalized before the filter is applied.
+	tests := []struct {
+		name   string
+		config Config
+		want   []bool
+	}{
+		{"no penalty", Config{}, []bool{true, false, false, false}},
+		{"repetition penalty", Config{RepetitionPenalty: 10}, []bool{false, true, false, false}},
+		{"presence penalty", Config{PresencePenalty: 5}, []bool{false, true, false, false}},
+		{"frequency penalty", Config{FrequencyPenalty: 5}, []bool{false, true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamSearchDecoder{
+				Config:        tt.config,
+				ProcessScores: TopPProcessor(0.5, math.Inf(-1), 1),
+			}
+			got := b.adjustPrediction([][]int{{3, 0}}, []mat.Matrix{logProbs(0.6, 0.3, 0.05, 0.05)})
+			if k := kept(got[0]); !reflect.DeepEqual(k, tt.want) {
+				t.Errorf("got kept %v, want %v", k, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ package generationutils
 
 import (
 	"container/heap"
+	"math"
 	"sort"
 
 	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
@@ -113,3 +114,104 @@ func TopPProcessor[T float.DType](topP, filterValue T, minSize int) ScoreProcess
 		return mat.NewDense[T](mat.WithBacking(outData))
 	}
 }
+
+// LogitBiasProcessor adds a bias to the scores of the given token IDs.
+// Token IDs outside the scores are ignored.
+func LogitBiasProcessor(bias map[int]float64) ScoreProcessor {
+	return func(scores mat.Matrix) mat.Matrix {
+		out := scores.Clone()
+		size := out.Size()
+		for tokenID, value := range bias {
+			if tokenID < 0 || tokenID >= size {
+				continue
+			}
+			out.SetScalar(float.Interface(out.ScalarAt(tokenID).F64()+value), tokenID)
+		}
+		return out
+	}
+}
+
+// MinPProcessor applies a min-p filter to a matrix of scores, removing the
+// tokens whose probability is lower than minP times the one of the most
+// probable token. At least minSize tokens are kept.
+func MinPProcessor(minP, filterValue float64, minSize int) ScoreProcessor {
+	return func(scores mat.Matrix) mat.Matrix {
+		probs := scores.Softmax().Data().F64()
+		threshold := minP * maxValue(probs)
+
+		sortedIndices := sortedIndicesByValue(probs, true)
+		keep := make([]bool, len(probs))
+		for rank, index := range sortedIndices {
+			keep[index] = rank < minSize || probs[index] >= threshold
+		}
+		return filterScores(scores, keep, filterValue)
+	}
+}
+
+// TypicalProcessor applies a locally typical sampling filter to a matrix of
+// scores, keeping the smallest set of tokens, whose probabilities add up to
+// mass, whose information content is closest to the expected one (i.e. the
+// entropy of the distribution). At least minSize tokens are kept.
+func TypicalProcessor(mass, filterValue float64, minSize int) ScoreProcessor {
+	return func(scores mat.Matrix) mat.Matrix {
+		probs := scores.Softmax().Data().F64()
+
+		entropy := 0.0
+		for _, p := range probs {
+			if p > 0 {
+				entropy -= p * math.Log(p)
+			}
+		}
+		shifted := make([]float64, len(probs))
+		for i, p := range probs {
+			shifted[i] = math.Abs(-math.Log(p) - entropy)
+		}
+
+		sortedIndices := sortedIndicesByValue(shifted, false)
+		keep := make([]bool, len(probs))
+		cumulativeProb := 0.0
+		for rank, index := range sortedIndices {
+			if rank >= minSize && cumulativeProb >= mass {
+				break
+			}
+			keep[index] = true
+			cumulativeProb += probs[index]
+		}
+		return filterScores(scores, keep, filterValue)
+	}
+}
+
+// sortedIndicesByValue returns the indices of the values, sorted by value.
+func sortedIndicesByValue(values []float64, descending bool) []int {
+	indices := make([]int, len(values))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		if descending {
+			return values[indices[i]] > values[indices[j]]
+		}
+		return values[indices[i]] < values[indices[j]]
+	})
+	return indices
+}
+
+// maxValue returns the maximum of the values.
+func maxValue(values []float64) float64 {
+	result := math.Inf(-1)
+	for _, v := range values {
+		result = math.Max(result, v)
+	}
+	return result
+}
+
+// filterScores replaces the scores that are not kept with filterValue.
+func filterScores(scores mat.Matrix, keep []bool, filterValue float64) mat.Matrix {
+	out := scores.Clone()
+	for i, k := range keep {
+		if !k {
+			out.SetScalar(float.Interface(filterValue), i)
+		}
+	}
+	return out
+}
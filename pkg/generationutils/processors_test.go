@@ -0,0 +1,105 @@
+// Copyright 2021 spaGO Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Additional copyright notes in the package README.
+
+package generationutils
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+// logProbs returns the scores of the given probabilities.
+func logProbs(probs ...float64) mat.Matrix {
+	values := make([]float64, len(probs))
+	for i, p := range probs {
+		values[i] = math.Log(p)
+	}
+	return newScores(values...)
+}
+
+// kept returns which scores are not filtered out.
+func kept(scores mat.Matrix) []bool {
+	result := make([]bool, scores.Size())
+	for i, v := range scores.Data().F64() {
+		result[i] = !math.IsInf(v, -1)
+	}
+	return result
+}
+
+func TestLogitBiasProcessor(t *testing.T) {
+	tests := []struct {
+		name string
+		bias map[int]float64
+		want []float64
+	}{
+		{"no bias", nil, []float64{-1, -2, -3}},
+		{"bias", map[int]float64{0: -1, 1: 2}, []float64{-2, 0, -3}},
+		{"out of range", map[int]float64{-1: 5, 3: 5, 2: 0.5}, []float64{-1, -2, -2.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := newScores(-1, -2, -3)
+			got := LogitBiasProcessor(tt.bias)(scores)
+			if data := got.Data().F64(); !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("got %v, want %v", data, tt.want)
+			}
+			if data := scores.Data().F64(); !reflect.DeepEqual(data, []float64{-1, -2, -3}) {
+				t.Errorf("the input scores were modified: %v", data)
+			}
+		})
+	}
+}
+
+func TestMinPProcessor(t *testing.T) {
+	tests := []struct {
+		name    string
+		minP    float64
+		minSize int
+		want    []bool
+	}{
+		{"half of the top", 0.5, 1, []bool{true, true, false, false}},
+		{"only the top", 1, 1, []bool{true, false, false, false}},
+		{"all", 0, 1, []bool{true, true, true, true}},
+		{"min size", 1, 3, []bool{true, true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MinPProcessor(tt.minP, math.Inf(-1), tt.minSize)(logProbs(0.5, 0.3, 0.15, 0.05))
+			if k := kept(got); !reflect.DeepEqual(k, tt.want) {
+				t.Errorf("got kept %v, want %v", k, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypicalProcessor(t *testing.T) {
+	// The entropy of the distribution is about 1.28 nats, so the tokens
+	// sorted by typicality, i.e. by the distance between their information
+	// content and the entropy, are 1 (1.20), 2 (1.61), 0 (0.92) and 3 (2.30).
+	tests := []struct {
+		name    string
+		mass    float64
+		minSize int
+		want    []bool
+	}{
+		{"most typical", 0.2, 1, []bool{false, true, false, false}},
+		{"skip the most probable", 0.4, 1, []bool{false, true, true, false}},
+		{"mass", 0.6, 1, []bool{true, true, true, false}},
+		{"all", 1, 1, []bool{true, true, true, true}},
+		{"min size", 0.2, 2, []bool{false, true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TypicalProcessor(tt.mass, math.Inf(-1), tt.minSize)(logProbs(0.4, 0.3, 0.2, 0.1))
+			if k := kept(got); !reflect.DeepEqual(k, tt.want) {
+				t.Errorf("got kept %v, want %v", k, tt.want)
+			}
+		})
+	}
+}
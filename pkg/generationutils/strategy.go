@@ -5,6 +5,7 @@
 package generationutils
 
 import (
+	"math"
 	"sort"
 
 	"github.com/nlpodyssey/spago/mat"
@@ -93,7 +94,7 @@ func SelectNextMultinomial(tokensScores []mat.Matrix, resultSize int) []*ScoredT
 	result := make([]*ScoredToken, 0, resultSize*len(tokensScores))
 
 	for beamIndex, m := range tokensScores {
-		nextIndices := multinomialSample(m.Softmax(), resultSize)
+		nextIndices := multinomialSample(withoutNaN(m).Softmax(), resultSize)
 		for _, nextIndex := range nextIndices {
 			result = append(result, &ScoredToken{
 				BeamIndex:  beamIndex,
@@ -111,6 +112,17 @@ func SelectNextMultinomial(tokensScores []mat.Matrix, resultSize int) []*ScoredT
 	return result
 }
 
+// withoutNaN returns the scores replacing the NaN values, which cannot be
+// sampled, with -Inf (e.g. the masked tokens of a float32 log-softmax).
+func withoutNaN(scores mat.Matrix) mat.Matrix {
+	return scores.Apply(func(_, _ int, v float64) float64 {
+		if math.IsNaN(v) {
+			return math.Inf(-1)
+		}
+		return v
+	})
+}
+
 // sample extracts the next index from the probability multinomial distribution.
 func multinomialSample(probs mat.Matrix, numSamples int) []int {
 	if numSamples > probs.Size() {
@@ -118,6 +130,16 @@ func multinomialSample(probs mat.Matrix, numSamples int) []int {
 	}
 	// FIXME: avoid casting to specific type
 	probsData := probs.Data().F64()
+
+	// The filtered tokens cannot be sampled: don't wait for them.
+	available := 0
+	for _, prob := range probsData {
+		if prob > 0 {
+			available++
+		}
+	}
+	numSamples = min(numSamples, available)
+
 	samples := make([]int, 0, numSamples)
 	samplesMap := make(map[int]struct{}, numSamples)
 
@@ -0,0 +1,94 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageprocessing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Resampling filters, with the same values used by PIL (and Hugging Face).
+const (
+	Nearest  = 0
+	Lanczos  = 1
+	Bilinear = 2
+	Bicubic  = 3
+	Box      = 4
+	Hamming  = 5
+)
+
+// Config contains the configuration of the image processor.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	DoResize      bool      `json:"do_resize"`
+	Size          Size      `json:"size"`
+	Resample      int       `json:"resample"`
+	DoCenterCrop  bool      `json:"do_center_crop"`
+	CropSize      Size      `json:"crop_size"`
+	DoRescale     bool      `json:"do_rescale"`
+	RescaleFactor float64   `json:"rescale_factor"`
+	DoNormalize   bool      `json:"do_normalize"`
+	ImageMean     []float64 `json:"image_mean"`
+	ImageStd      []float64 `json:"image_std"`
+	// DefaultToSquare reports whether a Size consisting of a single number
+	// is the length of both edges, instead of the length of the shortest edge.
+	DefaultToSquare bool `json:"-"`
+}
+
+// Size is the size of an image, given either as height and width,
+// or as the length of the shortest edge (preserving the aspect ratio).
+// In the JSON configuration, a single number is read as ShortestEdge.
+type Size struct {
+	Height       int `json:"height"`
+	Width        int `json:"width"`
+	ShortestEdge int `json:"shortest_edge"`
+}
+
+// UnmarshalJSON reads a Size from either a number or an object.
+func (s *Size) UnmarshalJSON(data []byte) error {
+	var edge int
+	if err := json.Unmarshal(data, &edge); err == nil {
+		*s = Size{ShortestEdge: edge}
+		return nil
+	}
+	type size Size // prevents recursion
+	*s = Size{}
+	return json.Unmarshal(data, (*size)(s))
+}
+
+// ConfigFromFile loads the image processor configuration from file
+// (e.g. "preprocessor_config.json").
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to decode image processor config: %w", err)
+	}
+	if n := len(config.ImageMean); n != len(config.ImageStd) {
+		return Config{}, fmt.Errorf("image processor config: mean and std sizes mismatch: %d != %d", n, len(config.ImageStd))
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face image processors,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		DoResize:      true,
+		Size:          Size{Height: 224, Width: 224},
+		Resample:      Bilinear,
+		DoRescale:     true,
+		RescaleFactor: 1.0 / 255,
+		DoNormalize:   true,
+		ImageMean:     []float64{0.5, 0.5, 0.5},
+		ImageStd:      []float64{0.5, 0.5, 0.5},
+	}
+}
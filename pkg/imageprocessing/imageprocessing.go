@@ -0,0 +1,195 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package imageprocessing implements the pre-processing of the images
+// for vision models (e.g. ViT, CLIP), in the same way as the
+// Hugging Face image processors: resizing, center cropping, rescaling
+// and normalization.
+package imageprocessing
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register the JPEG format
+	_ "image/png"  // register the PNG format
+	"io"
+	"path/filepath"
+)
+
+// DefaultConfigFilename is the default filename of the image processor configuration.
+const DefaultConfigFilename = "preprocessor_config.json"
+
+// Processor converts images into the pixel values expected by a vision model.
+type Processor struct {
+	// Config is the configuration of the image processor.
+	Config Config
+}
+
+// New returns a new Processor.
+func New(config Config) *Processor {
+	return &Processor{Config: config}
+}
+
+// NewFromModelFolder returns a new Processor loading the configuration from the model directory.
+// The defaultToSquare flag is set as Config.DefaultToSquare.
+func NewFromModelFolder(modelPath string, defaultToSquare bool) (*Processor, error) {
+	config, err := ConfigFromFile(filepath.Join(modelPath, DefaultConfigFilename))
+	if err != nil {
+		return nil, err
+	}
+	config.DefaultToSquare = defaultToSquare
+	return New(config), nil
+}
+
+// Decode decodes an image in one of the registered formats (JPEG, PNG).
+func Decode(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// Pixels contains the pixel values of an image.
+type Pixels struct {
+	// Channels is the number of channels (e.g. 3 for RGB images).
+	Channels int
+	// Height is the height of the image.
+	Height int
+	// Width is the width of the image.
+	Width int
+	// Data contains the pixel values in channels-first order, that is, indexed by [channel][y][x].
+	Data []float64
+}
+
+// At returns the value of the given channel of the pixel at (x, y).
+func (p Pixels) At(channel, x, y int) float64 {
+	return p.Data[(channel*p.Height+y)*p.Width+x]
+}
+
+// Patches splits the image into non-overlapping square patches of the given size,
+// in row-major order. Each patch is flattened in channels-first order, as the
+// weights of a convolution with kernel size and stride equal to the patch size.
+func (p Pixels) Patches(size int) [][]float64 {
+	rows, cols := p.Height/size, p.Width/size
+	patches := make([][]float64, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			patch := make([]float64, 0, p.Channels*size*size)
+			for ch := 0; ch < p.Channels; ch++ {
+				for y := r * size; y < (r+1)*size; y++ {
+					patch = append(patch, p.Data[(ch*p.Height+y)*p.Width+c*size:(ch*p.Height+y)*p.Width+(c+1)*size]...)
+				}
+			}
+			patches = append(patches, patch)
+		}
+	}
+	return patches
+}
+
+// Process returns the pixel values of the image, after resizing,
+// center cropping, rescaling and normalization, according to the configuration.
+func (p *Processor) Process(img image.Image) (Pixels, error) {
+	c := p.Config
+	rgb := toRGB(img)
+
+	if c.DoResize {
+		height, width := p.resizeOutputSize(rgb.height, rgb.width)
+		var err error
+		if rgb, err = resize(rgb, width, height, c.Resample); err != nil {
+			return Pixels{}, err
+		}
+	}
+	if c.DoCenterCrop {
+		height, width := c.CropSize.Height, c.CropSize.Width
+		if height == 0 || width == 0 {
+			height, width = c.CropSize.ShortestEdge, c.CropSize.ShortestEdge
+		}
+		rgb = centerCrop(rgb, width, height)
+	}
+
+	pixels := Pixels{
+		Channels: 3,
+		Height:   rgb.height,
+		Width:    rgb.width,
+		Data:     make([]float64, 3*rgb.height*rgb.width),
+	}
+	for ch := 0; ch < 3; ch++ {
+		for i := 0; i < rgb.height*rgb.width; i++ {
+			v := float64(rgb.pix[i*3+ch])
+			if c.DoRescale {
+				v *= c.RescaleFactor
+			}
+			if c.DoNormalize {
+				v = (v - c.ImageMean[ch]) / c.ImageStd[ch]
+			}
+			pixels.Data[ch*rgb.height*rgb.width+i] = v
+		}
+	}
+	return pixels, nil
+}
+
+// resizeOutputSize returns the height and the width of the resized image.
+func (p *Processor) resizeOutputSize(height, width int) (int, int) {
+	size := p.Config.Size
+	switch {
+	case size.Height > 0 && size.Width > 0:
+		return size.Height, size.Width
+	case p.Config.DefaultToSquare:
+		return size.ShortestEdge, size.ShortestEdge
+	case height <= width:
+		return size.ShortestEdge, int(float64(size.ShortestEdge) * float64(width) / float64(height))
+	default:
+		return int(float64(size.ShortestEdge) * float64(height) / float64(width)), size.ShortestEdge
+	}
+}
+
+// centerCrop returns the central region of the image with the given size.
+// The image is padded with zeros if it is smaller than the region.
+func centerCrop(img *rgbImage, width, height int) *rgbImage {
+	if img.width == width && img.height == height {
+		return img
+	}
+	out := newRGBImage(width, height)
+	top := floorDiv(img.height-height, 2)
+	left := floorDiv(img.width-width, 2)
+	for y := 0; y < height; y++ {
+		sy := y + top
+		if sy < 0 || sy >= img.height {
+			continue
+		}
+		for x := 0; x < width; x++ {
+			sx := x + left
+			if sx < 0 || sx >= img.width {
+				continue
+			}
+			copy(out.pix[(y*width+x)*3:(y*width+x+1)*3], img.pix[(sy*img.width+sx)*3:(sy*img.width+sx+1)*3])
+		}
+	}
+	return out
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// toRGB converts the image to 8-bit RGB, discarding the alpha channel.
+func toRGB(img image.Image) *rgbImage {
+	bounds := img.Bounds()
+	out := newRGBImage(bounds.Dx(), bounds.Dy())
+	i := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			out.pix[i], out.pix[i+1], out.pix[i+2] = c.R, c.G, c.B
+			i += 3
+		}
+	}
+	return out
+}
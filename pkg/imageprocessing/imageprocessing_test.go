@@ -0,0 +1,146 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageprocessing
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSize_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Size
+	}{
+		{`224`, Size{ShortestEdge: 224}},
+		{`{"shortest_edge": 256}`, Size{ShortestEdge: 256}},
+		{`{"height": 384, "width": 512}`, Size{Height: 384, Width: 512}},
+	}
+	for _, tt := range tests {
+		size := Size{Height: 1, Width: 1}
+		require.NoError(t, json.Unmarshal([]byte(tt.input), &size))
+		assert.Equal(t, tt.want, size, tt.input)
+	}
+}
+
+func TestResize(t *testing.T) {
+	img := newRGBImage(4, 1)
+	for i, v := range []uint8{0, 100, 200, 255} {
+		img.pix[i*3], img.pix[i*3+1], img.pix[i*3+2] = v, v, v
+	}
+
+	t.Run("bilinear", func(t *testing.T) {
+		out, err := resize(img, 2, 1, Bilinear)
+		require.NoError(t, err)
+		// weights (3/7, 3/7, 1/7) and (1/7, 3/7, 3/7)
+		assert.Equal(t, []uint8{71, 71, 71, 209, 209, 209}, out.pix)
+	})
+
+	t.Run("nearest", func(t *testing.T) {
+		out, err := resize(img, 2, 1, Nearest)
+		require.NoError(t, err)
+		assert.Equal(t, []uint8{100, 100, 100, 255, 255, 255}, out.pix)
+	})
+
+	t.Run("constant image", func(t *testing.T) {
+		src := newRGBImage(7, 5)
+		for i := range src.pix {
+			src.pix[i] = 42
+		}
+		for _, resample := range []int{Lanczos, Bilinear, Bicubic, Box, Hamming} {
+			out, err := resize(src, 3, 11, resample)
+			require.NoError(t, err)
+			for _, v := range out.pix {
+				require.Equal(t, uint8(42), v, "resample %d", resample)
+			}
+		}
+	})
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		_, err := resize(img, 2, 1, 42)
+		assert.Error(t, err)
+	})
+}
+
+func TestProcessor_Process(t *testing.T) {
+	// 8x4 image, with the left half red and the right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 4 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	decoded, err := Decode(&buf)
+	require.NoError(t, err)
+
+	p := New(Config{
+		DoResize:      true,
+		Size:          Size{ShortestEdge: 2},
+		Resample:      Nearest,
+		DoCenterCrop:  true,
+		CropSize:      Size{ShortestEdge: 2},
+		DoRescale:     true,
+		RescaleFactor: 1.0 / 255,
+		DoNormalize:   true,
+		ImageMean:     []float64{0.5, 0.5, 0.5},
+		ImageStd:      []float64{0.5, 0.5, 0.5},
+	})
+	pixels, err := p.Process(decoded)
+	require.NoError(t, err)
+
+	// resized to 4x2 (shortest edge), then cropped to the central 2x2
+	assert.Equal(t, 3, pixels.Channels)
+	assert.Equal(t, 2, pixels.Height)
+	assert.Equal(t, 2, pixels.Width)
+	for y := 0; y < 2; y++ {
+		assert.Equal(t, 1.0, pixels.At(0, 0, y))
+		assert.Equal(t, -1.0, pixels.At(2, 0, y))
+		assert.Equal(t, -1.0, pixels.At(0, 1, y))
+		assert.Equal(t, 1.0, pixels.At(2, 1, y))
+		assert.Equal(t, -1.0, pixels.At(1, 0, y))
+	}
+
+	p.Config.DefaultToSquare = true
+	pixels, err = p.Process(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pixels.Height)
+	assert.Equal(t, 2, pixels.Width)
+}
+
+func TestPixels_Patches(t *testing.T) {
+	pixels := Pixels{Channels: 2, Height: 2, Width: 4, Data: make([]float64, 16)}
+	for i := range pixels.Data {
+		pixels.Data[i] = float64(i)
+	}
+	patches := pixels.Patches(2)
+	assert.Equal(t, [][]float64{
+		{0, 1, 4, 5, 8, 9, 12, 13},
+		{2, 3, 6, 7, 10, 11, 14, 15},
+	}, patches)
+}
+
+func TestBicubicFilter(t *testing.T) {
+	// The filter interpolates the samples, and its weights sum up to one.
+	assert.Equal(t, 1.0, bicubicFilter(0))
+	assert.Equal(t, 0.0, bicubicFilter(1))
+	assert.Equal(t, 0.0, bicubicFilter(2))
+	for _, x := range []float64{0.1, 0.25, 0.5, 0.9} {
+		sum := bicubicFilter(x-2) + bicubicFilter(x-1) + bicubicFilter(x) + bicubicFilter(x+1)
+		assert.InDelta(t, 1.0, sum, 1e-12)
+	}
+}
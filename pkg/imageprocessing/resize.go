@@ -0,0 +1,220 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageprocessing
+
+import (
+	"fmt"
+	"math"
+)
+
+// rgbImage is an 8-bit RGB image, with the pixels stored row by row.
+type rgbImage struct {
+	width, height int
+	pix           []uint8
+}
+
+func newRGBImage(width, height int) *rgbImage {
+	return &rgbImage{
+		width:  width,
+		height: height,
+		pix:    make([]uint8, width*height*3),
+	}
+}
+
+// filter is a resampling filter, defined over [-support, support].
+type filter struct {
+	support float64
+	fn      func(x float64) float64
+}
+
+var filters = map[int]filter{
+	Box:      {support: 0.5, fn: boxFilter},
+	Bilinear: {support: 1, fn: bilinearFilter},
+	Hamming:  {support: 1, fn: hammingFilter},
+	Bicubic:  {support: 2, fn: bicubicFilter},
+	Lanczos:  {support: 3, fn: lanczosFilter},
+}
+
+// precisionBits is the number of bits of the fixed-point coefficients.
+const precisionBits = 32 - 8 - 2
+
+// resize returns the image resized to the given width and height.
+// It replicates the resampling of PIL (Image.resize), which Hugging Face
+// relies on, performing the horizontal and the vertical pass separately
+// with 8-bit fixed-point arithmetic.
+func resize(img *rgbImage, width, height, resample int) (*rgbImage, error) {
+	if img.width == width && img.height == height {
+		return img, nil
+	}
+	if resample == Nearest {
+		return resizeNearest(img, width, height), nil
+	}
+	f, ok := filters[resample]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resampling filter: %d", resample)
+	}
+	if img.width != width {
+		img = resampleHorizontal(img, width, f)
+	}
+	if img.height != height {
+		img = resampleVertical(img, height, f)
+	}
+	return img, nil
+}
+
+func resizeNearest(img *rgbImage, width, height int) *rgbImage {
+	out := newRGBImage(width, height)
+	scaleX := float64(img.width) / float64(width)
+	scaleY := float64(img.height) / float64(height)
+	for y := 0; y < height; y++ {
+		sy := min(int((float64(y)+0.5)*scaleY), img.height-1)
+		for x := 0; x < width; x++ {
+			sx := min(int((float64(x)+0.5)*scaleX), img.width-1)
+			copy(out.pix[(y*width+x)*3:(y*width+x+1)*3], img.pix[(sy*img.width+sx)*3:(sy*img.width+sx+1)*3])
+		}
+	}
+	return out
+}
+
+func resampleHorizontal(img *rgbImage, width int, f filter) *rgbImage {
+	out := newRGBImage(width, img.height)
+	bounds, coeffs := precomputeCoeffs(img.width, width, f)
+	for y := 0; y < img.height; y++ {
+		for x := 0; x < width; x++ {
+			xmin, k := bounds[x], coeffs[x]
+			for c := 0; c < 3; c++ {
+				ss := 1 << (precisionBits - 1)
+				for i, w := range k {
+					ss += int(img.pix[(y*img.width+xmin+i)*3+c]) * w
+				}
+				out.pix[(y*width+x)*3+c] = clip8(ss >> precisionBits)
+			}
+		}
+	}
+	return out
+}
+
+func resampleVertical(img *rgbImage, height int, f filter) *rgbImage {
+	out := newRGBImage(img.width, height)
+	bounds, coeffs := precomputeCoeffs(img.height, height, f)
+	for y := 0; y < height; y++ {
+		ymin, k := bounds[y], coeffs[y]
+		for x := 0; x < img.width; x++ {
+			for c := 0; c < 3; c++ {
+				ss := 1 << (precisionBits - 1)
+				for i, w := range k {
+					ss += int(img.pix[((ymin+i)*img.width+x)*3+c]) * w
+				}
+				out.pix[(y*img.width+x)*3+c] = clip8(ss >> precisionBits)
+			}
+		}
+	}
+	return out
+}
+
+// precomputeCoeffs returns, for each output pixel, the index of the first
+// input pixel contributing to it, and the fixed-point weights of the
+// contributing input pixels.
+func precomputeCoeffs(inSize, outSize int, f filter) ([]int, [][]int) {
+	scale := float64(inSize) / float64(outSize)
+	filterScale := math.Max(scale, 1)
+	support := f.support * filterScale
+
+	bounds := make([]int, outSize)
+	coeffs := make([][]int, outSize)
+	for i := range coeffs {
+		center := (float64(i) + 0.5) * scale
+		xmin := max(int(center-support+0.5), 0)
+		xmax := min(int(center+support+0.5), inSize) - xmin
+
+		weights := make([]float64, xmax)
+		total := 0.0
+		for x := range weights {
+			weights[x] = f.fn((float64(x+xmin) - center + 0.5) / filterScale)
+			total += weights[x]
+		}
+
+		k := make([]int, xmax)
+		for x, w := range weights {
+			if total != 0 {
+				w /= total
+			}
+			if w < 0 {
+				k[x] = int(-0.5 + w*(1<<precisionBits))
+			} else {
+				k[x] = int(0.5 + w*(1<<precisionBits))
+			}
+		}
+		bounds[i], coeffs[i] = xmin, k
+	}
+	return bounds, coeffs
+}
+
+func clip8(v int) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	default:
+		return uint8(v)
+	}
+}
+
+func boxFilter(x float64) float64 {
+	if x > -0.5 && x <= 0.5 {
+		return 1
+	}
+	return 0
+}
+
+func bilinearFilter(x float64) float64 {
+	x = math.Abs(x)
+	if x < 1 {
+		return 1 - x
+	}
+	return 0
+}
+
+func hammingFilter(x float64) float64 {
+	x = math.Abs(x)
+	switch {
+	case x == 0:
+		return 1
+	case x >= 1:
+		return 0
+	default:
+		x *= math.Pi
+		return math.Sin(x) / x * (0.54 + 0.46*math.Cos(x))
+	}
+}
+
+func bicubicFilter(x float64) float64 {
+	const a = -0.5
+	x = math.Abs(x)
+	switch {
+	case x < 1:
+		return ((a+2)*x-(a+3))*x*x + 1
+	case x < 2:
+		return (((x-5)*x+8)*x - 4) * a
+	default:
+		return 0
+	}
+}
+
+func lanczosFilter(x float64) float64 {
+	if x >= -3 && x < 3 {
+		return sinc(x) * sinc(x/3)
+	}
+	return 0
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	x *= math.Pi
+	return math.Sin(x) / x
+}
@@ -8,7 +8,6 @@ import (
 	"encoding/gob"
 	"sync"
 
-	"github.com/nlpodyssey/cybertron/pkg/generationutils"
 	"github.com/nlpodyssey/spago/ag"
 	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/mat/float"
@@ -67,6 +66,9 @@ type DecodingInput struct {
 	InputIDs []int
 	// CurLen is the current length of the generating sequence.
 	CurLen int
+	// MaxLength is the maximum length of the generating sequence, whose last
+	// token is forced to be the EOS. If zero, the one of the Config is used.
+	MaxLength int
 	// Cache is the cache for the decoder.
 	Cache Cache
 }
@@ -75,7 +77,8 @@ type DecodingInput struct {
 type DecodingOutput struct {
 	// LogProbRaw is the raw (not processed) log probability of the generated token.
 	LogProbRaw mat.Tensor
-	// LogProbValue is the post-processed log probability of the generated token.
+	// LogProbValue is the value of the log probability of the generated token,
+	// which is processed by the decoding search.
 	LogProbValue mat.Matrix
 	// NextCache is the next cache.
 	NextCache Cache
@@ -83,7 +86,7 @@ type DecodingOutput struct {
 
 // DecodingFunc returns a decoding function that works using the encoder states derived from the input.
 // During inference, it adjusts the logits to avoid impossible tokens.
-func (m *ModelForConditionalGeneration) DecodingFunc(encoderInputIDs []int, scoreProc generationutils.ScoreProcessor, inference bool) func(batch []*DecodingInput) []*DecodingOutput {
+func (m *ModelForConditionalGeneration) DecodingFunc(encoderInputIDs []int, inference bool) func(batch []*DecodingInput) []*DecodingOutput {
 	encoderStates := m.Bart.Encoder.Encode(encoderInputIDs)
 
 	return func(batch []*DecodingInput) []*DecodingOutput {
@@ -99,7 +102,6 @@ func (m *ModelForConditionalGeneration) DecodingFunc(encoderInputIDs []int, scor
 				result[i] = m.next(decodingState{
 					encoderStates: encoderStates,
 					decodingInput: item,
-					scoreProc:     scoreProc,
 					inference:     inference,
 				})
 			}()
@@ -113,11 +115,10 @@ func (m *ModelForConditionalGeneration) DecodingFunc(encoderInputIDs []int, scor
 type decodingState struct {
 	encoderStates []mat.Tensor
 	decodingInput *DecodingInput
-	scoreProc     generationutils.ScoreProcessor
 	inference     bool
 }
 
-// next returns the post-processed log probability for the generated tokens.
+// next returns the log probability for the generated tokens.
 func (m *ModelForConditionalGeneration) next(state decodingState) *DecodingOutput {
 	decoded, nextCache := m.Bart.Decoder.Decode(
 		state.encoderStates,
@@ -128,22 +129,25 @@ func (m *ModelForConditionalGeneration) next(state decodingState) *DecodingOutpu
 
 	logits := m.Projection.Forward(decoded...)[0]
 	if state.inference {
-		logits = m.adjustLogits(logits, state.decodingInput.CurLen)
+		logits = m.adjustLogits(logits, state.decodingInput.CurLen, state.decodingInput.MaxLength)
 	}
 
 	logProb := ag.LogSoftmax(logits)
 
 	return &DecodingOutput{
 		LogProbRaw:   logProb,
-		LogProbValue: state.scoreProc(logProb.Value().(mat.Matrix)),
+		LogProbValue: logProb.Value().(mat.Matrix),
 		NextCache:    nextCache,
 	}
 }
 
 // adjustLogits applies the mask to the logits to avoid impossible token from being generated during inference.
-func (m *ModelForConditionalGeneration) adjustLogits(xs mat.Tensor, curLen int) mat.Tensor {
+func (m *ModelForConditionalGeneration) adjustLogits(xs mat.Tensor, curLen, maxLength int) mat.Tensor {
+	if maxLength == 0 {
+		maxLength = m.Bart.Config.MaxLength
+	}
 	ys := ag.Add(xs, m.PadMask) // Don't generate pad token
-	if curLen == m.Bart.Config.MaxLength-1 && m.Bart.Config.EosTokenID >= 0 {
+	if curLen == maxLength-1 && m.Bart.Config.EosTokenID >= 0 {
 		ys = ag.Add(ys, m.EosMask) // Force EOS to be generated
 	}
 	return ys
@@ -48,6 +48,7 @@ type Config struct {
 	NumHiddenLayers            int               `json:"num_hidden_layers,omitempty"`
 	OutputPast                 bool              `json:"output_past,omitempty"`
 	PadTokenID                 int               `json:"pad_token_id,omitempty"`
+	ProblemType                string            `json:"problem_type,omitempty"`
 	ScaleEmbedding             bool              `json:"scale_embedding,omitempty"`
 	StaticPositionEmbeddings   bool              `json:"static_position_embeddings,omitempty"`
 	TotalFlos                  float64           `json:"total_flos,omitempty"`
@@ -65,6 +66,7 @@ type Config struct {
 		SharedEmbeddingsStoreName          string `json:"shared_embeddings_store_name,omitempty"`
 		DecoderPositionalEncodingStoreName string `json:"decoder_positional_encoding_store_name,omitempty"`
 		EncoderPositionalEncodingStoreName string `json:"encoder_positional_encoding_store_name,omitempty"`
+		FairseqPositionalEncoding          bool   `json:"fairseq_positional_encoding,omitempty"`
 	}
 }
 
@@ -88,6 +90,9 @@ func ConfigFromFile(file string) (Config, error) {
 	if config.NumBeams == 0 {
 		config.NumBeams = 4 // TODO: check if this is the default value?
 	}
+	if config.NumLabels == 0 {
+		config.NumLabels = len(config.ID2Label)
+	}
 	return config, nil
 }
 
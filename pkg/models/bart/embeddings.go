@@ -48,6 +48,7 @@ func NewEmbeddings[T float.DType](c Config, shared embedding.Shared, isDecoder b
 			Offset:        c.Cybertron.PositionalEncoderOffset,
 			StoreName:     storeName,
 			Trainable:     c.Cybertron.Training,
+			Fairseq:       c.Cybertron.FairseqPositionalEncoding,
 		}),
 		Norm:        layernorm.New[T](c.DModel, 1e-5),
 		ScaleFactor: scaleFactor,
@@ -7,6 +7,7 @@ package bart
 import (
 	"encoding/gob"
 	"log"
+	"math"
 
 	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/mat/float"
@@ -24,6 +25,9 @@ type PositionalEncoderConfig struct {
 	Offset        int
 	StoreName     string
 	Trainable     bool
+	// Fairseq reports whether the sinusoidal embeddings are computed as in
+	// fairseq (e.g. M2M100), rather than as in Marian.
+	Fairseq bool
 }
 
 // PositionalEncoder contains positional embeddings fine-tuned during
@@ -44,18 +48,14 @@ func init() {
 func NewPositionalEncoder[T float.DType](config PositionalEncoderConfig) *PositionalEncoder {
 	e := embedding.New[T](config.NumEmbeddings+config.Offset, config.EmbeddingDim)
 
-	size := config.EmbeddingDim
-	half := (size + (size % 2)) / 2
-
+	sinusoidal := marianSinusoidalEmbedding[T]
+	if config.Fairseq {
+		sinusoidal = fairseqSinusoidalEmbedding[T]
+	}
 	for i := 0; i < config.NumEmbeddings+config.Offset; i++ {
-		data := make([]T, size)
-		for j := 0; j < size; j++ {
-			v := T(i) / mat.Pow(10000, 2*T(j/2)/T(size))
-			if j%2 == 0 {
-				data[j/2] = mat.Sin(v)
-			} else {
-				data[half+j/2] = mat.Cos(v)
-			}
+		data := sinusoidal(i, config.EmbeddingDim)
+		if config.Fairseq && i == config.PaddingIDX {
+			data = make([]T, config.EmbeddingDim)
 		}
 		item, err := e.Embedding(i)
 		if err != nil {
@@ -69,6 +69,37 @@ func NewPositionalEncoder[T float.DType](config PositionalEncoderConfig) *Positi
 	}
 }
 
+// marianSinusoidalEmbedding returns the sinusoidal embedding of the given
+// position, with the sines in the first half and the cosines in the second.
+func marianSinusoidalEmbedding[T float.DType](pos, size int) []T {
+	half := (size + (size % 2)) / 2
+	data := make([]T, size)
+	for j := 0; j < size; j++ {
+		v := T(pos) / mat.Pow(10000, 2*T(j/2)/T(size))
+		if j%2 == 0 {
+			data[j/2] = mat.Sin(v)
+		} else {
+			data[half+j/2] = mat.Cos(v)
+		}
+	}
+	return data
+}
+
+// fairseqSinusoidalEmbedding returns the sinusoidal embedding of the given
+// position as computed by fairseq, whose frequencies are spaced by
+// log(10000)/(half-1) instead of log(10000)/half.
+func fairseqSinusoidalEmbedding[T float.DType](pos, size int) []T {
+	half := size / 2
+	step := math.Log(10000) / float64(half-1)
+	data := make([]T, size)
+	for k := 0; k < half; k++ {
+		v := float64(pos) * math.Exp(-float64(k)*step)
+		data[k] = T(math.Sin(v))
+		data[half+k] = T(math.Cos(v))
+	}
+	return data
+}
+
 // Encode performs the forward step for each input and returns the result.
 func (m *PositionalEncoder) Encode(positions []int) []mat.Tensor {
 	return m.Embeddings.MustEncode(m.shift(positions))
@@ -0,0 +1,49 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForIntentAndSlots{}
+
+// ModelForIntentAndSlots implements a Bert model for joint intent detection and slot filling.
+// A single encoder is shared by a sequence classifier for the intent and a token classifier for the slots.
+type ModelForIntentAndSlots struct {
+	nn.Module
+	// Bert is the fine-tuned BERT model.
+	Bert *Model
+	// IntentClassifier is the linear layer for the intent classification of the pooled `[CLS]` token.
+	IntentClassifier *linear.Model
+	// SlotClassifier is the linear layer for the slot classification of each token.
+	SlotClassifier *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForIntentAndSlots{})
+}
+
+// NewModelForIntentAndSlots returns a new model for joint intent detection and slot filling.
+func NewModelForIntentAndSlots[T float.DType](bert *Model) *ModelForIntentAndSlots {
+	return &ModelForIntentAndSlots{
+		Bert:             bert,
+		IntentClassifier: linear.New[T](bert.Config.HiddenSize, len(bert.Config.IntentID2Label)),
+		SlotClassifier:   linear.New[T](bert.Config.HiddenSize, len(bert.Config.SlotID2Label)),
+	}
+}
+
+// Classify returns the logits for the intent and the logits of the slots for each token.
+func (m *ModelForIntentAndSlots) Classify(tokens []string) (intent mat.Tensor, slots []mat.Tensor) {
+	encoded := m.Bert.EncodeTokens(tokens)
+	intent = m.IntentClassifier.Forward(m.Bert.Pooler.Forward(encoded[0]))[0]
+	slots = m.SlotClassifier.Forward(encoded...)
+	return
+}
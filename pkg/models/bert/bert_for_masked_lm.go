@@ -7,6 +7,7 @@ package bert
 import (
 	"encoding/gob"
 
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
 	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/mat/float"
@@ -37,10 +38,10 @@ func NewModelForMaskedLM[T float.DType](bert *Model) *ModelForMaskedLM {
 	return &ModelForMaskedLM{
 		Bert: bert,
 		Layers: []nn.StandardModel{
-			linear.New[T](c.HiddenSize, c.HiddenSize),
-			activation.New(activation.MustParseActivation(c.HiddenAct)),
-			layernorm.New[T](c.HiddenSize, 1e-5),
-			linear.New[T](c.HiddenSize, c.VocabSize),
+			linear.New[T](c.HiddenSize, c.EmbeddingsSize),
+			activation.New(activation.MustParseActivation(c.Activation())),
+			layernorm.New[T](c.EmbeddingsSize, 1e-5),
+			linear.New[T](c.EmbeddingsSize, c.VocabSize),
 		},
 	}
 }
@@ -65,7 +66,7 @@ func waitForComputation(xs ...mat.Tensor) []mat.Tensor {
 func masked(tokens []string) []int {
 	result := make([]int, 0)
 	for i := range tokens {
-		if tokens[i] == wordpiecetokenizer.DefaultMaskToken {
+		if tokens[i] == wordpiecetokenizer.DefaultMaskToken || tokens[i] == bpetokenizer.DefaultMaskToken {
 			result = append(result, i) // target tokens
 		}
 	}
@@ -0,0 +1,43 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForMultipleChoice{}
+
+// ModelForMultipleChoice implements a Bert model for multiple choice (e.g. SWAG, RACE).
+type ModelForMultipleChoice struct {
+	nn.Module
+	// Bert is the fine-tuned BERT model.
+	Bert *Model
+	// Classifier is the linear layer scoring each choice.
+	Classifier *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForMultipleChoice{})
+}
+
+// NewModelForMultipleChoice returns a new model for multiple choice.
+func NewModelForMultipleChoice[T float.DType](bert *Model) *ModelForMultipleChoice {
+	return &ModelForMultipleChoice{
+		Bert:       bert,
+		Classifier: linear.New[T](bert.Config.HiddenSize, 1),
+	}
+}
+
+// Score returns the logit of a choice, given the tokens of the context followed by the choice.
+// The logits of all the choices are normalized together to obtain the probability of each choice.
+func (m *ModelForMultipleChoice) Score(tokens []string) mat.Tensor {
+	return m.Classifier.Forward(m.Bert.Pooler.Forward(m.Bert.EncodeTokens(tokens)[0]))[0]
+}
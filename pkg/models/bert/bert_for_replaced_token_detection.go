@@ -0,0 +1,50 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForReplacedTokenDetection{}
+
+// ModelForReplacedTokenDetection implements the ELECTRA discriminator,
+// which predicts whether each token of the input has been replaced.
+type ModelForReplacedTokenDetection struct {
+	nn.Module
+	// Bert is the discriminator encoder.
+	Bert *Model
+	// Layers contains the feedforward layers of the discriminator predictions.
+	Layers nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&ModelForReplacedTokenDetection{})
+}
+
+// NewModelForReplacedTokenDetection returns a new model for replaced token detection.
+func NewModelForReplacedTokenDetection[T float.DType](bert *Model) *ModelForReplacedTokenDetection {
+	c := bert.Config
+	return &ModelForReplacedTokenDetection{
+		Bert: bert,
+		Layers: []nn.StandardModel{
+			linear.New[T](c.HiddenSize, c.HiddenSize),
+			activation.New(activation.MustParseActivation(c.Activation())),
+			linear.New[T](c.HiddenSize, 1),
+		},
+	}
+}
+
+// Discriminate returns, for each token, the probability that it has been replaced.
+func (m *ModelForReplacedTokenDetection) Discriminate(tokens []string) []mat.Tensor {
+	return ag.Map(ag.Sigmoid, m.Layers.Forward(m.Bert.EncodeTokens(tokens)...))
+}
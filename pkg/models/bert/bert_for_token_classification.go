@@ -10,6 +10,7 @@ import (
 	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/mat/float"
 	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/crf"
 	"github.com/nlpodyssey/spago/nn/linear"
 )
 
@@ -22,6 +23,9 @@ type ModelForTokenClassification struct {
 	Bert *Model
 	// Classifier is the linear layer for sequence classification.
 	Classifier *linear.Model
+	// CRF is the optional linear-chain CRF layer on top of the classifier.
+	// It is nil if the model has been trained without it.
+	CRF *crf.Model
 }
 
 func init() {
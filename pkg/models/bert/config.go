@@ -6,7 +6,10 @@ package bert
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 )
 
 // Config contains the global configuration of the Bert model and the heads of fine-tuning tasks.
@@ -19,6 +22,7 @@ type Config struct {
 	HiddenDropoutProb         float64           `json:"hidden_dropout_prob"`
 	HiddenSize                int               `json:"hidden_size"`
 	EmbeddingsSize            int               `json:"embeddings_size"`
+	EmbeddingSize             int               `json:"embedding_size"`
 	InitializerRange          float64           `json:"initializer_range"`
 	IntermediateSize          int               `json:"intermediate_size"`
 	LayerNormEps              float64           `json:"layer_norm_eps"`
@@ -26,6 +30,8 @@ type Config struct {
 	ModelType                 string            `json:"model_type"`
 	NumAttentionHeads         int               `json:"num_attention_heads"`
 	NumHiddenLayers           int               `json:"num_hidden_layers"`
+	NumHiddenGroups           int               `json:"num_hidden_groups"`
+	InnerGroupNum             int               `json:"inner_group_num"`
 	PadTokenId                int               `json:"pad_token_id"`
 	PositionEmbeddingType     string            `json:"position_embedding_type"`
 	TransformersVersion       string            `json:"transformers_version"`
@@ -33,6 +39,9 @@ type Config struct {
 	UseCache                  bool              `json:"use_cache"`
 	VocabSize                 int               `json:"vocab_size"`
 	ID2Label                  map[string]string `json:"id2label"`
+	ProblemType               string            `json:"problem_type"`
+	IntentID2Label            map[string]string `json:"intent_id2label"`
+	SlotID2Label              map[string]string `json:"slot_id2label"`
 	Cybertron                 struct {
 		Training            bool   `json:"training"`
 		TokensStoreName     string `json:"tokens_store_name"`
@@ -41,6 +50,65 @@ type Config struct {
 	}
 }
 
+// PositionIDsOffset returns the offset to add to the position ids of the input tokens.
+// RoBERTa-like models reserve the first `pad_token_id + 1` positions, following fairseq.
+func (c Config) PositionIDsOffset() int {
+	switch c.ModelType {
+	case "roberta", "xlm-roberta":
+		return c.PadTokenId + 1
+	default:
+		return 0
+	}
+}
+
+// MaxSequenceLength returns the maximum number of tokens the model can encode.
+func (c Config) MaxSequenceLength() int {
+	return c.MaxPositionEmbeddings - c.PositionIDsOffset()
+}
+
+// NumEncoderLayers returns the number of distinct encoder layers.
+// ALBERT shares the parameters of the layers across depth, so that the
+// NumHiddenLayers layers are computed by NumHiddenGroups layers only.
+func (c Config) NumEncoderLayers() int {
+	if c.NumHiddenGroups > 0 {
+		return c.NumHiddenGroups
+	}
+	return c.NumHiddenLayers
+}
+
+// Activation returns the name of the activation function of the hidden layers.
+func (c Config) Activation() string {
+	if c.HiddenAct == "gelu_new" {
+		return "gelu"
+	}
+	return c.HiddenAct
+}
+
+// EntailmentID returns the id of the `entailment` label.
+func (c Config) EntailmentID() (int, error) {
+	return c.labelID("entailment")
+}
+
+// ContradictionID returns the id of the `contradiction` label.
+func (c Config) ContradictionID() (int, error) {
+	return c.labelID("contradiction")
+}
+
+// labelID returns the id of the given label, ignoring the case.
+func (c Config) labelID(label string) (int, error) {
+	for k, v := range c.ID2Label {
+		if !strings.EqualFold(v, label) {
+			continue
+		}
+		id, err := strconv.Atoi(k)
+		if err != nil {
+			return -1, fmt.Errorf("bert: invalid id %#v for label `%s`: %w", k, label, err)
+		}
+		return id, nil
+	}
+	return -1, fmt.Errorf("bert: `%s` label not found", label)
+}
+
 // TokenizerConfig contains the configuration of the tokenizer.
 // The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
 type TokenizerConfig struct {
@@ -49,7 +49,7 @@ func NewEmbeddings[T float.DType](c Config) *Embeddings {
 func (m *Embeddings) EncodeTokens(tokens []string) []mat.Tensor {
 	var (
 		encoded      = m.Tokens.MustEncode(m.tokensToIDs(tokens))
-		positions    = m.Positions.MustEncode(indices(len(tokens)))
+		positions    = m.Positions.MustEncode(indices(len(tokens), m.Config.PositionIDsOffset()))
 		tokenType, _ = m.TokenTypes.Embedding(0)
 	)
 
@@ -82,11 +82,11 @@ func (m *Embeddings) useProjection(xs []mat.Tensor) []mat.Tensor {
 }
 
 // indices returns a slice of the given size, where each element has
-// the same value of its own index position.
-func indices(size int) []int {
+// the same value of its own index position plus the given offset.
+func indices(size, offset int) []int {
 	idx := make([]int, size)
 	for i := range idx {
-		idx[i] = i
+		idx[i] = i + offset
 	}
 	return idx
 }
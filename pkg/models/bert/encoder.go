@@ -27,8 +27,8 @@ func init() {
 
 // NewEncoder returns a new Encoder.
 func NewEncoder[T float.DType](c Config) *Encoder {
-	layers := make([]*EncoderLayer, c.NumHiddenLayers)
-	for i := 0; i < c.NumHiddenLayers; i++ {
+	layers := make([]*EncoderLayer, c.NumEncoderLayers())
+	for i := range layers {
 		layers[i] = NewEncoderLayer[T](c)
 	}
 	return &Encoder{
@@ -38,6 +38,15 @@ func NewEncoder[T float.DType](c Config) *Encoder {
 }
 
 // Encode performs the Bert encoding.
+// When the layers are shared across depth (ALBERT), each group of
+// consecutive hidden layers reuses the same encoder layer.
 func (e *Encoder) Encode(xs []mat.Tensor) []mat.Tensor {
-	return e.Layers.Forward(xs...)
+	n := e.Config.NumHiddenLayers
+	if len(e.Layers) == n {
+		return e.Layers.Forward(xs...)
+	}
+	for i := 0; i < n; i++ {
+		xs = e.Layers[i*len(e.Layers)/n].Forward(xs...)
+	}
+	return xs
 }
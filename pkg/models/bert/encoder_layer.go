@@ -37,7 +37,7 @@ func NewEncoderLayer[T float.DType](c Config) *EncoderLayer {
 		FF: NewFeedForwardBlock[T](FeedForwardBlockConfig{
 			Dim:        c.HiddenSize,
 			HiddenDim:  c.IntermediateSize,
-			Activation: activation.MustParseActivation(c.HiddenAct),
+			Activation: activation.MustParseActivation(c.Activation()),
 		}),
 		Config: c,
 	}
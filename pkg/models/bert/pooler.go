@@ -25,10 +25,15 @@ func init() {
 
 // NewPooler returns a new Pooler.
 func NewPooler[T float.DType](c Config) *Pooler {
+	act := activation.Tanh
+	if c.ModelType == "electra" {
+		// ELECTRA has no pooler, but its classification head is equivalent to a pooler with GELU activation.
+		act = activation.GELU
+	}
 	return &Pooler{
 		Model: []nn.StandardModel{
 			linear.New[T](c.HiddenSize, c.HiddenSize),
-			activation.New(activation.Tanh),
+			activation.New(act),
 		},
 	}
 }
@@ -0,0 +1,78 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clip implements the Contrastive Language-Image Pre-training (CLIP)
+// model introduced by Radford et al., 2021.
+// "Learning Transferable Visual Models From Natural Language Supervision"
+// https://arxiv.org/abs/2103.00020
+package clip
+
+import (
+	"encoding/gob"
+	"math"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements the CLIP dual encoder, which maps texts and images
+// to a shared vector space.
+type Model struct {
+	nn.Module
+	// Text is the text encoder.
+	Text *TextModel
+	// Vision is the image encoder.
+	Vision *VisionModel
+	// TextProjection projects the text representation to the shared space.
+	TextProjection *linear.Model
+	// VisualProjection projects the image representation to the shared space.
+	VisualProjection *linear.Model
+	// LogitScale is the logarithm of the temperature that scales the cosine similarities.
+	LogitScale *nn.Param
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new CLIP model.
+func New[T float.DType](c Config) *Model {
+	return &Model{
+		Text:             NewTextModel[T](c.TextConfig),
+		Vision:           NewVisionModel[T](c.VisionConfig),
+		TextProjection:   linear.New[T](c.TextConfig.HiddenSize, c.ProjectionDim),
+		VisualProjection: linear.New[T](c.VisionConfig.HiddenSize, c.ProjectionDim),
+		LogitScale:       nn.NewParam(mat.Scalar(T(c.LogitScaleInitValue))),
+		Config:           c,
+	}
+}
+
+// EncodeText returns the embedding of the text, given the IDs of its tokens,
+// including the start and the end of text tokens.
+func (m *Model) EncodeText(inputIDs []int) mat.Tensor {
+	pooled := m.Text.Pool(inputIDs, m.Text.Encode(inputIDs))
+	return m.TextProjection.Forward(pooled)[0]
+}
+
+// EncodeImage returns the embedding of the image.
+func (m *Model) EncodeImage(pixels imageprocessing.Pixels) (mat.Tensor, error) {
+	encoded, err := m.Vision.Encode(pixels)
+	if err != nil {
+		return nil, err
+	}
+	return m.VisualProjection.Forward(m.Vision.Pool(encoded))[0], nil
+}
+
+// Scale returns the factor applied to the cosine similarities between
+// image and text embeddings to obtain the logits.
+func (m *Model) Scale() float64 {
+	return math.Exp(m.LogitScale.Value().(mat.Matrix).Item().F64())
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the global configuration of the CLIP model.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Architectures       []string     `json:"architectures"`
+	LogitScaleInitValue float64      `json:"logit_scale_init_value"`
+	ModelType           string       `json:"model_type"`
+	ProjectionDim       int          `json:"projection_dim"`
+	TextConfig          TextConfig   `json:"text_config"`
+	VisionConfig        VisionConfig `json:"vision_config"`
+	Cybertron           struct {
+		Training bool `json:"training"`
+	}
+}
+
+// TextConfig contains the configuration of the CLIP text encoder.
+type TextConfig struct {
+	BosTokenID            int     `json:"bos_token_id"`
+	EosTokenID            int     `json:"eos_token_id"`
+	HiddenAct             string  `json:"hidden_act"`
+	HiddenSize            int     `json:"hidden_size"`
+	IntermediateSize      int     `json:"intermediate_size"`
+	LayerNormEps          float64 `json:"layer_norm_eps"`
+	MaxPositionEmbeddings int     `json:"max_position_embeddings"`
+	NumAttentionHeads     int     `json:"num_attention_heads"`
+	NumHiddenLayers       int     `json:"num_hidden_layers"`
+	VocabSize             int     `json:"vocab_size"`
+}
+
+// VisionConfig contains the configuration of the CLIP image encoder.
+type VisionConfig struct {
+	HiddenAct         string  `json:"hidden_act"`
+	HiddenSize        int     `json:"hidden_size"`
+	ImageSize         int     `json:"image_size"`
+	IntermediateSize  int     `json:"intermediate_size"`
+	LayerNormEps      float64 `json:"layer_norm_eps"`
+	NumAttentionHeads int     `json:"num_attention_heads"`
+	NumChannels       int     `json:"num_channels"`
+	NumHiddenLayers   int     `json:"num_hidden_layers"`
+	PatchSize         int     `json:"patch_size"`
+}
+
+// ConfigFromFile loads a CLIP model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face CLIP configuration,
+// used for the keys missing from the JSON file (the text and vision configurations
+// usually contain only the values that differ from the defaults).
+func baseConfig() Config {
+	return Config{
+		LogitScaleInitValue: 2.6592,
+		ProjectionDim:       512,
+		TextConfig: TextConfig{
+			BosTokenID:            49406,
+			EosTokenID:            49407,
+			HiddenAct:             "quick_gelu",
+			HiddenSize:            512,
+			IntermediateSize:      2048,
+			LayerNormEps:          1e-5,
+			MaxPositionEmbeddings: 77,
+			NumAttentionHeads:     8,
+			NumHiddenLayers:       12,
+			VocabSize:             49408,
+		},
+		VisionConfig: VisionConfig{
+			HiddenAct:         "quick_gelu",
+			HiddenSize:        768,
+			ImageSize:         224,
+			IntermediateSize:  3072,
+			LayerNormEps:      1e-5,
+			NumAttentionHeads: 12,
+			NumChannels:       3,
+			NumHiddenLayers:   12,
+			PatchSize:         32,
+		},
+	}
+}
+
+// NumPatches returns the number of patches in which the images are split.
+func (c VisionConfig) NumPatches() int {
+	n := c.ImageSize / c.PatchSize
+	return n * n
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var (
+	_ nn.Model = &EncoderLayer{}
+	_ nn.Model = &QuickGELU{}
+)
+
+// EncoderLayer implements a CLIP transformer layer, shared by the text and the image
+// encoders, with the layer normalization applied before the self-attention and
+// the feed-forward layers.
+type EncoderLayer struct {
+	nn.Module
+	// AttentionNorm is the normalization applied before the self-attention.
+	AttentionNorm *layernorm.Model
+	// Attention is the self-attention module, which is causal in the text encoder.
+	Attention *multiheadattention.Model
+	// FFNorm is the normalization applied before the feed-forward.
+	FFNorm *layernorm.Model
+	// FF is the feed-forward module.
+	FF nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&EncoderLayer{})
+	gob.Register(&QuickGELU{})
+}
+
+// encoderLayerConfig contains the settings shared by the text and the vision configurations.
+type encoderLayerConfig struct {
+	HiddenSize        int
+	IntermediateSize  int
+	NumAttentionHeads int
+	HiddenAct         string
+	LayerNormEps      float64
+	UseCausalMask     bool
+}
+
+// newEncoderLayer returns a new EncoderLayer.
+func newEncoderLayer[T float.DType](c encoderLayerConfig) *EncoderLayer {
+	return &EncoderLayer{
+		AttentionNorm: layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Attention:     multiheadattention.New[T](c.HiddenSize, c.NumAttentionHeads, c.UseCausalMask, false),
+		FFNorm:        layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		FF: []nn.StandardModel{
+			linear.New[T](c.HiddenSize, c.IntermediateSize),
+			newActivation(c.HiddenAct),
+			linear.New[T](c.IntermediateSize, c.HiddenSize),
+		},
+	}
+}
+
+// Forward performs the forward step of the layer.
+func (m *EncoderLayer) Forward(xs []mat.Tensor) []mat.Tensor {
+	norm := m.AttentionNorm.Forward(xs...)
+	att, _, _ := m.Attention.Forward(nil, norm, norm)
+	hs := ag.Map2(ag.Add, xs, att)
+	return ag.Map2(ag.Add, hs, m.FF.Forward(m.FFNorm.Forward(hs...)...))
+}
+
+// newActivation returns the activation module with the given name,
+// including the "quick_gelu" used by the original CLIP models.
+func newActivation(name string) nn.StandardModel {
+	if name == "quick_gelu" {
+		return &QuickGELU{}
+	}
+	return activation.New(activation.MustParseActivation(name))
+}
+
+// QuickGELU is the sigmoid approximation of the GELU activation: x * sigmoid(1.702 * x).
+type QuickGELU struct {
+	nn.Module
+}
+
+// Forward returns the activation of each input.
+func (m *QuickGELU) Forward(xs ...mat.Tensor) []mat.Tensor {
+	ys := make([]mat.Tensor, len(xs))
+	for i, x := range xs {
+		ys[i] = ag.Prod(x, ag.Sigmoid(ag.ProdScalar(x, x.Value().(mat.Matrix).NewScalar(1.702))))
+	}
+	return ys
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &TextModel{}
+
+// TextModel implements the CLIP text encoder, a causal transformer.
+type TextModel struct {
+	nn.Module
+	// Tokens contains the token embeddings.
+	Tokens *embedding.Model
+	// Positions contains the learned position embeddings.
+	Positions *embedding.Model
+	// Layers is the list of transformer layers.
+	Layers []*EncoderLayer
+	// LayerNorm is the final layer normalization.
+	LayerNorm *layernorm.Model
+	// Config is the text encoder configuration.
+	Config TextConfig
+}
+
+func init() {
+	gob.Register(&TextModel{})
+}
+
+// NewTextModel returns a new TextModel.
+func NewTextModel[T float.DType](c TextConfig) *TextModel {
+	layers := make([]*EncoderLayer, c.NumHiddenLayers)
+	for i := range layers {
+		layers[i] = newEncoderLayer[T](encoderLayerConfig{
+			HiddenSize:        c.HiddenSize,
+			IntermediateSize:  c.IntermediateSize,
+			NumAttentionHeads: c.NumAttentionHeads,
+			HiddenAct:         c.HiddenAct,
+			LayerNormEps:      c.LayerNormEps,
+			UseCausalMask:     true,
+		})
+	}
+	return &TextModel{
+		Tokens:    embedding.New[T](c.VocabSize, c.HiddenSize),
+		Positions: embedding.New[T](c.MaxPositionEmbeddings, c.HiddenSize),
+		Layers:    layers,
+		LayerNorm: layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Config:    c,
+	}
+}
+
+// Encode returns the hidden states of the input tokens.
+func (m *TextModel) Encode(inputIDs []int) []mat.Tensor {
+	positionIDs := make([]int, len(inputIDs))
+	for i := range positionIDs {
+		positionIDs[i] = i
+	}
+	ys := ag.Map2(ag.Add, m.Tokens.MustEncode(inputIDs), m.Positions.MustEncode(positionIDs))
+	for _, layer := range m.Layers {
+		ys = layer.Forward(ys)
+	}
+	return m.LayerNorm.Forward(ys...)
+}
+
+// Pool returns the hidden state of the end of text token, which summarizes the input.
+func (m *TextModel) Pool(inputIDs []int, hiddenStates []mat.Tensor) mat.Tensor {
+	return hiddenStates[m.endOfTextIndex(inputIDs)]
+}
+
+// endOfTextIndex returns the position of the end of text token.
+// As in Hugging Face, the legacy configurations with the wrong EOS token ID (2)
+// are handled by taking the token with the highest ID, i.e. the end of text token.
+func (m *TextModel) endOfTextIndex(inputIDs []int) int {
+	index := 0
+	for i, id := range inputIDs {
+		if m.Config.EosTokenID == 2 {
+			if id > inputIDs[index] {
+				index = i
+			}
+			continue
+		}
+		if id == m.Config.EosTokenID {
+			return i
+		}
+	}
+	return index
+}
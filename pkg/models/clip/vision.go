@@ -0,0 +1,101 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &VisionModel{}
+
+// VisionModel implements the CLIP image encoder, a Vision Transformer.
+type VisionModel struct {
+	nn.Module
+	// Patches is the projection of the flattened patches, equivalent to
+	// a convolution with kernel size and stride equal to the patch size.
+	Patches *linear.Model
+	// ClassToken is the embedding of the class token, prepended to the patches.
+	ClassToken *nn.Param
+	// Positions contains the learned position embeddings.
+	Positions *embedding.Model
+	// PreLayerNorm is the normalization applied to the embeddings.
+	PreLayerNorm *layernorm.Model
+	// Layers is the list of transformer layers.
+	Layers []*EncoderLayer
+	// PostLayerNorm is the normalization applied to the hidden state of the class token.
+	PostLayerNorm *layernorm.Model
+	// Config is the image encoder configuration.
+	Config VisionConfig
+}
+
+func init() {
+	gob.Register(&VisionModel{})
+}
+
+// NewVisionModel returns a new VisionModel.
+func NewVisionModel[T float.DType](c VisionConfig) *VisionModel {
+	layers := make([]*EncoderLayer, c.NumHiddenLayers)
+	for i := range layers {
+		layers[i] = newEncoderLayer[T](encoderLayerConfig{
+			HiddenSize:        c.HiddenSize,
+			IntermediateSize:  c.IntermediateSize,
+			NumAttentionHeads: c.NumAttentionHeads,
+			HiddenAct:         c.HiddenAct,
+			LayerNormEps:      c.LayerNormEps,
+		})
+	}
+	return &VisionModel{
+		Patches:       linear.New[T](c.NumChannels*c.PatchSize*c.PatchSize, c.HiddenSize),
+		ClassToken:    nn.NewParam(mat.NewDense[T](mat.WithShape(c.HiddenSize))),
+		Positions:     embedding.New[T](c.NumPatches()+1, c.HiddenSize),
+		PreLayerNorm:  layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Layers:        layers,
+		PostLayerNorm: layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Config:        c,
+	}
+}
+
+// Encode returns the hidden states of the class token, at index 0,
+// followed by those of the patches of the image.
+func (m *VisionModel) Encode(pixels imageprocessing.Pixels) ([]mat.Tensor, error) {
+	c := m.Config
+	if pixels.Channels != c.NumChannels || pixels.Height != c.ImageSize || pixels.Width != c.ImageSize {
+		return nil, fmt.Errorf("clip: unexpected image size %dx%dx%d, expected %dx%dx%d",
+			pixels.Channels, pixels.Height, pixels.Width, c.NumChannels, c.ImageSize, c.ImageSize)
+	}
+	w := m.Patches.W.Value().(mat.Matrix)
+	patches := pixels.Patches(c.PatchSize)
+	xs := make([]mat.Tensor, 0, len(patches)+1)
+	xs = append(xs, m.ClassToken)
+	for _, p := range patches {
+		xs = append(xs, w.NewMatrix(mat.WithBacking(p)))
+	}
+	xs = append(xs[:1], m.Patches.Forward(xs[1:]...)...)
+
+	positions := make([]int, len(xs))
+	for i := range positions {
+		positions[i] = i
+	}
+	ys := m.PreLayerNorm.Forward(ag.Map2(ag.Add, xs, m.Positions.MustEncode(positions))...)
+	for _, layer := range m.Layers {
+		ys = layer.Forward(ys)
+	}
+	return ys, nil
+}
+
+// Pool returns the normalized hidden state of the class token, which summarizes the image.
+func (m *VisionModel) Pool(hiddenStates []mat.Tensor) mat.Tensor {
+	return m.PostLayerNorm.Forward(hiddenStates[0])[0]
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+	"math"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &Attention{}
+
+// Attention implements the disentangled self-attention of DeBERTa.
+//
+// Each token is represented by its content, while the position information is
+// given by the relative position embeddings. The attention scores are the sum
+// of the content-to-content scores, and of the content-to-position ("c2p") and
+// position-to-content ("p2c") scores computed between the contents and the
+// relative position embeddings.
+type Attention struct {
+	nn.Module
+	// Queries contains the query projection of each attention head.
+	Queries []*linear.Model
+	// Keys contains the key projection of each attention head.
+	Keys []*linear.Model
+	// Values contains the value projection of each attention head.
+	Values []*linear.Model
+	// PosQueries contains the projection of the relative position embeddings
+	// used for the position-to-content scores. It is nil if the projections
+	// are shared with the queries.
+	PosQueries []*linear.Model
+	// PosKeys contains the projection of the relative position embeddings
+	// used for the content-to-position scores. It is nil if the projections
+	// are shared with the keys.
+	PosKeys []*linear.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Attention{})
+}
+
+// NewAttention returns a new Attention.
+func NewAttention[T float.DType](c Config) *Attention {
+	newProjections := func() []*linear.Model {
+		projections := make([]*linear.Model, c.NumAttentionHeads)
+		for i := range projections {
+			projections[i] = linear.New[T](c.HiddenSize, c.HeadDim())
+		}
+		return projections
+	}
+	m := &Attention{
+		Queries: newProjections(),
+		Keys:    newProjections(),
+		Values:  newProjections(),
+		Config:  c,
+	}
+	if c.RelativeAttention && !c.ShareAttKey {
+		if c.PosAttType.Has("p2c") {
+			m.PosQueries = newProjections()
+		}
+		if c.PosAttType.Has("c2p") {
+			m.PosKeys = newProjections()
+		}
+	}
+	return m
+}
+
+// Forward performs the attention of xs. The relative position embeddings
+// rel are the ones in the range of the relative positions pos.
+// If the relative attention is disabled, rel and pos are ignored.
+func (m *Attention) Forward(xs, rel []mat.Tensor, pos *RelativePositions) []mat.Tensor {
+	scaleFactor := 1
+	for _, t := range []string{"c2p", "p2c"} {
+		if m.Config.PosAttType.Has(t) {
+			scaleFactor++
+		}
+	}
+	scale := xs[0].Value().(mat.Matrix).NewScalar(1 / math.Sqrt(float64(m.Config.HeadDim()*scaleFactor)))
+
+	heads := make([][]mat.Tensor, len(m.Queries))
+	for h := range m.Queries {
+		q := ag.Stack(m.Queries[h].Forward(xs...)...)
+		k := ag.Stack(m.Keys[h].Forward(xs...)...)
+		v := ag.Stack(m.Values[h].Forward(xs...)...)
+
+		scores := ag.Mul(q, ag.T(k))
+		if m.Config.RelativeAttention {
+			scores = m.addRelativeScores(h, scores, q, k, rel, pos)
+		}
+		scores = ag.ProdScalar(scores, scale)
+
+		heads[h] = make([]mat.Tensor, len(xs))
+		for i := range xs {
+			heads[h][i] = ag.MulT(v, ag.Softmax(ag.T(ag.RowView(scores, i))))
+		}
+	}
+
+	concat := make([]mat.Tensor, len(xs))
+	for i := range xs {
+		buf := make([]mat.Tensor, len(heads))
+		for h := range heads {
+			buf[h] = heads[h][i]
+		}
+		concat[i] = ag.Concat(buf...)
+	}
+	return concat
+}
+
+// addRelativeScores adds the content-to-position and position-to-content
+// scores of the h-th head to the content-to-content scores.
+func (m *Attention) addRelativeScores(h int, scores, q, k mat.Tensor, rel []mat.Tensor, pos *RelativePositions) mat.Tensor {
+	if m.Config.PosAttType.Has("c2p") {
+		posKeys := m.Keys[h]
+		if m.PosKeys != nil {
+			posKeys = m.PosKeys[h]
+		}
+		c2p := ag.Mul(q, ag.T(ag.Stack(posKeys.Forward(rel...)...)))
+		scores = ag.Add(scores, gather(c2p, pos.ContentToPosition))
+	}
+	if m.Config.PosAttType.Has("p2c") {
+		posQueries := m.Queries[h]
+		if m.PosQueries != nil {
+			posQueries = m.PosQueries[h]
+		}
+		p2c := ag.Mul(k, ag.T(ag.Stack(posQueries.Forward(rel...)...)))
+		scores = ag.Add(scores, ag.T(gather(p2c, pos.PositionToContent)))
+	}
+	return scores
+}
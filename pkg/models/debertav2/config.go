@@ -0,0 +1,175 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config contains the global configuration of the DeBERTa-v2 model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Architectures         []string               `json:"architectures"`
+	ConvKernelSize        int                    `json:"conv_kernel_size"`
+	EmbeddingSize         int                    `json:"embedding_size"`
+	HiddenAct             string                 `json:"hidden_act"`
+	HiddenSize            int                    `json:"hidden_size"`
+	InitializerRange      float64                `json:"initializer_range"`
+	IntermediateSize      int                    `json:"intermediate_size"`
+	LayerNormEps          float64                `json:"layer_norm_eps"`
+	MaxPositionEmbeddings int                    `json:"max_position_embeddings"`
+	MaxRelativePositions  int                    `json:"max_relative_positions"`
+	ModelType             string                 `json:"model_type"`
+	NormRelEbd            string                 `json:"norm_rel_ebd"`
+	NumAttentionHeads     int                    `json:"num_attention_heads"`
+	NumHiddenLayers       int                    `json:"num_hidden_layers"`
+	PadTokenID            int                    `json:"pad_token_id"`
+	PoolerHiddenAct       string                 `json:"pooler_hidden_act"`
+	PoolerHiddenSize      int                    `json:"pooler_hidden_size"`
+	PosAttType            PositionAttentionTypes `json:"pos_att_type"`
+	PositionBiasedInput   bool                   `json:"position_biased_input"`
+	PositionBuckets       int                    `json:"position_buckets"`
+	RelativeAttention     bool                   `json:"relative_attention"`
+	ShareAttKey           bool                   `json:"share_att_key"`
+	TypeVocabSize         int                    `json:"type_vocab_size"`
+	VocabSize             int                    `json:"vocab_size"`
+	ID2Label              map[string]string      `json:"id2label"`
+	Label2ID              map[string]int         `json:"label2id"`
+	ProblemType           string                 `json:"problem_type"`
+	Cybertron             struct {
+		Training bool `json:"training"`
+	}
+}
+
+// PositionAttentionTypes is the list of the position attention types
+// (i.e. "c2p" for content-to-position and "p2c" for position-to-content).
+// In the JSON configuration it can be either a list, or a string
+// with the types separated by "|".
+type PositionAttentionTypes []string
+
+// UnmarshalJSON decodes the position attention types from either a list or a string.
+func (p *PositionAttentionTypes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*p = nil
+		for _, t := range strings.Split(s, "|") {
+			if t = strings.TrimSpace(strings.ToLower(t)); t != "" {
+				*p = append(*p, t)
+			}
+		}
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return err
+	}
+	*p = types
+	return nil
+}
+
+// Has reports whether the given position attention type is enabled.
+func (p PositionAttentionTypes) Has(t string) bool {
+	for _, item := range p {
+		if item == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigFromFile loads a DeBERTa-v2 model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// Set default values
+	if config.EmbeddingSize == 0 {
+		config.EmbeddingSize = config.HiddenSize
+	}
+	if config.PoolerHiddenSize == 0 {
+		config.PoolerHiddenSize = config.HiddenSize
+	}
+	if config.MaxRelativePositions < 1 {
+		config.MaxRelativePositions = config.MaxPositionEmbeddings
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face DeBERTa-v2 configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		HiddenAct:             "gelu",
+		LayerNormEps:          1e-7,
+		MaxPositionEmbeddings: 512,
+		MaxRelativePositions:  -1,
+		NormRelEbd:            "none",
+		PoolerHiddenAct:       "gelu",
+		PositionBiasedInput:   true,
+		PositionBuckets:       -1,
+	}
+}
+
+// HeadDim returns the size of each attention head.
+func (c Config) HeadDim() int {
+	return c.HiddenSize / c.NumAttentionHeads
+}
+
+// AttentionSpan returns the maximum relative distance between two tokens
+// encoded by the relative position embeddings: the number of buckets, when
+// the relative positions are bucketed, or the maximum relative positions.
+func (c Config) AttentionSpan() int {
+	if c.PositionBuckets > 0 {
+		return c.PositionBuckets
+	}
+	return c.MaxRelativePositions
+}
+
+// NormalizeRelativeEmbeddings reports whether the relative position
+// embeddings are normalized before being used.
+func (c Config) NormalizeRelativeEmbeddings() bool {
+	for _, t := range strings.Split(c.NormRelEbd, "|") {
+		if strings.TrimSpace(strings.ToLower(t)) == "layer_norm" {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSequenceLength returns the maximum length of the input sequence.
+func (c Config) MaxSequenceLength() int {
+	return c.MaxPositionEmbeddings
+}
+
+// EntailmentID returns the id of the `entailment` labels.
+func (c Config) EntailmentID() (int, error) {
+	return c.labelID("entailment")
+}
+
+// ContradictionID returns the id of the `contradiction` labels.
+func (c Config) ContradictionID() (int, error) {
+	return c.labelID("contradiction")
+}
+
+// labelID returns the id of the given label, ignoring the case.
+func (c Config) labelID(label string) (int, error) {
+	for k, id := range c.Label2ID {
+		if strings.EqualFold(k, label) {
+			return id, nil
+		}
+	}
+	return -1, fmt.Errorf("deberta-v2: `%s` label not found", label)
+}
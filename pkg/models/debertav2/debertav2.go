@@ -0,0 +1,57 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package debertav2 implements the DeBERTa-v2 encoder, introduced by He et al., 2020.
+// "DeBERTa: Decoding-enhanced BERT with Disentangled Attention"
+// https://arxiv.org/abs/2006.03654
+//
+// The same architecture is shared by DeBERTa-v3 models, which differ only in
+// the pre-training objective. See "DeBERTaV3: Improving DeBERTa using
+// ELECTRA-Style Pre-Training with Gradient-Disentangled Embedding Sharing"
+// (https://arxiv.org/abs/2111.09543).
+//
+// The enhanced mask decoder (EMD) of the paper is not implemented: it is only
+// used by the masked language modeling head during pre-training, and it is not
+// part of the fine-tuned classification models, which only need the encoder.
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base DeBERTa-v2 encoder without any head on top.
+type Model struct {
+	nn.Module
+	// Embeddings is the embeddings module.
+	Embeddings *Embeddings
+	// Encoder is the encoder module.
+	Encoder *Encoder
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new DeBERTa-v2 model.
+func New[T float.DType](c Config) *Model {
+	return &Model{
+		Embeddings: NewEmbeddings[T](c),
+		Encoder:    NewEncoder[T](c),
+		Config:     c,
+	}
+}
+
+// Encode returns the hidden states of the input tokens.
+// The token type IDs are optional: if nil, all tokens have type 0.
+func (m *Model) Encode(inputIDs, tokenTypeIDs []int) []mat.Tensor {
+	return m.Encoder.Encode(m.Embeddings.Encode(inputIDs, tokenTypeIDs))
+}
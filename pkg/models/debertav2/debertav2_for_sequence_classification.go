@@ -0,0 +1,46 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForSequenceClassification{}
+
+// ModelForSequenceClassification implements a DeBERTa-v2 model for sequence classification.
+type ModelForSequenceClassification struct {
+	nn.Module
+	// DeBERTa is the fine-tuned DeBERTa-v2 model.
+	DeBERTa *Model
+	// Pooler is the pooler of the first token.
+	Pooler *Pooler
+	// Classifier is the linear layer for sequence classification.
+	Classifier *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForSequenceClassification{})
+}
+
+// NewModelForSequenceClassification returns a new model for sequence classification.
+func NewModelForSequenceClassification[T float.DType](deberta *Model) *ModelForSequenceClassification {
+	return &ModelForSequenceClassification{
+		DeBERTa:    deberta,
+		Pooler:     NewPooler[T](deberta.Config),
+		Classifier: linear.New[T](deberta.Config.PoolerHiddenSize, len(deberta.Config.ID2Label)),
+	}
+}
+
+// Classify returns the logits for the sequence classification.
+// The token type IDs are optional: if nil, all tokens have type 0.
+func (m *ModelForSequenceClassification) Classify(inputIDs, tokenTypeIDs []int) mat.Tensor {
+	return m.Classifier.Forward(m.Pooler.Forward(m.DeBERTa.Encode(inputIDs, tokenTypeIDs)[0]))[0]
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForTokenClassification{}
+
+// ModelForTokenClassification implements a DeBERTa-v2 model for token classification.
+type ModelForTokenClassification struct {
+	nn.Module
+	// DeBERTa is the fine-tuned DeBERTa-v2 model.
+	DeBERTa *Model
+	// Classifier is the linear layer for token classification.
+	Classifier *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForTokenClassification{})
+}
+
+// NewModelForTokenClassification returns a new model for token classification.
+func NewModelForTokenClassification[T float.DType](deberta *Model) *ModelForTokenClassification {
+	return &ModelForTokenClassification{
+		DeBERTa:    deberta,
+		Classifier: linear.New[T](deberta.Config.HiddenSize, len(deberta.Config.ID2Label)),
+	}
+}
+
+// Classify returns the logits for each token.
+func (m *ModelForTokenClassification) Classify(inputIDs []int) []mat.Tensor {
+	return m.Classifier.Forward(m.DeBERTa.Encode(inputIDs, nil)...)
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &Embeddings{}
+
+// Embeddings implements the DeBERTa-v2 input embeddings.
+type Embeddings struct {
+	nn.Module
+	// Tokens contains the token embeddings.
+	Tokens *embedding.Model
+	// Positions contains the absolute position embeddings.
+	// It is nil if the position information is only provided by the relative attention.
+	Positions *embedding.Model
+	// TokenTypes contains the token type embeddings. It is nil if the model has no token types.
+	TokenTypes *embedding.Model
+	// Projection projects the embeddings to the hidden size.
+	// It is nil if the embedding size is equal to the hidden size.
+	Projection *linear.Model
+	// Norm is the normalization of the embeddings.
+	Norm *layernorm.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Embeddings{})
+}
+
+// NewEmbeddings returns a new Embeddings.
+func NewEmbeddings[T float.DType](c Config) *Embeddings {
+	m := &Embeddings{
+		Tokens: embedding.New[T](c.VocabSize, c.EmbeddingSize),
+		Norm:   layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Config: c,
+	}
+	if c.PositionBiasedInput {
+		m.Positions = embedding.New[T](c.MaxPositionEmbeddings, c.EmbeddingSize)
+	}
+	if c.TypeVocabSize > 0 {
+		m.TokenTypes = embedding.New[T](c.TypeVocabSize, c.EmbeddingSize)
+	}
+	if c.EmbeddingSize != c.HiddenSize {
+		m.Projection = linear.New[T](c.EmbeddingSize, c.HiddenSize)
+	}
+	return m
+}
+
+// Encode returns the embeddings of the input tokens.
+// The token type IDs are optional: if nil, all tokens have type 0.
+func (m *Embeddings) Encode(inputIDs, tokenTypeIDs []int) []mat.Tensor {
+	ys := m.Tokens.MustEncode(inputIDs)
+	if m.Positions != nil {
+		positions := m.Positions.MustEncode(indices(len(inputIDs)))
+		ys = ag.Map2(ag.Add, ys, positions)
+	}
+	if m.TokenTypes != nil {
+		if tokenTypeIDs == nil {
+			tokenTypeIDs = make([]int, len(inputIDs))
+		}
+		ys = ag.Map2(ag.Add, ys, m.TokenTypes.MustEncode(tokenTypeIDs))
+	}
+	if m.Projection != nil {
+		ys = m.Projection.Forward(ys...)
+	}
+	return m.Norm.Forward(ys...)
+}
+
+// indices returns a slice of the given size, filled with the indices from 0 to size-1.
+func indices(size int) []int {
+	idx := make([]int, size)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
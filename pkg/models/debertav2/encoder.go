@@ -0,0 +1,84 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &Encoder{}
+
+// Encoder implements a DeBERTa-v2 encoder.
+type Encoder struct {
+	nn.Module
+	// Layers is the list of encoder layers.
+	Layers []*EncoderLayer
+	// RelEmbeddings contains the relative position embeddings, shared by all layers.
+	// It is nil if the relative attention is disabled.
+	RelEmbeddings *embedding.Model
+	// RelNorm is the normalization of the relative position embeddings.
+	// It is nil if the relative position embeddings are not normalized.
+	RelNorm *layernorm.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Encoder{})
+}
+
+// NewEncoder returns a new Encoder.
+func NewEncoder[T float.DType](c Config) *Encoder {
+	layers := make([]*EncoderLayer, c.NumHiddenLayers)
+	for i := range layers {
+		layers[i] = NewEncoderLayer[T](c)
+	}
+	m := &Encoder{
+		Layers: layers,
+		Config: c,
+	}
+	if c.RelativeAttention {
+		m.RelEmbeddings = embedding.New[T](2*c.AttentionSpan(), c.HiddenSize)
+		if c.NormalizeRelativeEmbeddings() {
+			m.RelNorm = layernorm.New[T](c.HiddenSize, c.LayerNormEps)
+		}
+	}
+	return m
+}
+
+// Encode performs the DeBERTa-v2 encoding.
+func (e *Encoder) Encode(xs []mat.Tensor) []mat.Tensor {
+	var (
+		rel []mat.Tensor
+		pos *RelativePositions
+	)
+	if e.Config.RelativeAttention {
+		pos = NewRelativePositions(len(xs), e.Config)
+		rel = e.relativeEmbeddings(pos)
+	}
+	for _, layer := range e.Layers {
+		xs = layer.Forward(xs, rel, pos)
+	}
+	return xs
+}
+
+// relativeEmbeddings returns the relative position embeddings used by the relative positions.
+func (e *Encoder) relativeEmbeddings(pos *RelativePositions) []mat.Tensor {
+	ids := make([]int, pos.Size)
+	for i := range ids {
+		ids[i] = pos.Offset + i
+	}
+	rel := e.RelEmbeddings.MustEncode(ids)
+	if e.RelNorm != nil {
+		rel = e.RelNorm.Forward(rel...)
+	}
+	return rel
+}
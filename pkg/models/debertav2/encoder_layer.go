@@ -0,0 +1,41 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ nn.Model = &EncoderLayer{}
+
+// EncoderLayer implements a DeBERTa-v2 encoder layer.
+type EncoderLayer struct {
+	nn.Module
+	// SelfAttention is the disentangled self-attention block.
+	SelfAttention *SelfAttentionBlock
+	// FF is the feed-forward block.
+	FF *FeedForwardBlock
+}
+
+func init() {
+	gob.Register(&EncoderLayer{})
+}
+
+// NewEncoderLayer returns a new EncoderLayer.
+func NewEncoderLayer[T float.DType](c Config) *EncoderLayer {
+	return &EncoderLayer{
+		SelfAttention: NewSelfAttentionBlock[T](c),
+		FF:            NewFeedForwardBlock[T](c),
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *EncoderLayer) Forward(xs, rel []mat.Tensor, pos *RelativePositions) []mat.Tensor {
+	return m.FF.Forward(m.SelfAttention.Forward(xs, rel, pos))
+}
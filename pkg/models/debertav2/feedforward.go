@@ -0,0 +1,50 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &FeedForwardBlock{}
+
+// FeedForwardBlock implements the position-wise feed-forward network
+// followed by the residual connection and the normalization.
+type FeedForwardBlock struct {
+	nn.Module
+	// MLP is the feed-forward network.
+	MLP nn.ModuleList[nn.StandardModel]
+	// Norm is the layer normalization module.
+	Norm *layernorm.Model
+}
+
+func init() {
+	gob.Register(&FeedForwardBlock{})
+}
+
+// NewFeedForwardBlock returns a new FeedForwardBlock.
+func NewFeedForwardBlock[T float.DType](c Config) *FeedForwardBlock {
+	return &FeedForwardBlock{
+		MLP: []nn.StandardModel{
+			linear.New[T](c.HiddenSize, c.IntermediateSize),
+			activation.New(activation.MustParseActivation(c.HiddenAct)),
+			linear.New[T](c.IntermediateSize, c.HiddenSize),
+		},
+		Norm: layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *FeedForwardBlock) Forward(xs []mat.Tensor) []mat.Tensor {
+	return m.Norm.Forward(ag.Map2(ag.Add, xs, m.MLP.Forward(xs...))...)
+}
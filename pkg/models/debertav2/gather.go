@@ -0,0 +1,64 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"fmt"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+// gather returns a new matrix whose element (i, j) is the element (i, indices[i][j]) of x.
+func gather(x mat.Tensor, indices [][]int) mat.Tensor {
+	return ag.NewOperator(&gatherFn{x: x, indices: indices}).Run()
+}
+
+// gatherFn is the autograd function of gather.
+type gatherFn struct {
+	x       mat.Tensor
+	indices [][]int
+}
+
+// Operands returns the list of operands.
+func (g *gatherFn) Operands() []mat.Tensor {
+	return []mat.Tensor{g.x}
+}
+
+// Forward computes the output of the function.
+func (g *gatherFn) Forward() (mat.Tensor, error) {
+	x := g.x.Value().(mat.Matrix)
+	if len(g.indices) != x.Shape()[0] {
+		return nil, fmt.Errorf("gather: expected %d rows of indices, got %d", x.Shape()[0], len(g.indices))
+	}
+	data := x.Data().F64()
+	xCols := x.Shape()[1]
+	rows, cols := len(g.indices), len(g.indices[0])
+	out := make([]float64, 0, rows*cols)
+	for i, row := range g.indices {
+		for _, k := range row {
+			out = append(out, data[i*xCols+k])
+		}
+	}
+	return x.NewMatrix(mat.WithShape(rows, cols), mat.WithBacking(out)), nil
+}
+
+// Backward computes the backward pass.
+func (g *gatherFn) Backward(gy mat.Tensor) error {
+	if !g.x.RequiresGrad() {
+		return nil
+	}
+	x := g.x.Value().(mat.Matrix)
+	grad := make([]float64, x.Size())
+	gyData := gy.(mat.Matrix).Data().F64()
+	xCols, cols := x.Shape()[1], len(g.indices[0])
+	for i, row := range g.indices {
+		for j, k := range row {
+			grad[i*xCols+k] += gyData[i*cols+j]
+		}
+	}
+	g.x.AccGrad(x.NewMatrix(mat.WithShape(x.Shape()...), mat.WithBacking(grad)))
+	return nil
+}
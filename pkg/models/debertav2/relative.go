@@ -0,0 +1,90 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"math"
+)
+
+// RelativePositions contains the indices of the relative position embeddings
+// used by the disentangled attention of a sequence.
+//
+// Only the embeddings in the range [Offset, Offset+Size) are actually used,
+// so the indices are relative to Offset.
+type RelativePositions struct {
+	// ContentToPosition contains, for each query i and key j, the index
+	// of the relative position embedding of i with respect to j.
+	ContentToPosition [][]int
+	// PositionToContent contains, for each key i and query j, the index
+	// of the relative position embedding of j with respect to i.
+	PositionToContent [][]int
+	// Offset is the index of the first relative position embedding used.
+	Offset int
+	// Size is the number of relative position embeddings used.
+	Size int
+}
+
+// NewRelativePositions returns the RelativePositions of a sequence of the given length.
+func NewRelativePositions(length int, c Config) *RelativePositions {
+	span := c.AttentionSpan()
+	index := func(relPos int) int {
+		return min(max(relPos+span, 0), 2*span-1)
+	}
+
+	c2p := make([][]int, length)
+	p2c := make([][]int, length)
+	minIndex, maxIndex := 2*span-1, 0
+	for i := range c2p {
+		c2p[i] = make([]int, length)
+		p2c[i] = make([]int, length)
+		for j := range c2p[i] {
+			relPos := relativePosition(i, j, c.PositionBuckets, c.MaxRelativePositions)
+			c2p[i][j] = index(relPos)
+			p2c[i][j] = index(-relPos)
+			minIndex = min(minIndex, c2p[i][j], p2c[i][j])
+			maxIndex = max(maxIndex, c2p[i][j], p2c[i][j])
+		}
+	}
+	for i := range c2p {
+		for j := range c2p[i] {
+			c2p[i][j] -= minIndex
+			p2c[i][j] -= minIndex
+		}
+	}
+
+	return &RelativePositions{
+		ContentToPosition: c2p,
+		PositionToContent: p2c,
+		Offset:            minIndex,
+		Size:              maxIndex - minIndex + 1,
+	}
+}
+
+// relativePosition returns the relative position of the query i with respect
+// to the key j, bucketed on a logarithmic scale if bucketSize is positive.
+func relativePosition(i, j, bucketSize, maxPosition int) int {
+	relPos := i - j
+	if bucketSize <= 0 || maxPosition <= 0 {
+		return relPos
+	}
+	return logBucketPosition(relPos, bucketSize, maxPosition)
+}
+
+// logBucketPosition maps the relative position to a bucket. The positions
+// closer than half the bucket size are kept as they are, while the farther
+// ones share the same bucket on a logarithmic scale.
+func logBucketPosition(relPos, bucketSize, maxPosition int) int {
+	mid := bucketSize / 2
+	absPos := relPos
+	sign := 1
+	if relPos < 0 {
+		absPos, sign = -relPos, -1
+	}
+	if absPos <= mid {
+		return relPos
+	}
+	logPos := math.Ceil(math.Log(float64(absPos)/float64(mid))/math.Log(float64(maxPosition-1)/float64(mid))*float64(mid-1)) + float64(mid)
+	return sign * int(logPos)
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &SelfAttentionBlock{}
+
+// SelfAttentionBlock implements the disentangled self-attention followed by
+// the output projection, the residual connection and the normalization.
+type SelfAttentionBlock struct {
+	nn.Module
+	// Attention is the disentangled self-attention module.
+	Attention *Attention
+	// OutputMerge is the projection of the concatenated heads.
+	OutputMerge *linear.Model
+	// Norm is the layer normalization module.
+	Norm *layernorm.Model
+}
+
+func init() {
+	gob.Register(&SelfAttentionBlock{})
+}
+
+// NewSelfAttentionBlock returns a new SelfAttentionBlock.
+func NewSelfAttentionBlock[T float.DType](c Config) *SelfAttentionBlock {
+	return &SelfAttentionBlock{
+		Attention:   NewAttention[T](c),
+		OutputMerge: linear.New[T](c.HiddenSize, c.HiddenSize),
+		Norm:        layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *SelfAttentionBlock) Forward(xs, rel []mat.Tensor, pos *RelativePositions) []mat.Tensor {
+	att := m.OutputMerge.Forward(m.Attention.Forward(xs, rel, pos)...)
+	return m.Norm.Forward(ag.Map2(ag.Add, xs, att)...)
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the global configuration of the DistilBERT model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Activation            string            `json:"activation"`
+	Architectures         []string          `json:"architectures"`
+	AttentionDropout      float64           `json:"attention_dropout"`
+	Dim                   int               `json:"dim"`
+	Dropout               float64           `json:"dropout"`
+	HiddenDim             int               `json:"hidden_dim"`
+	InitializerRange      float64           `json:"initializer_range"`
+	MaxPositionEmbeddings int               `json:"max_position_embeddings"`
+	ModelType             string            `json:"model_type"`
+	NumAttentionHeads     int               `json:"n_heads"`
+	NumLayers             int               `json:"n_layers"`
+	PadTokenId            int               `json:"pad_token_id"`
+	QADropout             float64           `json:"qa_dropout"`
+	SeqClassifDropout     float64           `json:"seq_classif_dropout"`
+	SinusoidalPosEmbds    bool              `json:"sinusoidal_pos_embds"`
+	TransformersVersion   string            `json:"transformers_version"`
+	VocabSize             int               `json:"vocab_size"`
+	ID2Label              map[string]string `json:"id2label"`
+	ProblemType           string            `json:"problem_type"`
+	Cybertron             struct {
+		Training bool `json:"training"`
+	}
+}
+
+// ConfigFromFile loads a DistilBERT model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	var config Config
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base DistilBERT encoder model without any head on top.
+type Model struct {
+	nn.Module
+	Embeddings *Embeddings
+	Encoder    *Encoder
+	Config     Config
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new DistilBERT model.
+func New[T float.DType](c Config) *Model {
+	return &Model{
+		Embeddings: NewEmbeddings[T](c),
+		Encoder:    NewEncoder[T](c),
+		Config:     c,
+	}
+}
+
+// EncodeTokens produce the encoded representation for the input tokens
+func (m *Model) EncodeTokens(tokens []string) []mat.Tensor {
+	return m.Encoder.Encode(m.Embeddings.EncodeTokens(tokens))
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &ModelForMaskedLM{}
+
+// ModelForMaskedLM implements a DistilBERT model for masked language modeling.
+type ModelForMaskedLM struct {
+	nn.Module
+	// DistilBert is the fine-tuned DistilBERT model.
+	DistilBert *Model
+	// Layers contains the vocabulary transform, activation, layer norm and projector layers.
+	Layers nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&ModelForMaskedLM{})
+}
+
+// NewModelForMaskedLM returns a new model for masked language model.
+func NewModelForMaskedLM[T float.DType](m *Model) *ModelForMaskedLM {
+	c := m.Config
+	return &ModelForMaskedLM{
+		DistilBert: m,
+		Layers: []nn.StandardModel{
+			linear.New[T](c.Dim, c.Dim),
+			activation.New(activation.MustParseActivation(c.Activation)),
+			layernorm.New[T](c.Dim, 1e-12),
+			linear.New[T](c.Dim, c.VocabSize),
+		},
+	}
+}
+
+// Predict returns the predictions for the token associated to the masked nodes.
+func (m *ModelForMaskedLM) Predict(tokens []string) map[int]mat.Tensor {
+	encoded := m.DistilBert.EncodeTokens(tokens)
+	result := make(map[int]mat.Tensor)
+	for i, token := range tokens {
+		if token == wordpiecetokenizer.DefaultMaskToken {
+			result[i] = m.Layers.Forward(encoded[i])[0]
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForSequenceClassification{}
+
+// ModelForSequenceClassification implements a DistilBERT model for sequence classification.
+type ModelForSequenceClassification struct {
+	nn.Module
+	// DistilBert is the fine-tuned DistilBERT model.
+	DistilBert *Model
+	// Classifier contains the pre-classifier, the activation and the classifier layers.
+	Classifier nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&ModelForSequenceClassification{})
+}
+
+// NewModelForSequenceClassification returns a new model for sequence classification.
+func NewModelForSequenceClassification[T float.DType](m *Model) *ModelForSequenceClassification {
+	c := m.Config
+	return &ModelForSequenceClassification{
+		DistilBert: m,
+		Classifier: []nn.StandardModel{
+			linear.New[T](c.Dim, c.Dim),
+			activation.New(activation.ReLU),
+			linear.New[T](c.Dim, len(c.ID2Label)),
+		},
+	}
+}
+
+// Classify returns the logits for the sequence classification.
+func (m *ModelForSequenceClassification) Classify(tokens []string) mat.Tensor {
+	return m.Classifier.Forward(m.DistilBert.EncodeTokens(tokens)[0])[0]
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ nn.Model = &ModelForSequenceEncoding{}
+
+// ModelForSequenceEncoding implements a DistilBERT model for sequence encoding.
+type ModelForSequenceEncoding struct {
+	nn.Module
+	// DistilBert is the fine-tuned DistilBERT model.
+	DistilBert *Model
+}
+
+func init() {
+	gob.Register(&ModelForSequenceEncoding{})
+}
+
+// NewModelForSequenceEncoding returns a new model for sequence encoding.
+func NewModelForSequenceEncoding(m *Model) *ModelForSequenceEncoding {
+	return &ModelForSequenceEncoding{
+		DistilBert: m,
+	}
+}
+
+// Encode returns the vector representation for the input sequence.
+// Since DistilBERT has no pooler, the ClsTokenPooling strategy returns the last hidden state of the first token.
+func (m *ModelForSequenceEncoding) Encode(tokens []string, poolingStrategy bert.PoolingStrategyType) (mat.Tensor, error) {
+	lastHiddenStates := m.DistilBert.EncodeTokens(tokens)
+	switch poolingStrategy {
+	case bert.MeanPooling:
+		return ag.Mean(lastHiddenStates), nil
+	case bert.MaxPooling:
+		return ag.Maximum(lastHiddenStates), nil
+	case bert.MeanMaxPooling:
+		return ag.Concat(ag.Mean(lastHiddenStates), ag.Maximum(lastHiddenStates)), nil
+	case bert.ClsTokenPooling:
+		return lastHiddenStates[0], nil
+	default:
+		return nil, fmt.Errorf("distilbert: invalid pooling strategy")
+	}
+}
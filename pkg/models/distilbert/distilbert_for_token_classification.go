@@ -0,0 +1,42 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForTokenClassification{}
+
+// ModelForTokenClassification implements a DistilBERT model for token classification.
+type ModelForTokenClassification struct {
+	nn.Module
+	// DistilBert is the fine-tuned DistilBERT model.
+	DistilBert *Model
+	// Classifier is the linear layer for token classification.
+	Classifier *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForTokenClassification{})
+}
+
+// NewModelForTokenClassification returns a new model for token classification.
+func NewModelForTokenClassification[T float.DType](m *Model) *ModelForTokenClassification {
+	return &ModelForTokenClassification{
+		DistilBert: m,
+		Classifier: linear.New[T](m.Config.Dim, len(m.Config.ID2Label)),
+	}
+}
+
+// Classify returns the logits for each token.
+func (m *ModelForTokenClassification) Classify(tokens []string) []mat.Tensor {
+	return m.Classifier.Forward(m.DistilBert.EncodeTokens(tokens)...)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+)
+
+var _ nn.Model = &Encoder{}
+
+// Encoder implements a DistilBERT encoder (the "transformer" in Hugging Face naming).
+type Encoder struct {
+	nn.Module
+	Layers nn.ModuleList[*EncoderLayer]
+	Config Config
+}
+
+func init() {
+	gob.Register(&Encoder{})
+	gob.Register(&EncoderLayer{})
+}
+
+// NewEncoder returns a new Encoder.
+func NewEncoder[T float.DType](c Config) *Encoder {
+	layers := make([]*EncoderLayer, c.NumLayers)
+	for i := range layers {
+		layers[i] = NewEncoderLayer[T](c)
+	}
+	return &Encoder{
+		Layers: layers,
+		Config: c,
+	}
+}
+
+// Encode performs the DistilBERT encoding.
+func (e *Encoder) Encode(xs []mat.Tensor) []mat.Tensor {
+	return e.Layers.Forward(xs...)
+}
+
+var _ nn.StandardModel = &EncoderLayer{}
+
+// EncoderLayer implements a DistilBERT encoder layer.
+// The post-norm residual blocks are the same as BERT's.
+type EncoderLayer struct {
+	nn.Module
+	SelfAttention *bert.SelfAttentionBlock
+	FF            *bert.FeedForwardBlock
+}
+
+// NewEncoderLayer returns a new EncoderLayer.
+func NewEncoderLayer[T float.DType](c Config) *EncoderLayer {
+	return &EncoderLayer{
+		SelfAttention: bert.NewSelfAttentionBlock[T](bert.SelfAttentionBlockConfig{
+			Dim:        c.Dim,
+			NumOfHeads: c.NumAttentionHeads,
+		}),
+		FF: bert.NewFeedForwardBlock[T](bert.FeedForwardBlockConfig{
+			Dim:        c.Dim,
+			HiddenDim:  c.HiddenDim,
+			Activation: activation.MustParseActivation(c.Activation),
+		}),
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *EncoderLayer) Forward(xs ...mat.Tensor) []mat.Tensor {
+	return m.FF.Forward(m.SelfAttention.Forward(xs))
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &Block{}
+
+// Block implements a GPT-2 transformer block, with the layer normalization
+// applied before the causal self-attention and the feed-forward layers.
+type Block struct {
+	nn.Module
+	// AttentionNorm is the normalization applied before the self-attention.
+	AttentionNorm *layernorm.Model
+	// Attention is the causal self-attention module.
+	Attention *multiheadattention.Model
+	// FFNorm is the normalization applied before the feed-forward.
+	FFNorm *layernorm.Model
+	// FF is the feed-forward module.
+	FF nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&Block{})
+}
+
+// NewBlock returns a new Block.
+func NewBlock[T float.DType](c Config) *Block {
+	return &Block{
+		AttentionNorm: layernorm.New[T](c.NEmbd, c.LayerNormEpsilon),
+		Attention:     multiheadattention.New[T](c.NEmbd, c.NHead, true, false),
+		FFNorm:        layernorm.New[T](c.NEmbd, c.LayerNormEpsilon),
+		FF: []nn.StandardModel{
+			linear.New[T](c.NEmbd, c.NInner),
+			activation.New(activation.MustParseActivation(c.Activation())),
+			linear.New[T](c.NInner, c.NEmbd),
+		},
+	}
+}
+
+// Forward performs the forward step of the block.
+func (m *Block) Forward(cache multiheadattention.Cache, xs []mat.Tensor) ([]mat.Tensor, multiheadattention.Cache) {
+	norm := m.AttentionNorm.Forward(xs...)
+	att, _, nextCache := m.Attention.Forward(cache, norm, norm)
+	hs := ag.Map2(ag.Add, xs, att)
+	hs = ag.Map2(ag.Add, hs, m.FF.Forward(m.FFNorm.Forward(hs...)...))
+	return hs, nextCache
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the global configuration of the GPT-2 model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	ActivationFunction string   `json:"activation_function"`
+	Architectures      []string `json:"architectures"`
+	AttnPdrop          float64  `json:"attn_pdrop"`
+	BosTokenID         int      `json:"bos_token_id"`
+	EmbdPdrop          float64  `json:"embd_pdrop"`
+	EosTokenID         int      `json:"eos_token_id"`
+	InitializerRange   float64  `json:"initializer_range"`
+	LayerNormEpsilon   float64  `json:"layer_norm_epsilon"`
+	ModelType          string   `json:"model_type"`
+	NEmbd              int      `json:"n_embd"`
+	NHead              int      `json:"n_head"`
+	NInner             int      `json:"n_inner"`
+	NLayer             int      `json:"n_layer"`
+	NPositions         int      `json:"n_positions"`
+	ResidPdrop         float64  `json:"resid_pdrop"`
+	VocabSize          int      `json:"vocab_size"`
+	NumBeams           int      `json:"num_beams"`
+	MaxLength          int      `json:"max_length"`
+	MinLength          int      `json:"min_length"`
+	LengthPenalty      float64  `json:"length_penalty"`
+	EarlyStopping      bool     `json:"early_stopping"`
+	NoRepeatNGramSize  int      `json:"no_repeat_ngram_size"`
+	BadWordsIDs        [][]int  `json:"bad_words_ids"`
+	Cybertron          struct {
+		Training bool `json:"training"`
+	}
+}
+
+// ConfigFromFile loads a GPT-2 model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// Set default values
+	if config.NInner == 0 {
+		config.NInner = 4 * config.NEmbd
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face GPT-2 configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		ActivationFunction: "gelu_new",
+		BosTokenID:         50256,
+		EosTokenID:         50256,
+		LayerNormEpsilon:   1e-5,
+		NPositions:         1024,
+		NumBeams:           1,
+		MaxLength:          50,
+		LengthPenalty:      1.0,
+	}
+}
+
+// Activation returns the name of the activation function of the feed-forward layers.
+func (c Config) Activation() string {
+	if c.ActivationFunction == "gelu_new" {
+		return "gelu"
+	}
+	return c.ActivationFunction
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gpt2 implements the decoder-only transformer model introduced by Radford et al., 2019.
+// "Language Models are Unsupervised Multitask Learners"
+// https://cdn.openai.com/better-language-models/language_models_are_unsupervised_multitask_learners.pdf
+package gpt2
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base GPT-2 model without any head on top.
+type Model struct {
+	nn.Module
+	// Tokens contains the token embeddings.
+	Tokens *embedding.Model
+	// Positions contains the learned position embeddings.
+	Positions *embedding.Model
+	// Layers is the list of transformer blocks.
+	Layers []*Block
+	// LayerNorm is the final layer normalization.
+	LayerNorm *layernorm.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+// Cache contains the keys and values of the self-attention of each Block.
+type Cache []multiheadattention.Cache
+
+// Layer returns the cache at the given index.
+func (c Cache) Layer(i int) multiheadattention.Cache {
+	if len(c) == 0 {
+		return nil
+	}
+	return c[i]
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new GPT-2 model.
+func New[T float.DType](c Config) *Model {
+	layers := make([]*Block, c.NLayer)
+	for i := range layers {
+		layers[i] = NewBlock[T](c)
+	}
+	return &Model{
+		Tokens:    embedding.New[T](c.VocabSize, c.NEmbd),
+		Positions: embedding.New[T](c.NPositions, c.NEmbd),
+		Layers:    layers,
+		LayerNorm: layernorm.New[T](c.NEmbd, c.LayerNormEpsilon),
+		Config:    c,
+	}
+}
+
+// Encode returns the hidden states of the input tokens, which follow the
+// pastLength tokens whose keys and values are stored in the cache.
+func (m *Model) Encode(inputIDs []int, cache Cache, pastLength int) ([]mat.Tensor, Cache) {
+	positionIDs := make([]int, len(inputIDs))
+	for i := range positionIDs {
+		positionIDs[i] = pastLength + i
+	}
+	ys := ag.Map2(ag.Add, m.Tokens.MustEncode(inputIDs), m.Positions.MustEncode(positionIDs))
+
+	nextCache := make(Cache, len(m.Layers))
+	for i, layer := range m.Layers {
+		ys, nextCache[i] = layer.Forward(cache.Layer(i), ys)
+	}
+	return m.LayerNorm.Forward(ys...), nextCache
+}
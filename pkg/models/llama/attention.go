@@ -0,0 +1,126 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"encoding/gob"
+	"math"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/attention/selfattention"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &Attention{}
+
+// Attention implements the causal self-attention of Llama, with rotary
+// position embeddings applied to queries and keys.
+//
+// It supports grouped-query attention: the attention heads are divided into
+// groups of the same size, and the heads of each group share the same keys
+// and values. Standard multi-head attention is the special case in which each
+// group contains a single head.
+type Attention struct {
+	nn.Module
+	// Queries contains the query projection of each attention head.
+	Queries []*linear.Model
+	// Keys contains the key projection of each key-value head.
+	Keys []*linear.Model
+	// Values contains the value projection of each key-value head.
+	Values []*linear.Model
+	// OutputMerge is the projection of the concatenated heads.
+	OutputMerge *linear.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Attention{})
+}
+
+// NewAttention returns a new Attention.
+func NewAttention[T float.DType](c Config) *Attention {
+	headDim := c.HeadDim()
+	newProjections := func(n int) []*linear.Model {
+		projections := make([]*linear.Model, n)
+		for i := range projections {
+			projections[i] = linear.New[T](c.HiddenSize, headDim)
+		}
+		return projections
+	}
+	return &Attention{
+		Queries:     newProjections(c.NumAttentionHeads),
+		Keys:        newProjections(c.NumKeyValueHeads),
+		Values:      newProjections(c.NumKeyValueHeads),
+		OutputMerge: linear.New[T](c.NumAttentionHeads*headDim, c.HiddenSize),
+		Config:      c,
+	}
+}
+
+// Forward performs the attention of xs, following the tokens whose keys and
+// values are stored in the cache. The cache is indexed by key-value head, and
+// it contains the keys with the rotary position embeddings already applied.
+func (m *Attention) Forward(cache multiheadattention.Cache, rope *RotaryEmbedding, xs []mat.Tensor) ([]mat.Tensor, multiheadattention.Cache) {
+	nextCache := make(multiheadattention.Cache, len(m.Keys))
+	for i := range m.Keys {
+		k := rope.Apply(m.Keys[i].Forward(xs...))
+		v := m.Values[i].Forward(xs...)
+		if c := cache.At(i); c.HasValues() {
+			nextCache[i] = selfattention.Cache{ag.AppendRows(c[0], k...), ag.AppendRows(c[1], v...)}
+		} else {
+			nextCache[i] = selfattention.Cache{ag.Stack(k...), ag.Stack(v...)}
+		}
+	}
+
+	groupSize := len(m.Queries) / len(m.Keys)
+	scale := xs[0].Value().(mat.Matrix).NewScalar(1 / math.Sqrt(float64(m.Config.HeadDim())))
+	attentions := make([][]mat.Tensor, len(m.Queries))
+	for i, query := range m.Queries {
+		kv := nextCache[i/groupSize]
+		attentions[i] = m.attend(rope.Apply(query.Forward(xs...)), kv[0], kv[1], scale)
+	}
+
+	concat := make([]mat.Tensor, len(xs))
+	for i := range xs {
+		buf := make([]mat.Tensor, len(attentions))
+		for j := range buf {
+			buf[j] = attentions[j][i]
+		}
+		concat[i] = ag.Concat(buf...)
+	}
+	return m.OutputMerge.Forward(concat...), nextCache
+}
+
+// attend performs the scaled dot-product attention of the queries over the
+// keys and values. The queries are the last ones of the sequence, so each of
+// them can only attend to the keys up to its own position.
+func (m *Attention) attend(qs []mat.Tensor, k, v, scale mat.Tensor) []mat.Tensor {
+	kRows := k.Value().Shape()[0]
+	offset := kRows - len(qs)
+
+	result := make([]mat.Tensor, len(qs))
+	for i, q := range qs {
+		scores := ag.ProdScalar(ag.Mul(k, q), scale)
+		if pos := offset + i; pos+1 < kRows {
+			scores = ag.Add(scores, k.Value().(mat.Matrix).NewMatrix(mat.WithBacking(causalMask(pos, kRows))))
+		}
+		result[i] = ag.MulT(v, ag.Softmax(scores))
+	}
+	return result
+}
+
+// causalMask returns a slice of the given size, filled with zeros up to the
+// given position (included), and with -inf after it.
+func causalMask(pos, size int) []float64 {
+	mask := make([]float64, size)
+	for i := pos + 1; i < size; i++ {
+		mask[i] = math.Inf(-1)
+	}
+	return mask
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the global configuration of the Llama model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Architectures         []string `json:"architectures"`
+	BosTokenID            int      `json:"bos_token_id"`
+	EosTokenID            int      `json:"eos_token_id"`
+	HiddenAct             string   `json:"hidden_act"`
+	HiddenSize            int      `json:"hidden_size"`
+	InitializerRange      float64  `json:"initializer_range"`
+	IntermediateSize      int      `json:"intermediate_size"`
+	MaxPositionEmbeddings int      `json:"max_position_embeddings"`
+	ModelType             string   `json:"model_type"`
+	NumAttentionHeads     int      `json:"num_attention_heads"`
+	NumHiddenLayers       int      `json:"num_hidden_layers"`
+	NumKeyValueHeads      int      `json:"num_key_value_heads"`
+	RMSNormEps            float64  `json:"rms_norm_eps"`
+	RopeTheta             float64  `json:"rope_theta"`
+	TieWordEmbeddings     bool     `json:"tie_word_embeddings"`
+	VocabSize             int      `json:"vocab_size"`
+	NumBeams              int      `json:"num_beams"`
+	MaxLength             int      `json:"max_length"`
+	MinLength             int      `json:"min_length"`
+	LengthPenalty         float64  `json:"length_penalty"`
+	EarlyStopping         bool     `json:"early_stopping"`
+	NoRepeatNGramSize     int      `json:"no_repeat_ngram_size"`
+	BadWordsIDs           [][]int  `json:"bad_words_ids"`
+	Cybertron             struct {
+		Training bool `json:"training"`
+	}
+}
+
+// ConfigFromFile loads a Llama model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// Set default values
+	if config.NumKeyValueHeads == 0 {
+		config.NumKeyValueHeads = config.NumAttentionHeads
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face Llama configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		BosTokenID:            1,
+		EosTokenID:            2,
+		HiddenAct:             "silu",
+		MaxPositionEmbeddings: 2048,
+		RMSNormEps:            1e-6,
+		RopeTheta:             10000,
+		NumBeams:              1,
+		MaxLength:             50,
+		LengthPenalty:         1.0,
+	}
+}
+
+// HeadDim returns the size of each attention head.
+func (c Config) HeadDim() int {
+	return c.HiddenSize / c.NumAttentionHeads
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+)
+
+var _ nn.Model = &DecoderLayer{}
+
+// DecoderLayer implements a Llama decoder layer, with the RMS normalization
+// applied before the self-attention and the MLP.
+type DecoderLayer struct {
+	nn.Module
+	// AttentionNorm is the normalization applied before the self-attention.
+	AttentionNorm *RMSNorm
+	// Attention is the causal self-attention module.
+	Attention *Attention
+	// MLPNorm is the normalization applied before the MLP.
+	MLPNorm *RMSNorm
+	// MLP is the gated feed-forward module.
+	MLP *MLP
+}
+
+func init() {
+	gob.Register(&DecoderLayer{})
+}
+
+// NewDecoderLayer returns a new DecoderLayer.
+func NewDecoderLayer[T float.DType](c Config) *DecoderLayer {
+	return &DecoderLayer{
+		AttentionNorm: NewRMSNorm[T](c.HiddenSize, c.RMSNormEps),
+		Attention:     NewAttention[T](c),
+		MLPNorm:       NewRMSNorm[T](c.HiddenSize, c.RMSNormEps),
+		MLP:           NewMLP[T](c),
+	}
+}
+
+// Forward performs the forward step of the layer.
+func (m *DecoderLayer) Forward(cache multiheadattention.Cache, rope *RotaryEmbedding, xs []mat.Tensor) ([]mat.Tensor, multiheadattention.Cache) {
+	att, nextCache := m.Attention.Forward(cache, rope, m.AttentionNorm.Forward(xs...))
+	hs := ag.Map2(ag.Add, xs, att)
+	hs = ag.Map2(ag.Add, hs, m.MLP.Forward(m.MLPNorm.Forward(hs...)...))
+	return hs, nextCache
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package llama implements the decoder-only transformer model introduced by Touvron et al., 2023.
+// "LLaMA: Open and Efficient Foundation Language Models"
+// https://arxiv.org/abs/2302.13971
+//
+// The same architecture, possibly with grouped-query attention, is shared by
+// later models of the family, such as Llama 2 and TinyLlama.
+package llama
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base Llama model without any head on top.
+type Model struct {
+	nn.Module
+	// Embeddings contains the token embeddings.
+	Embeddings *embedding.Model
+	// Layers is the list of decoder layers.
+	Layers []*DecoderLayer
+	// Norm is the final normalization.
+	Norm *RMSNorm
+	// Config is the model configuration.
+	Config Config
+}
+
+// Cache contains the keys and values of the self-attention of each DecoderLayer.
+type Cache []multiheadattention.Cache
+
+// Layer returns the cache at the given index.
+func (c Cache) Layer(i int) multiheadattention.Cache {
+	if len(c) == 0 {
+		return nil
+	}
+	return c[i]
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new Llama model.
+func New[T float.DType](c Config) *Model {
+	layers := make([]*DecoderLayer, c.NumHiddenLayers)
+	for i := range layers {
+		layers[i] = NewDecoderLayer[T](c)
+	}
+	return &Model{
+		Embeddings: embedding.New[T](c.VocabSize, c.HiddenSize),
+		Layers:     layers,
+		Norm:       NewRMSNorm[T](c.HiddenSize, c.RMSNormEps),
+		Config:     c,
+	}
+}
+
+// Encode returns the hidden states of the input tokens, which follow the
+// pastLength tokens whose keys and values are stored in the cache.
+func (m *Model) Encode(inputIDs []int, cache Cache, pastLength int) ([]mat.Tensor, Cache) {
+	ys := m.Embeddings.MustEncode(inputIDs)
+	like := ys[0].Value().(mat.Matrix)
+	rope := NewRotaryEmbedding(like, m.Config.HeadDim(), pastLength, len(inputIDs), m.Config.RopeTheta)
+
+	nextCache := make(Cache, len(m.Layers))
+	for i, layer := range m.Layers {
+		ys, nextCache[i] = layer.Forward(cache.Layer(i), rope, ys)
+	}
+	return m.Norm.Forward(ys...), nextCache
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"encoding/gob"
+	"sync"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForCausalLM{}
+
+// ModelForCausalLM is a model for causal language modeling (i.e. text continuation)
+// which embeds a Llama pre-trained model.
+type ModelForCausalLM struct {
+	nn.Module
+	// Llama is the pre-trained Llama model.
+	Llama *Model
+	// Projection is the projection layer from the hidden states to the vocabulary.
+	// It contains a copy of the token embeddings when they are tied.
+	Projection *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForCausalLM{})
+}
+
+// NewModelForCausalLM returns a new model for causal language modeling.
+func NewModelForCausalLM[T float.DType](llama *Model) *ModelForCausalLM {
+	return &ModelForCausalLM{
+		Llama:      llama,
+		Projection: linear.New[T](llama.Config.HiddenSize, llama.Config.VocabSize),
+	}
+}
+
+// DecodingInput is the input for the decoding function of the model for causal language modeling.
+type DecodingInput struct {
+	// InputIDs are the input IDs not processed yet: the whole prompt at the
+	// first step, then the last generated token.
+	InputIDs []int
+	// CurLen is the current length of the sequence, including the InputIDs.
+	CurLen int
+	// Cache is the cache of the previous steps.
+	Cache Cache
+}
+
+// DecodingOutput is the output of the decoding function of the model for causal language modeling.
+type DecodingOutput struct {
+	// LogProbRaw is the raw (not processed) log probability of the generated token.
+	LogProbRaw mat.Tensor
+	// LogProbValue is the value of the log probability of the generated token,
+	// which is processed by the decoding search.
+	LogProbValue mat.Matrix
+	// NextCache is the next cache.
+	NextCache Cache
+}
+
+// DecodingFunc returns a decoding function that predicts the next token of each item of the batch.
+func (m *ModelForCausalLM) DecodingFunc() func(batch []*DecodingInput) []*DecodingOutput {
+	return func(batch []*DecodingInput) []*DecodingOutput {
+		result := make([]*DecodingOutput, len(batch))
+
+		var wg sync.WaitGroup
+		wg.Add(len(batch))
+
+		for i, item := range batch {
+			i, item := i, item
+			go func() {
+				defer wg.Done()
+				result[i] = m.next(item)
+			}()
+		}
+		wg.Wait()
+		return result
+	}
+}
+
+// next returns the log probability for the generated tokens.
+func (m *ModelForCausalLM) next(input *DecodingInput) *DecodingOutput {
+	encoded, nextCache := m.Llama.Encode(input.InputIDs, input.Cache, input.CurLen-len(input.InputIDs))
+	logits := m.Projection.Forward(encoded[len(encoded)-1])[0]
+	logProb := ag.LogSoftmax(logits)
+
+	return &DecodingOutput{
+		LogProbRaw:   logProb,
+		LogProbValue: logProb.Value().(mat.Matrix),
+		NextCache:    nextCache,
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &MLP{}
+
+// MLP implements the gated feed-forward layer of Llama (SwiGLU), which
+// multiplies the activated gate projection by the up projection, element-wise,
+// before projecting the result back to the hidden size.
+type MLP struct {
+	nn.Module
+	// Gate is the gate projection.
+	Gate *linear.Model
+	// Up is the up projection.
+	Up *linear.Model
+	// Down is the down projection.
+	Down *linear.Model
+	// Activation is the activation function applied to the gate projection.
+	Activation *activation.Model
+}
+
+func init() {
+	gob.Register(&MLP{})
+}
+
+// NewMLP returns a new MLP.
+func NewMLP[T float.DType](c Config) *MLP {
+	return &MLP{
+		Gate:       linear.New[T](c.HiddenSize, c.IntermediateSize),
+		Up:         linear.New[T](c.HiddenSize, c.IntermediateSize),
+		Down:       linear.New[T](c.IntermediateSize, c.HiddenSize),
+		Activation: activation.New(activation.MustParseActivation(c.HiddenAct)),
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *MLP) Forward(xs ...mat.Tensor) []mat.Tensor {
+	gates := m.Activation.Forward(m.Gate.Forward(xs...)...)
+	return m.Down.Forward(ag.Map2(ag.Prod, gates, m.Up.Forward(xs...))...)
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ nn.Model = &RMSNorm{}
+
+// RMSNorm implements the root mean square layer normalization used by Llama,
+// which scales the input by its root mean square, without bias.
+type RMSNorm struct {
+	nn.Module
+	// W is the scaling parameter.
+	W *nn.Param
+	// Eps is the value added to the mean square for numerical stability.
+	Eps float64
+}
+
+func init() {
+	gob.Register(&RMSNorm{})
+}
+
+// NewRMSNorm returns a new RMSNorm.
+func NewRMSNorm[T float.DType](size int, eps float64) *RMSNorm {
+	return &RMSNorm{
+		W:   nn.NewParam(mat.NewDense[T](mat.WithBacking(mat.CreateInitializedSlice(size, T(1))))),
+		Eps: eps,
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *RMSNorm) Forward(xs ...mat.Tensor) []mat.Tensor {
+	if len(xs) == 0 {
+		return nil
+	}
+	eps := xs[0].Value().(mat.Matrix).NewScalar(m.Eps)
+	ys := make([]mat.Tensor, len(xs))
+	for i, x := range xs {
+		rms := ag.Sqrt(ag.AddScalar(ag.ReduceMean(ag.Square(x)), eps))
+		ys[i] = ag.Prod(ag.DivScalar(x, rms), m.W)
+	}
+	return ys
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"math"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+// RotaryEmbedding contains the rotary position embeddings (RoPE) of a range
+// of positions. See "RoFormer: Enhanced Transformer with Rotary Position Embedding"
+// (https://arxiv.org/abs/2104.09864).
+//
+// Following the Hugging Face implementation, the vector is split in two halves,
+// and the i-th element of the first half is rotated together with the i-th
+// element of the second half.
+type RotaryEmbedding struct {
+	// cos contains the cosines of the angles of each position.
+	cos []mat.Tensor
+	// sin contains the sines of the angles of each position, with the sign
+	// of the first half flipped, so that rotating the halves of the input
+	// and multiplying them by sin yields the rotated components.
+	sin []mat.Tensor
+	// half is the size of each half of the vectors.
+	half int
+}
+
+// NewRotaryEmbedding returns the RotaryEmbedding of the positions in the range
+// [offset, offset+length), for vectors of the given dimension.
+// The like matrix is used to create matrices of the same type.
+func NewRotaryEmbedding(like mat.Matrix, dim, offset, length int, theta float64) *RotaryEmbedding {
+	half := dim / 2
+	invFreq := make([]float64, half)
+	for i := range invFreq {
+		invFreq[i] = 1 / math.Pow(theta, float64(2*i)/float64(dim))
+	}
+
+	r := &RotaryEmbedding{
+		cos:  make([]mat.Tensor, length),
+		sin:  make([]mat.Tensor, length),
+		half: half,
+	}
+	for p := 0; p < length; p++ {
+		cos := make([]float64, dim)
+		sin := make([]float64, dim)
+		for i, f := range invFreq {
+			angle := float64(offset+p) * f
+			cos[i], cos[i+half] = math.Cos(angle), math.Cos(angle)
+			sin[i], sin[i+half] = -math.Sin(angle), math.Sin(angle)
+		}
+		r.cos[p] = like.NewMatrix(mat.WithShape(dim), mat.WithBacking(cos))
+		r.sin[p] = like.NewMatrix(mat.WithShape(dim), mat.WithBacking(sin))
+	}
+	return r
+}
+
+// Apply rotates each vector according to its position.
+func (r *RotaryEmbedding) Apply(xs []mat.Tensor) []mat.Tensor {
+	ys := make([]mat.Tensor, len(xs))
+	for i, x := range xs {
+		ys[i] = ag.Add(ag.Prod(x, r.cos[i]), ag.Prod(ag.RotateR(x, r.half), r.sin[i]))
+	}
+	return ys
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"encoding/gob"
+	"math"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var (
+	_ nn.Model = &Attention{}
+	_ nn.Model = &AttentionHead{}
+)
+
+// Attention implements the MPNet multi-head self-attention, whose scaled scores
+// are shifted by the relative position biases (see t5.RelativePositionBias).
+type Attention struct {
+	nn.Module
+	// Heads contains the attention heads.
+	Heads []*AttentionHead
+	// OutputMerge is the projection of the concatenated heads.
+	OutputMerge *linear.Model
+}
+
+// AttentionHead contains the projections of a single attention head.
+type AttentionHead struct {
+	nn.Module
+	// Query is the query projection.
+	Query *linear.Model
+	// Key is the key projection.
+	Key *linear.Model
+	// Value is the value projection.
+	Value *linear.Model
+}
+
+func init() {
+	gob.Register(&Attention{})
+	gob.Register(&AttentionHead{})
+}
+
+// NewAttention returns a new Attention.
+func NewAttention[T float.DType](c Config) *Attention {
+	heads := make([]*AttentionHead, c.NumAttentionHeads)
+	for i := range heads {
+		heads[i] = &AttentionHead{
+			Query: linear.New[T](c.HiddenSize, c.HeadDim()),
+			Key:   linear.New[T](c.HiddenSize, c.HeadDim()),
+			Value: linear.New[T](c.HiddenSize, c.HeadDim()),
+		}
+	}
+	return &Attention{
+		Heads:       heads,
+		OutputMerge: linear.New[T](c.HiddenSize, c.HiddenSize),
+	}
+}
+
+// Forward performs the self-attention of xs. The biases are indexed by head
+// and query position, and they are added to the corresponding attention scores.
+func (m *Attention) Forward(biases [][]mat.Tensor, xs []mat.Tensor) []mat.Tensor {
+	n := len(m.Heads)
+	attentions := make([][]mat.Tensor, n)
+	for i, h := range m.Heads {
+		attentions[i] = h.forward(biases[i], xs)
+	}
+
+	concat := make([]mat.Tensor, len(xs))
+	for i := range xs {
+		buf := make([]mat.Tensor, n)
+		for j := range buf {
+			buf[j] = attentions[j][i]
+		}
+		concat[i] = ag.Concat(buf...)
+	}
+	return m.OutputMerge.Forward(concat...)
+}
+
+func (m *AttentionHead) forward(bias []mat.Tensor, xs []mat.Tensor) []mat.Tensor {
+	pq := m.Query.Forward(xs...)
+	pk := ag.Stack(m.Key.Forward(xs...)...)
+	pv := ag.Stack(m.Value.Forward(xs...)...)
+
+	scale := pk.Value().(mat.Matrix).NewScalar(1 / math.Sqrt(float64(pk.Value().Shape()[1])))
+	result := make([]mat.Tensor, len(pq))
+	for i, q := range pq {
+		scores := ag.Add(ag.ProdScalar(ag.Mul(pk, q), scale), bias[i])
+		result[i] = ag.MulT(pv, ag.Softmax(scores))
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the global configuration of the MPNet model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Architectures               []string          `json:"architectures"`
+	AttentionProbsDropoutProb   float64           `json:"attention_probs_dropout_prob"`
+	BosTokenID                  int               `json:"bos_token_id"`
+	EosTokenID                  int               `json:"eos_token_id"`
+	HiddenAct                   string            `json:"hidden_act"`
+	HiddenDropoutProb           float64           `json:"hidden_dropout_prob"`
+	HiddenSize                  int               `json:"hidden_size"`
+	InitializerRange            float64           `json:"initializer_range"`
+	IntermediateSize            int               `json:"intermediate_size"`
+	LayerNormEps                float64           `json:"layer_norm_eps"`
+	MaxPositionEmbeddings       int               `json:"max_position_embeddings"`
+	ModelType                   string            `json:"model_type"`
+	NumAttentionHeads           int               `json:"num_attention_heads"`
+	NumHiddenLayers             int               `json:"num_hidden_layers"`
+	PadTokenID                  int               `json:"pad_token_id"`
+	RelativeAttentionNumBuckets int               `json:"relative_attention_num_buckets"`
+	TransformersVersion         string            `json:"transformers_version"`
+	VocabSize                   int               `json:"vocab_size"`
+	ID2Label                    map[string]string `json:"id2label"`
+	Cybertron                   struct {
+		Training bool `json:"training"`
+	}
+}
+
+// ConfigFromFile loads an MPNet model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face MPNet configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		BosTokenID:                  0,
+		EosTokenID:                  2,
+		HiddenAct:                   "gelu",
+		LayerNormEps:                1e-12,
+		MaxPositionEmbeddings:       514,
+		PadTokenID:                  1,
+		RelativeAttentionNumBuckets: 32,
+	}
+}
+
+// HeadDim returns the size of each attention head.
+func (c Config) HeadDim() int {
+	return c.HiddenSize / c.NumAttentionHeads
+}
+
+// PositionIDsOffset returns the offset to add to the position ids of the input tokens.
+// Like RoBERTa, MPNet reserves the first `pad_token_id + 1` positions.
+func (c Config) PositionIDsOffset() int {
+	return c.PadTokenID + 1
+}
+
+// MaxSequenceLength returns the maximum number of tokens the model can encode.
+func (c Config) MaxSequenceLength() int {
+	return c.MaxPositionEmbeddings - c.PositionIDsOffset()
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	emb "github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &Embeddings{}
+
+// Embeddings implements an MPNet input embedding module.
+// Unlike BERT, there are no token type embeddings.
+type Embeddings struct {
+	nn.Module
+	Vocab     *vocabulary.Vocabulary
+	Tokens    *emb.Model
+	Positions *emb.Model
+	Norm      *layernorm.Model
+	Config    Config
+}
+
+func init() {
+	gob.Register(&Embeddings{})
+}
+
+// NewEmbeddings returns a new MPNet input embedding module.
+func NewEmbeddings[T float.DType](c Config) *Embeddings {
+	return &Embeddings{
+		Tokens:    emb.New[T](c.VocabSize, c.HiddenSize),
+		Positions: emb.New[T](c.MaxPositionEmbeddings, c.HiddenSize),
+		Norm:      layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Config:    c,
+	}
+}
+
+// EncodeTokens performs the MPNet input encoding.
+func (m *Embeddings) EncodeTokens(tokens []string) []mat.Tensor {
+	var (
+		encoded   = m.Tokens.MustEncode(m.tokensToIDs(tokens))
+		positions = m.Positions.MustEncode(indices(len(tokens), m.Config.PositionIDsOffset()))
+	)
+	for i := range encoded {
+		encoded[i] = ag.Add(encoded[i], positions[i])
+	}
+	return m.Norm.Forward(encoded...)
+}
+
+// tokensToIDs returns the IDs of the given tokens.
+func (m *Embeddings) tokensToIDs(tokens []string) []int {
+	IDs := make([]int, len(tokens))
+	for i, token := range tokens {
+		IDs[i] = m.Vocab.MustID(token)
+	}
+	return IDs
+}
+
+// indices returns a slice of the given size, where each element has
+// the same value of its own index position plus the given offset.
+func indices(size, offset int) []int {
+	idx := make([]int, size)
+	for i := range idx {
+		idx[i] = i + offset
+	}
+	return idx
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/models/t5"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+// relativeAttentionMaxDistance is the relative distance beyond which
+// all the positions share the same bucket.
+const relativeAttentionMaxDistance = 128
+
+var (
+	_ nn.Model = &Encoder{}
+	_ nn.Model = &EncoderLayer{}
+)
+
+// Encoder implements an MPNet encoder.
+type Encoder struct {
+	nn.Module
+	// Layers is the list of encoder layers.
+	Layers []*EncoderLayer
+	// PositionBias is the relative position bias shared by all the layers.
+	// It is computed by bidirectional buckets, the same as T5's.
+	PositionBias *t5.RelativePositionBias
+	// Config is the model configuration.
+	Config Config
+}
+
+// EncoderLayer implements an MPNet encoder layer.
+type EncoderLayer struct {
+	nn.Module
+	// Attention is the self-attention module.
+	Attention *Attention
+	// AttentionNorm is the normalization of the residual self-attention output.
+	AttentionNorm *layernorm.Model
+	// FF is the post-norm residual feed-forward block, the same as BERT's.
+	FF *bert.FeedForwardBlock
+}
+
+func init() {
+	gob.Register(&Encoder{})
+	gob.Register(&EncoderLayer{})
+}
+
+// NewEncoder returns a new Encoder.
+func NewEncoder[T float.DType](c Config) *Encoder {
+	layers := make([]*EncoderLayer, c.NumHiddenLayers)
+	for i := range layers {
+		layers[i] = &EncoderLayer{
+			Attention:     NewAttention[T](c),
+			AttentionNorm: layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+			FF: bert.NewFeedForwardBlock[T](bert.FeedForwardBlockConfig{
+				Dim:        c.HiddenSize,
+				HiddenDim:  c.IntermediateSize,
+				Activation: activation.MustParseActivation(c.HiddenAct),
+			}),
+		}
+	}
+	return &Encoder{
+		Layers: layers,
+		PositionBias: t5.NewRelativePositionBias[T](t5.RelativePositionBiasConfig{
+			NumBuckets:    c.RelativeAttentionNumBuckets,
+			MaxDistance:   relativeAttentionMaxDistance,
+			NumHeads:      c.NumAttentionHeads,
+			Bidirectional: true,
+		}),
+		Config: c,
+	}
+}
+
+// Encode performs the MPNet encoding.
+func (m *Encoder) Encode(xs []mat.Tensor) []mat.Tensor {
+	biases := m.PositionBias.Forward(0, len(xs), len(xs))
+	for _, layer := range m.Layers {
+		xs = layer.Forward(xs, biases)
+	}
+	return xs
+}
+
+// Forward performs the forward step of the encoder layer.
+func (m *EncoderLayer) Forward(xs []mat.Tensor, biases [][]mat.Tensor) []mat.Tensor {
+	hs := m.AttentionNorm.Forward(ag.Map2(ag.Add, xs, m.Attention.Forward(biases, xs))...)
+	return m.FF.Forward(hs)
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpnet
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &Pooler{}
+
+// Pooler implements the MPNet pooler, which maps the encoding of the first token
+// to the representation of the whole sequence.
+type Pooler struct {
+	nn.Module
+	Model nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&Pooler{})
+}
+
+// NewPooler returns a new Pooler.
+func NewPooler[T float.DType](c Config) *Pooler {
+	return &Pooler{
+		Model: []nn.StandardModel{
+			linear.New[T](c.HiddenSize, c.HiddenSize),
+			activation.New(activation.Tanh),
+		},
+	}
+}
+
+// Forward returns the pooled representation of the encoded first token.
+func (m *Pooler) Forward(encoded mat.Tensor) mat.Tensor {
+	return m.Model.Forward(encoded)[0]
+}
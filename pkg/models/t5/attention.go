@@ -0,0 +1,126 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/attention/selfattention"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var (
+	_ nn.Model = &Attention{}
+	_ nn.Model = &AttentionHead{}
+)
+
+// Attention implements the T5 multi-head attention.
+// Unlike the standard one, the scores are not scaled, and they can be shifted
+// by externally provided biases (see RelativePositionBias).
+type Attention struct {
+	nn.Module
+	// Heads contains the attention heads.
+	Heads []*AttentionHead
+	// OutputMerge is the projection of the concatenated heads.
+	OutputMerge *linear.Model
+	// IsCrossAttention reports whether the keys and values are computed once
+	// from a different (encoded) sequence.
+	IsCrossAttention bool
+}
+
+// AttentionHead contains the projections of a single attention head.
+type AttentionHead struct {
+	nn.Module
+	// Query is the query projection.
+	Query *linear.Model
+	// Key is the key projection.
+	Key *linear.Model
+	// Value is the value projection.
+	Value *linear.Model
+}
+
+func init() {
+	gob.Register(&Attention{})
+	gob.Register(&AttentionHead{})
+}
+
+// NewAttention returns a new Attention.
+func NewAttention[T float.DType](c Config, isCrossAttention bool) *Attention {
+	heads := make([]*AttentionHead, c.NumHeads)
+	for i := range heads {
+		heads[i] = &AttentionHead{
+			Query: linear.New[T](c.DModel, c.DKV),
+			Key:   linear.New[T](c.DModel, c.DKV),
+			Value: linear.New[T](c.DModel, c.DKV),
+		}
+	}
+	return &Attention{
+		Heads:            heads,
+		OutputMerge:      linear.New[T](c.NumHeads*c.DKV, c.DModel),
+		IsCrossAttention: isCrossAttention,
+	}
+}
+
+// Forward performs the attention of xs over kv. The optional biases are indexed by head
+// and query position, and they are added to the corresponding attention scores.
+func (m *Attention) Forward(cache multiheadattention.Cache, biases [][]mat.Tensor, xs, kv []mat.Tensor) ([]mat.Tensor, multiheadattention.Cache) {
+	n := len(m.Heads)
+	attentions := make([][]mat.Tensor, n)
+	nextCache := make(multiheadattention.Cache, n)
+
+	for i, h := range m.Heads {
+		var bias []mat.Tensor
+		if biases != nil {
+			bias = biases[i]
+		}
+		attentions[i], nextCache[i] = h.forward(cache.At(i), bias, xs, kv, m.IsCrossAttention)
+	}
+
+	concat := make([]mat.Tensor, len(xs))
+	for i := range xs {
+		buf := make([]mat.Tensor, n)
+		for j := range buf {
+			buf[j] = attentions[j][i]
+		}
+		concat[i] = ag.Concat(buf...)
+	}
+	return m.OutputMerge.Forward(concat...), nextCache
+}
+
+func (m *AttentionHead) forward(cache selfattention.Cache, bias []mat.Tensor, xs, kv []mat.Tensor, isCrossAttention bool) ([]mat.Tensor, selfattention.Cache) {
+	var pk, pv mat.Tensor
+
+	pq := m.Query.Forward(xs...)
+
+	if hasCache := cache.HasValues(); hasCache && isCrossAttention {
+		pk, pv = cache[0], cache[1]
+	} else {
+		k := m.Key.Forward(kv...)
+		v := m.Value.Forward(kv...)
+
+		if hasCache {
+			pk = ag.AppendRows(cache[0], k...)
+			pv = ag.AppendRows(cache[1], v...)
+		} else {
+			pk = ag.Stack(k...)
+			pv = ag.Stack(v...)
+		}
+	}
+
+	result := make([]mat.Tensor, len(pq))
+	for i, q := range pq {
+		scores := ag.Mul(pk, q)
+		if bias != nil {
+			scores = ag.Add(scores, bias[i])
+		}
+		result[i] = ag.MulT(pv, ag.Softmax(scores))
+	}
+	return result, selfattention.Cache{pk, pv}
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// Config contains the global configuration of the T5 model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Architectures                []string `json:"architectures"`
+	DModel                       int      `json:"d_model"`
+	DKV                          int      `json:"d_kv"`
+	DFF                          int      `json:"d_ff"`
+	DecoderStartTokenID          int      `json:"decoder_start_token_id"`
+	DropoutRate                  float64  `json:"dropout_rate"`
+	EosTokenID                   int      `json:"eos_token_id"`
+	FeedForwardProj              string   `json:"feed_forward_proj"`
+	InitializerFactor            float64  `json:"initializer_factor"`
+	IsEncoderDecoder             bool     `json:"is_encoder_decoder"`
+	LayerNormEpsilon             float64  `json:"layer_norm_epsilon"`
+	ModelType                    string   `json:"model_type"`
+	NPositions                   int      `json:"n_positions"`
+	NumDecoderLayers             int      `json:"num_decoder_layers"`
+	NumHeads                     int      `json:"num_heads"`
+	NumLayers                    int      `json:"num_layers"`
+	PadTokenID                   int      `json:"pad_token_id"`
+	RelativeAttentionMaxDistance int      `json:"relative_attention_max_distance"`
+	RelativeAttentionNumBuckets  int      `json:"relative_attention_num_buckets"`
+	TieWordEmbeddings            bool     `json:"tie_word_embeddings"`
+	VocabSize                    int      `json:"vocab_size"`
+	NumBeams                     int      `json:"num_beams"`
+	MaxLength                    int      `json:"max_length"`
+	MinLength                    int      `json:"min_length"`
+	LengthPenalty                float64  `json:"length_penalty"`
+	EarlyStopping                bool     `json:"early_stopping"`
+	NoRepeatNGramSize            int      `json:"no_repeat_ngram_size"`
+	BadWordsIDs                  [][]int  `json:"bad_words_ids"`
+	Cybertron                    struct {
+		Training bool `json:"training"`
+	}
+}
+
+// ConfigFromFile loads a T5 model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// Set default values
+	if config.NumDecoderLayers == 0 {
+		config.NumDecoderLayers = config.NumLayers
+	}
+	if config.MaxLength == 0 {
+		config.MaxLength = config.NPositions
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face T5 configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		DecoderStartTokenID:          0,
+		EosTokenID:                   1,
+		FeedForwardProj:              "relu",
+		IsEncoderDecoder:             true,
+		LayerNormEpsilon:             1e-6,
+		NPositions:                   512,
+		PadTokenID:                   0,
+		RelativeAttentionMaxDistance: 128,
+		RelativeAttentionNumBuckets:  32,
+		TieWordEmbeddings:            true,
+		NumBeams:                     1,
+		LengthPenalty:                1.0,
+	}
+}
+
+// IsGatedActivation reports whether the feed-forward layers use a gated
+// activation (e.g. "gated-gelu" in T5 v1.1 and Flan-T5).
+func (c Config) IsGatedActivation() bool {
+	return strings.HasPrefix(c.FeedForwardProj, "gated-")
+}
+
+// DenseActivation returns the name of the activation function of the feed-forward layers.
+func (c Config) DenseActivation() string {
+	act := strings.TrimPrefix(c.FeedForwardProj, "gated-")
+	if act == "gelu_new" {
+		return "gelu"
+	}
+	return act
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var (
+	_ nn.Model = &Decoder{}
+	_ nn.Model = &DecoderLayer{}
+)
+
+// Decoder implements a T5 decoder.
+type Decoder struct {
+	nn.Module
+	// Embeddings contains the embeddings shared with the encoder.
+	Embeddings embedding.Shared
+	// PositionBias is the relative position bias shared by all the layers.
+	PositionBias *RelativePositionBias
+	// Layers is the list of decoder layers.
+	Layers []*DecoderLayer
+	// LayerNorm is the final layer normalization.
+	LayerNorm *LayerNorm
+	// Config is the configuration of the decoder.
+	Config Config
+}
+
+// DecoderLayer implements a T5 decoder layer.
+type DecoderLayer struct {
+	nn.Module
+	// SelfAttention is the self-attention module.
+	SelfAttention *Attention
+	// SelfAttentionNorm is the normalization applied before the self-attention.
+	SelfAttentionNorm *LayerNorm
+	// CrossAttention is the cross-attention module.
+	CrossAttention *Attention
+	// CrossAttentionNorm is the normalization applied before the cross-attention.
+	CrossAttentionNorm *LayerNorm
+	// FF is the feed-forward module.
+	FF *FeedForward
+	// FFNorm is the normalization applied before the feed-forward.
+	FFNorm *LayerNorm
+}
+
+// Cache contains the cache of each DecoderLayer.
+// For each layer, the cache contains the keys and values used by the self-attention at index 0 and cross-attention at index 1.
+type Cache [][2]multiheadattention.Cache
+
+// Layer returns the cache at the given index.
+func (c Cache) Layer(i int) [2]multiheadattention.Cache {
+	if len(c) == 0 {
+		return [2]multiheadattention.Cache{}
+	}
+	return c[i]
+}
+
+func init() {
+	gob.Register(&Decoder{})
+	gob.Register(&DecoderLayer{})
+}
+
+// NewDecoder returns a new Decoder.
+func NewDecoder[T float.DType](c Config, shared embedding.Shared) *Decoder {
+	layers := make([]*DecoderLayer, c.NumDecoderLayers)
+	for i := range layers {
+		layers[i] = &DecoderLayer{
+			SelfAttention:      NewAttention[T](c, false),
+			SelfAttentionNorm:  NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+			CrossAttention:     NewAttention[T](c, true),
+			CrossAttentionNorm: NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+			FF:                 NewFeedForward[T](c),
+			FFNorm:             NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+		}
+	}
+	return &Decoder{
+		Embeddings: shared,
+		PositionBias: NewRelativePositionBias[T](RelativePositionBiasConfig{
+			NumBuckets:    c.RelativeAttentionNumBuckets,
+			MaxDistance:   c.RelativeAttentionMaxDistance,
+			NumHeads:      c.NumHeads,
+			Bidirectional: false,
+		}),
+		Layers:    layers,
+		LayerNorm: NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+		Config:    c,
+	}
+}
+
+// Decode performs the decoding considering the encoder output and the decoder input.
+// The curLen is the length of the decoded sequence including the inputIDs.
+func (m *Decoder) Decode(encoderStates []mat.Tensor, inputIDs []int, cache Cache, curLen int) ([]mat.Tensor, Cache) {
+	nextCache := make(Cache, len(m.Layers))
+	ys := m.Embeddings.MustEncode(inputIDs)
+	biases := m.PositionBias.Forward(curLen-len(inputIDs), len(inputIDs), curLen)
+	for i, layer := range m.Layers {
+		ys, nextCache[i] = layer.Forward(cache.Layer(i), ys, encoderStates, biases)
+	}
+	return m.LayerNorm.Forward(ys...), nextCache
+}
+
+// Forward performs the forward step of the decoder layer.
+func (m *DecoderLayer) Forward(cache [2]multiheadattention.Cache, xs, encoderStates []mat.Tensor, biases [][]mat.Tensor) ([]mat.Tensor, [2]multiheadattention.Cache) {
+	var nextCache [2]multiheadattention.Cache
+	var att []mat.Tensor
+
+	norm := m.SelfAttentionNorm.Forward(xs...)
+	att, nextCache[0] = m.SelfAttention.Forward(cache[0], biases, norm, norm)
+	hs := ag.Map2(ag.Add, xs, att)
+
+	att, nextCache[1] = m.CrossAttention.Forward(cache[1], nil, m.CrossAttentionNorm.Forward(hs...), encoderStates)
+	hs = ag.Map2(ag.Add, hs, att)
+
+	hs = ag.Map2(ag.Add, hs, m.FF.Forward(m.FFNorm.Forward(hs...)...))
+	return hs, nextCache
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var (
+	_ nn.Model = &Encoder{}
+	_ nn.Model = &EncoderLayer{}
+)
+
+// Encoder implements a T5 encoder.
+type Encoder struct {
+	nn.Module
+	// Embeddings contains the embeddings shared with the decoder.
+	Embeddings embedding.Shared
+	// PositionBias is the relative position bias shared by all the layers.
+	PositionBias *RelativePositionBias
+	// Layers is the list of encoder layers.
+	Layers []*EncoderLayer
+	// LayerNorm is the final layer normalization.
+	LayerNorm *LayerNorm
+	// Config is the configuration of the encoder.
+	Config Config
+}
+
+// EncoderLayer implements a T5 encoder layer.
+type EncoderLayer struct {
+	nn.Module
+	// SelfAttention is the self-attention module.
+	SelfAttention *Attention
+	// SelfAttentionNorm is the normalization applied before the self-attention.
+	SelfAttentionNorm *LayerNorm
+	// FF is the feed-forward module.
+	FF *FeedForward
+	// FFNorm is the normalization applied before the feed-forward.
+	FFNorm *LayerNorm
+}
+
+func init() {
+	gob.Register(&Encoder{})
+	gob.Register(&EncoderLayer{})
+}
+
+// NewEncoder returns a new Encoder.
+func NewEncoder[T float.DType](c Config, shared embedding.Shared) *Encoder {
+	layers := make([]*EncoderLayer, c.NumLayers)
+	for i := range layers {
+		layers[i] = &EncoderLayer{
+			SelfAttention:     NewAttention[T](c, false),
+			SelfAttentionNorm: NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+			FF:                NewFeedForward[T](c),
+			FFNorm:            NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+		}
+	}
+	return &Encoder{
+		Embeddings: shared,
+		PositionBias: NewRelativePositionBias[T](RelativePositionBiasConfig{
+			NumBuckets:    c.RelativeAttentionNumBuckets,
+			MaxDistance:   c.RelativeAttentionMaxDistance,
+			NumHeads:      c.NumHeads,
+			Bidirectional: true,
+		}),
+		Layers:    layers,
+		LayerNorm: NewLayerNorm[T](c.DModel, c.LayerNormEpsilon),
+		Config:    c,
+	}
+}
+
+// Encode performs the T5 encoding.
+func (m *Encoder) Encode(inputIDs []int) []mat.Tensor {
+	ys := m.Embeddings.MustEncode(inputIDs)
+	biases := m.PositionBias.Forward(0, len(inputIDs), len(inputIDs))
+	for _, layer := range m.Layers {
+		ys = layer.Forward(ys, biases)
+	}
+	return m.LayerNorm.Forward(ys...)
+}
+
+// Forward performs the forward step of the encoder layer.
+func (m *EncoderLayer) Forward(xs []mat.Tensor, biases [][]mat.Tensor) []mat.Tensor {
+	norm := m.SelfAttentionNorm.Forward(xs...)
+	att, _ := m.SelfAttention.Forward(nil, biases, norm, norm)
+	hs := ag.Map2(ag.Add, xs, att)
+	hs = ag.Map2(ag.Add, hs, m.FF.Forward(m.FFNorm.Forward(hs...)...))
+	return hs
+}
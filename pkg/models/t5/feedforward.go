@@ -0,0 +1,60 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &FeedForward{}
+
+// FeedForward implements the T5 feed-forward layer.
+// With a gated activation (T5 v1.1, Flan-T5) it computes Output(act(Input(x)) * Gate(x)),
+// otherwise Output(act(Input(x))). None of the projections use a bias.
+type FeedForward struct {
+	nn.Module
+	// Input is the first projection (the "wi" or "wi_0" layer).
+	Input *linear.Model
+	// Gate is the linear projection multiplied by the activated input (the "wi_1" layer).
+	// It is nil if the activation is not gated.
+	Gate *linear.Model
+	// Output is the final projection (the "wo" layer).
+	Output *linear.Model
+	// Activation is the activation function.
+	Activation *activation.Model
+}
+
+func init() {
+	gob.Register(&FeedForward{})
+}
+
+// NewFeedForward returns a new FeedForward.
+func NewFeedForward[T float.DType](c Config) *FeedForward {
+	m := &FeedForward{
+		Input:      linear.New[T](c.DModel, c.DFF),
+		Output:     linear.New[T](c.DFF, c.DModel),
+		Activation: activation.New(activation.MustParseActivation(c.DenseActivation())),
+	}
+	if c.IsGatedActivation() {
+		m.Gate = linear.New[T](c.DModel, c.DFF)
+	}
+	return m
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *FeedForward) Forward(xs ...mat.Tensor) []mat.Tensor {
+	hs := m.Activation.Forward(m.Input.Forward(xs...)...)
+	if m.Gate != nil {
+		hs = ag.Map2(ag.Prod, hs, m.Gate.Forward(xs...))
+	}
+	return m.Output.Forward(hs...)
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ nn.Model = &LayerNorm{}
+
+// LayerNorm implements the T5 layer normalization, which only scales the input
+// by its root mean square, without subtracting the mean and without bias.
+type LayerNorm struct {
+	nn.Module
+	// W is the scaling parameter.
+	W *nn.Param
+	// Eps is the value added to the mean square for numerical stability.
+	Eps float64
+}
+
+func init() {
+	gob.Register(&LayerNorm{})
+}
+
+// NewLayerNorm returns a new LayerNorm.
+func NewLayerNorm[T float.DType](size int, eps float64) *LayerNorm {
+	return &LayerNorm{
+		W:   nn.NewParam(mat.NewDense[T](mat.WithBacking(mat.CreateInitializedSlice(size, T(1))))),
+		Eps: eps,
+	}
+}
+
+// Forward performs the forward step for each input node and returns the result.
+func (m *LayerNorm) Forward(xs ...mat.Tensor) []mat.Tensor {
+	if len(xs) == 0 {
+		return nil
+	}
+	eps := xs[0].Value().(mat.Matrix).NewScalar(m.Eps)
+	ys := make([]mat.Tensor, len(xs))
+	for i, x := range xs {
+		rms := ag.Sqrt(ag.AddScalar(ag.ReduceMean(ag.Square(x)), eps))
+		ys[i] = ag.Prod(ag.DivScalar(x, rms), m.W)
+	}
+	return ys
+}
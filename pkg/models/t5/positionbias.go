@@ -0,0 +1,118 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+	"math"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var _ nn.Model = &RelativePositionBias{}
+
+// RelativePositionBias computes the scalar biases added to the attention scores
+// of each head, learned for buckets of relative distances between query and key positions.
+type RelativePositionBias struct {
+	nn.Module
+	// Embeddings contains a vector of size NumHeads for each bucket.
+	Embeddings *embedding.Model
+	// Config is the configuration of the relative position bias.
+	Config RelativePositionBiasConfig
+}
+
+// RelativePositionBiasConfig provides the configuration of the RelativePositionBias.
+type RelativePositionBiasConfig struct {
+	NumBuckets    int
+	MaxDistance   int
+	NumHeads      int
+	Bidirectional bool
+}
+
+func init() {
+	gob.Register(&RelativePositionBias{})
+}
+
+// NewRelativePositionBias returns a new RelativePositionBias.
+func NewRelativePositionBias[T float.DType](c RelativePositionBiasConfig) *RelativePositionBias {
+	return &RelativePositionBias{
+		Embeddings: embedding.New[T](c.NumBuckets, c.NumHeads),
+		Config:     c,
+	}
+}
+
+// Forward returns the biases for each head and for each query position, starting from
+// queryOffset, with respect to keyLength key positions.
+// If the bias is unidirectional, it also masks the keys that follow each query.
+func (m *RelativePositionBias) Forward(queryOffset, queryLength, keyLength int) [][]mat.Tensor {
+	biases := make([][]mat.Tensor, m.Config.NumHeads)
+	for h := range biases {
+		biases[h] = make([]mat.Tensor, queryLength)
+	}
+
+	for i := 0; i < queryLength; i++ {
+		queryPosition := queryOffset + i
+		buckets := make([]int, keyLength)
+		for j := range buckets {
+			buckets[j] = m.bucket(j - queryPosition)
+		}
+		values := ag.Stack(m.Embeddings.MustEncode(buckets)...)
+
+		var causalMask mat.Tensor
+		if !m.Config.Bidirectional && queryPosition+1 < keyLength {
+			causalMask = values.Value().(mat.Matrix).NewMatrix(mat.WithBacking(makeCausalMask(queryPosition, keyLength)))
+		}
+
+		for h := 0; h < m.Config.NumHeads; h++ {
+			bias := ag.ColView(values, h)
+			if causalMask != nil {
+				bias = ag.Add(bias, causalMask)
+			}
+			biases[h][i] = bias
+		}
+	}
+	return biases
+}
+
+// bucket translates a relative position (key position minus query position) to a bucket index.
+// Half of the buckets are used for exact increments in positions, the others for logarithmically
+// bigger bins up to MaxDistance; all relative positions beyond it are mapped to the same bucket.
+func (m *RelativePositionBias) bucket(relativePosition int) int {
+	numBuckets := m.Config.NumBuckets
+	bucket := 0
+	if m.Config.Bidirectional {
+		numBuckets /= 2
+		if relativePosition > 0 {
+			bucket += numBuckets
+		}
+		if relativePosition < 0 {
+			relativePosition = -relativePosition
+		}
+	} else {
+		relativePosition = -min(relativePosition, 0)
+	}
+
+	maxExact := numBuckets / 2
+	if relativePosition < maxExact {
+		return bucket + relativePosition
+	}
+
+	large := maxExact + int(math.Log(float64(relativePosition)/float64(maxExact))/
+		math.Log(float64(m.Config.MaxDistance)/float64(maxExact))*float64(numBuckets-maxExact))
+	return bucket + min(large, numBuckets-1)
+}
+
+// makeCausalMask returns a slice of size length filled with zeros until curIndex, and the rest with -inf.
+func makeCausalMask(curIndex, length int) []float64 {
+	mask := make([]float64, length)
+	for k := curIndex + 1; k < length; k++ {
+		mask[k] = math.Inf(-1)
+	}
+	return mask
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package t5 implements the transformer model introduced by Raffel et al., 2019.
+// "Exploring the Limits of Transfer Learning with a Unified Text-to-Text Transformer"
+// https://arxiv.org/abs/1910.10683
+//
+// It also supports the T5 v1.1 variants with gated activations, such as Flan-T5.
+package t5
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base T5 encoder-decoder model without any head on top.
+type Model struct {
+	nn.Module
+	// Config is the model configuration.
+	Config Config
+	// Encoder is the encoder model.
+	Encoder *Encoder
+	// Decoder is the decoder model.
+	Decoder *Decoder
+	// Embeddings contains the embeddings shared between the encoder and the decoder.
+	Embeddings *embedding.Model
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new T5 model.
+func New[T float.DType](c Config) *Model {
+	emb := embedding.New[T](c.VocabSize, c.DModel)
+	return &Model{
+		Encoder:    NewEncoder[T](c, embedding.Shared{Model: emb}),
+		Decoder:    NewDecoder[T](c, embedding.Shared{Model: emb}),
+		Embeddings: emb,
+		Config:     c,
+	}
+}
+
+// Forward performs encoding-decoding, where the decoder input is the
+// target sequence shifted right by the decoder start token.
+func (m *Model) Forward(inputIDs, targetIDs []int) []mat.Tensor {
+	encoded := m.Encoder.Encode(inputIDs)
+	decoderInputIDs := append([]int{m.Config.DecoderStartTokenID}, targetIDs[:len(targetIDs)-1]...)
+	decoded, _ := m.Decoder.Decode(encoded, decoderInputIDs, nil, len(decoderInputIDs))
+	return decoded
+}
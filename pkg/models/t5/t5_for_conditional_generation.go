@@ -0,0 +1,165 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"encoding/gob"
+	"math"
+	"sync"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForConditionalGeneration{}
+
+// ModelForConditionalGeneration is a model for conditional generation tasks
+// which embeds a T5 fine-tuned model.
+type ModelForConditionalGeneration struct {
+	nn.Module
+	// T5 is the fine-tuned T5 model.
+	T5 *Model
+	// Projection is the projection layer from the decoder output to the vocabulary.
+	// When the word embeddings are tied, it contains a copy of them.
+	Projection *linear.Model
+	// ProjectionScale is the scaling factor applied to the decoder output before the projection.
+	ProjectionScale *nn.Buffer
+	// PadMask is the mask for the pad token.
+	PadMask *nn.Buffer
+	// EosMask is the mask for the EOS token.
+	EosMask *nn.Buffer
+}
+
+func init() {
+	gob.Register(&ModelForConditionalGeneration{})
+}
+
+// NewModelForConditionalGeneration returns a new model for conditional generation.
+func NewModelForConditionalGeneration[T float.DType](t5 *Model) *ModelForConditionalGeneration {
+	c := t5.Config
+	scale := 1.0
+	if c.TieWordEmbeddings {
+		// The original T5 rescales the output before projecting it onto the tied embeddings.
+		scale = 1.0 / math.Sqrt(float64(c.DModel))
+	}
+	return &ModelForConditionalGeneration{
+		T5:              t5,
+		Projection:      linear.New[T](c.DModel, c.VocabSize),
+		ProjectionScale: nn.Buf(mat.Scalar(T(scale))),
+		PadMask:         makePadMask[T](c.PadTokenID, c.VocabSize),
+		EosMask:         makeEosMask[T](c.EosTokenID, c.VocabSize),
+	}
+}
+
+// makePadMask returns a mask for padding.
+func makePadMask[T float.DType](padTokenID int, vocabSize int) *nn.Buffer {
+	mask := mat.NewDense[T](mat.WithBacking(mat.CreateInitializedSlice(vocabSize, 0.)))
+	mask.SetScalar(float.Interface(mat.Inf[T](-1)), padTokenID)
+	return nn.Buf(mask)
+}
+
+// makeEosMask returns a mask for EOS.
+func makeEosMask[T float.DType](eosTokenID int, vocabSize int) *nn.Buffer {
+	mask := mat.NewDense[T](mat.WithBacking(mat.CreateInitializedSlice(vocabSize, mat.Inf[T](-1))))
+	mask.SetScalar(float.Interface(T(0)), eosTokenID)
+	return nn.Buf(mask)
+}
+
+// DecodingInput is the input for the decoding function of the model for conditional generation.
+type DecodingInput struct {
+	// InputIDs are the input IDs for the decoder.
+	InputIDs []int
+	// CurLen is the current length of the generating sequence.
+	CurLen int
+	// MaxLength is the maximum length of the generating sequence, whose last
+	// token is forced to be the EOS. If zero, the one of the Config is used.
+	MaxLength int
+	// Cache is the cache for the decoder.
+	Cache Cache
+}
+
+// DecodingOutput is the output of the decoding function of the model for conditional generation.
+type DecodingOutput struct {
+	// LogProbRaw is the raw (not processed) log probability of the generated token.
+	LogProbRaw mat.Tensor
+	// LogProbValue is the value of the log probability of the generated token,
+	// which is processed by the decoding search.
+	LogProbValue mat.Matrix
+	// NextCache is the next cache.
+	NextCache Cache
+}
+
+// DecodingFunc returns a decoding function that works using the encoder states derived from the input.
+// During inference, it adjusts the logits to avoid impossible tokens.
+func (m *ModelForConditionalGeneration) DecodingFunc(encoderInputIDs []int, inference bool) func(batch []*DecodingInput) []*DecodingOutput {
+	encoderStates := m.T5.Encoder.Encode(encoderInputIDs)
+
+	return func(batch []*DecodingInput) []*DecodingOutput {
+		result := make([]*DecodingOutput, len(batch))
+
+		var wg sync.WaitGroup
+		wg.Add(len(batch))
+
+		for i, item := range batch {
+			i, item := i, item
+			go func() {
+				defer wg.Done()
+				result[i] = m.next(decodingState{
+					encoderStates: encoderStates,
+					decodingInput: item,
+					inference:     inference,
+				})
+			}()
+		}
+		wg.Wait()
+		return result
+	}
+}
+
+// decodingState is a state for the decoding function.
+type decodingState struct {
+	encoderStates []mat.Tensor
+	decodingInput *DecodingInput
+	inference     bool
+}
+
+// next returns the log probability for the generated tokens.
+func (m *ModelForConditionalGeneration) next(state decodingState) *DecodingOutput {
+	decoded, nextCache := m.T5.Decoder.Decode(
+		state.encoderStates,
+		state.decodingInput.InputIDs,
+		state.decodingInput.Cache,
+		state.decodingInput.CurLen,
+	)
+
+	last := decoded[len(decoded)-1]
+	logits := m.Projection.Forward(ag.ProdScalar(last, m.ProjectionScale))[0]
+	if state.inference {
+		logits = m.adjustLogits(logits, state.decodingInput.CurLen, state.decodingInput.MaxLength)
+	}
+
+	logProb := ag.LogSoftmax(logits)
+
+	return &DecodingOutput{
+		LogProbRaw:   logProb,
+		LogProbValue: logProb.Value().(mat.Matrix),
+		NextCache:    nextCache,
+	}
+}
+
+// adjustLogits applies the mask to the logits to avoid impossible token from being generated during inference.
+func (m *ModelForConditionalGeneration) adjustLogits(xs mat.Tensor, curLen, maxLength int) mat.Tensor {
+	if maxLength == 0 {
+		maxLength = m.T5.Config.MaxLength
+	}
+	ys := ag.Add(xs, m.PadMask) // Don't generate pad token
+	if curLen == maxLength-1 && m.T5.Config.EosTokenID >= 0 {
+		ys = ag.Add(ys, m.EosMask) // Force EOS to be generated
+	}
+	return ys
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config contains the global configuration of the ViT model and the heads of fine-tuning tasks.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	Architectures     []string          `json:"architectures"`
+	HiddenAct         string            `json:"hidden_act"`
+	HiddenSize        int               `json:"hidden_size"`
+	ID2Label          map[string]string `json:"id2label"`
+	ImageSize         int               `json:"image_size"`
+	IntermediateSize  int               `json:"intermediate_size"`
+	LayerNormEps      float64           `json:"layer_norm_eps"`
+	ModelType         string            `json:"model_type"`
+	NumAttentionHeads int               `json:"num_attention_heads"`
+	NumChannels       int               `json:"num_channels"`
+	NumHiddenLayers   int               `json:"num_hidden_layers"`
+	PatchSize         int               `json:"patch_size"`
+	Cybertron         struct {
+		Training bool `json:"training"`
+	}
+}
+
+// ConfigFromFile loads a ViT model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face ViT configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		HiddenAct:         "gelu",
+		HiddenSize:        768,
+		ImageSize:         224,
+		IntermediateSize:  3072,
+		LayerNormEps:      1e-12,
+		NumAttentionHeads: 12,
+		NumChannels:       3,
+		NumHiddenLayers:   12,
+		PatchSize:         16,
+	}
+}
+
+// NumPatches returns the number of patches in which the images are split.
+func (c Config) NumPatches() int {
+	n := c.ImageSize / c.PatchSize
+	return n * n
+}
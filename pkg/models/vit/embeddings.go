@@ -0,0 +1,71 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &Embeddings{}
+
+// Embeddings implements the patch embeddings of the Vision Transformer.
+type Embeddings struct {
+	nn.Module
+	// Patches is the projection of the flattened patches, equivalent to
+	// a convolution with kernel size and stride equal to the patch size.
+	Patches *linear.Model
+	// ClassToken is the embedding of the class token, prepended to the patches.
+	ClassToken *nn.Param
+	// Positions contains the learned position embeddings.
+	Positions *embedding.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Embeddings{})
+}
+
+// NewEmbeddings returns a new Embeddings.
+func NewEmbeddings[T float.DType](c Config) *Embeddings {
+	return &Embeddings{
+		Patches:    linear.New[T](c.NumChannels*c.PatchSize*c.PatchSize, c.HiddenSize),
+		ClassToken: nn.NewParam(mat.NewDense[T](mat.WithShape(c.HiddenSize))),
+		Positions:  embedding.New[T](c.NumPatches()+1, c.HiddenSize),
+		Config:     c,
+	}
+}
+
+// Encode returns the embeddings of the class token followed by the patches of the image.
+func (m *Embeddings) Encode(pixels imageprocessing.Pixels) ([]mat.Tensor, error) {
+	c := m.Config
+	if pixels.Channels != c.NumChannels || pixels.Height != c.ImageSize || pixels.Width != c.ImageSize {
+		return nil, fmt.Errorf("vit: unexpected image size %dx%dx%d, expected %dx%dx%d",
+			pixels.Channels, pixels.Height, pixels.Width, c.NumChannels, c.ImageSize, c.ImageSize)
+	}
+	w := m.Patches.W.Value().(mat.Matrix)
+	patches := pixels.Patches(c.PatchSize)
+	xs := make([]mat.Tensor, 0, len(patches)+1)
+	xs = append(xs, m.ClassToken)
+	for _, p := range patches {
+		xs = append(xs, w.NewMatrix(mat.WithBacking(p)))
+	}
+	xs = append(xs[:1], m.Patches.Forward(xs[1:]...)...)
+
+	positions := make([]int, len(xs))
+	for i := range positions {
+		positions[i] = i
+	}
+	return ag.Map2(ag.Add, xs, m.Positions.MustEncode(positions)), nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/attention/multiheadattention"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &EncoderLayer{}
+
+// EncoderLayer implements a ViT transformer layer, with the layer normalization
+// applied before the self-attention and the feed-forward layers.
+type EncoderLayer struct {
+	nn.Module
+	// AttentionNorm is the normalization applied before the self-attention.
+	AttentionNorm *layernorm.Model
+	// Attention is the self-attention module.
+	Attention *multiheadattention.Model
+	// FFNorm is the normalization applied before the feed-forward.
+	FFNorm *layernorm.Model
+	// FF is the feed-forward module.
+	FF nn.ModuleList[nn.StandardModel]
+}
+
+func init() {
+	gob.Register(&EncoderLayer{})
+}
+
+// NewEncoderLayer returns a new EncoderLayer.
+func NewEncoderLayer[T float.DType](c Config) *EncoderLayer {
+	return &EncoderLayer{
+		AttentionNorm: layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Attention:     multiheadattention.New[T](c.HiddenSize, c.NumAttentionHeads, false, false),
+		FFNorm:        layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		FF: []nn.StandardModel{
+			linear.New[T](c.HiddenSize, c.IntermediateSize),
+			activation.New(activation.MustParseActivation(c.HiddenAct)),
+			linear.New[T](c.IntermediateSize, c.HiddenSize),
+		},
+	}
+}
+
+// Forward performs the forward step of the layer.
+func (m *EncoderLayer) Forward(xs []mat.Tensor) []mat.Tensor {
+	norm := m.AttentionNorm.Forward(xs...)
+	att, _, _ := m.Attention.Forward(nil, norm, norm)
+	hs := ag.Map2(ag.Add, xs, att)
+	return ag.Map2(ag.Add, hs, m.FF.Forward(m.FFNorm.Forward(hs...)...))
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package vit implements the Vision Transformer (ViT) introduced by Dosovitskiy et al., 2020.
+// "An Image is Worth 16x16 Words: Transformers for Image Recognition at Scale"
+// https://arxiv.org/abs/2010.11929
+package vit
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base ViT encoder without any head on top.
+type Model struct {
+	nn.Module
+	// Embeddings contains the patch embeddings.
+	Embeddings *Embeddings
+	// Layers is the list of transformer layers.
+	Layers []*EncoderLayer
+	// LayerNorm is the final layer normalization.
+	LayerNorm *layernorm.Model
+	// Config is the model configuration.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new ViT model.
+func New[T float.DType](c Config) *Model {
+	layers := make([]*EncoderLayer, c.NumHiddenLayers)
+	for i := range layers {
+		layers[i] = NewEncoderLayer[T](c)
+	}
+	return &Model{
+		Embeddings: NewEmbeddings[T](c),
+		Layers:     layers,
+		LayerNorm:  layernorm.New[T](c.HiddenSize, c.LayerNormEps),
+		Config:     c,
+	}
+}
+
+// Encode returns the hidden states of the class token, at index 0,
+// followed by those of the patches of the image.
+func (m *Model) Encode(pixels imageprocessing.Pixels) ([]mat.Tensor, error) {
+	ys, err := m.Embeddings.Encode(pixels)
+	if err != nil {
+		return nil, err
+	}
+	for _, layer := range m.Layers {
+		ys = layer.Forward(ys)
+	}
+	return m.LayerNorm.Forward(ys...), nil
+}
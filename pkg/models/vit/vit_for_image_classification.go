@@ -0,0 +1,47 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForImageClassification{}
+
+// ModelForImageClassification implements a ViT model for image classification.
+type ModelForImageClassification struct {
+	nn.Module
+	// ViT is the fine-tuned ViT model.
+	ViT *Model
+	// Classifier is the linear layer applied to the hidden state of the class token.
+	Classifier *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForImageClassification{})
+}
+
+// NewModelForImageClassification returns a new model for image classification.
+func NewModelForImageClassification[T float.DType](vit *Model) *ModelForImageClassification {
+	return &ModelForImageClassification{
+		ViT:        vit,
+		Classifier: linear.New[T](vit.Config.HiddenSize, len(vit.Config.ID2Label)),
+	}
+}
+
+// Classify returns the logits for the image classification.
+func (m *ModelForImageClassification) Classify(pixels imageprocessing.Pixels) (mat.Tensor, error) {
+	encoded, err := m.ViT.Encode(pixels)
+	if err != nil {
+		return nil, err
+	}
+	return m.Classifier.Forward(encoded[0])[0], nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whisper
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+)
+
+// Config contains the global configuration of the Whisper model.
+// The configuration coincides with that of Hugging Face to facilitate compatibility between the two architectures.
+type Config struct {
+	ActivationFunction    string   `json:"activation_function"`
+	Architectures         []string `json:"architectures"`
+	BeginSuppressTokens   []int    `json:"begin_suppress_tokens"`
+	BosTokenID            int      `json:"bos_token_id"`
+	DModel                int      `json:"d_model"`
+	DecoderAttentionHeads int      `json:"decoder_attention_heads"`
+	DecoderFFNDim         int      `json:"decoder_ffn_dim"`
+	DecoderLayers         int      `json:"decoder_layers"`
+	DecoderStartTokenID   int      `json:"decoder_start_token_id"`
+	EncoderAttentionHeads int      `json:"encoder_attention_heads"`
+	EncoderFFNDim         int      `json:"encoder_ffn_dim"`
+	EncoderLayers         int      `json:"encoder_layers"`
+	EosTokenID            int      `json:"eos_token_id"`
+	MaxLength             int      `json:"max_length"`
+	MaxSourcePositions    int      `json:"max_source_positions"`
+	MaxTargetPositions    int      `json:"max_target_positions"`
+	ModelType             string   `json:"model_type"`
+	NumMelBins            int      `json:"num_mel_bins"`
+	PadTokenID            int      `json:"pad_token_id"`
+	ScaleEmbedding        bool     `json:"scale_embedding"`
+	SuppressTokens        []int    `json:"suppress_tokens"`
+	VocabSize             int      `json:"vocab_size"`
+	Cybertron             struct {
+		Training bool `json:"training,omitempty"`
+	}
+}
+
+// ConfigFromFile loads a Whisper model Config from file.
+func ConfigFromFile(file string) (Config, error) {
+	config := baseConfig()
+	configFile, err := os.Open(file)
+	if err != nil {
+		return Config{}, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	if err != nil {
+		return Config{}, err
+	}
+	if config.MaxLength == 0 {
+		config.MaxLength = config.MaxTargetPositions
+	}
+	return config, nil
+}
+
+// baseConfig returns the default values of the Hugging Face Whisper configuration,
+// used for the keys missing from the JSON file.
+func baseConfig() Config {
+	return Config{
+		ActivationFunction:    "gelu",
+		BosTokenID:            50257,
+		DModel:                384,
+		DecoderAttentionHeads: 6,
+		DecoderFFNDim:         1536,
+		DecoderLayers:         4,
+		DecoderStartTokenID:   50258,
+		EncoderAttentionHeads: 6,
+		EncoderFFNDim:         1536,
+		EncoderLayers:         4,
+		EosTokenID:            50257,
+		MaxSourcePositions:    1500,
+		MaxTargetPositions:    448,
+		NumMelBins:            80,
+		PadTokenID:            50257,
+		VocabSize:             51865,
+	}
+}
+
+// BartConfig returns the configuration of the Bart layers used by Whisper,
+// which is a pre-norm model with learned decoder positions.
+func (c Config) BartConfig() bart.Config {
+	bc := bart.Config{
+		ActivationFunction:    c.ActivationFunction,
+		FinalLayerNorm:        true,
+		BosTokenID:            c.BosTokenID,
+		DModel:                c.DModel,
+		DecoderAttentionHeads: c.DecoderAttentionHeads,
+		DecoderFFNDim:         c.DecoderFFNDim,
+		DecoderLayers:         c.DecoderLayers,
+		DecoderStartTokenID:   c.DecoderStartTokenID,
+		EncoderAttentionHeads: c.EncoderAttentionHeads,
+		EncoderFFNDim:         c.EncoderFFNDim,
+		EncoderLayers:         c.EncoderLayers,
+		EosTokenID:            c.EosTokenID,
+		IsEncoderDecoder:      true,
+		MaxPositionEmbeddings: c.MaxTargetPositions,
+		MaxLength:             c.MaxLength,
+		ModelType:             c.ModelType,
+		NormalizeBefore:       true,
+		NormalizeEmbedding:    false,
+		PadTokenID:            c.PadTokenID,
+		ScaleEmbedding:        c.ScaleEmbedding,
+		VocabSize:             c.VocabSize,
+	}
+	bc.Cybertron.Training = c.Cybertron.Training
+	return bc
+}
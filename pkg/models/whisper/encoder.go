@@ -0,0 +1,115 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whisper
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/activation"
+	"github.com/nlpodyssey/spago/nn/embedding"
+	"github.com/nlpodyssey/spago/nn/linear"
+	"github.com/nlpodyssey/spago/nn/normalization/layernorm"
+)
+
+// convKernelSize is the kernel size of the convolutions applied to the log-mel spectrogram.
+const convKernelSize = 3
+
+var _ nn.Model = &Encoder{}
+
+// Encoder implements the Whisper audio encoder.
+type Encoder struct {
+	nn.Module
+	// Conv1 is the first convolution over the frames of the log-mel spectrogram,
+	// applied as a projection of the concatenated frames of each window.
+	Conv1 *linear.Model
+	// Conv2 is the second convolution, which halves the number of frames.
+	Conv2 *linear.Model
+	// Positions contains the sinusoidal position embeddings.
+	Positions *embedding.Model
+	// Layers is the list of encoder layers.
+	Layers nn.ModuleList[*bart.EncoderLayer]
+	// LayerNorm is the layer normalization module.
+	LayerNorm *layernorm.Model
+	// Config is the configuration of the model.
+	Config Config
+}
+
+func init() {
+	gob.Register(&Encoder{})
+}
+
+// NewEncoder returns a new Encoder.
+func NewEncoder[T float.DType](c Config) *Encoder {
+	bc := c.BartConfig()
+	layers := make([]*bart.EncoderLayer, c.EncoderLayers)
+	for i := range layers {
+		layers[i] = bart.NewEncoderLayer[T](bc)
+	}
+	return &Encoder{
+		Conv1:     linear.New[T](c.NumMelBins*convKernelSize, c.DModel),
+		Conv2:     linear.New[T](c.DModel*convKernelSize, c.DModel),
+		Positions: embedding.New[T](c.MaxSourcePositions, c.DModel),
+		Layers:    layers,
+		LayerNorm: layernorm.New[T](c.DModel, 1e-5),
+		Config:    c,
+	}
+}
+
+// Encode returns the hidden states of the given log-mel spectrogram, which
+// contains the values of the mel filters for each frame.
+func (m *Encoder) Encode(features [][]float64) ([]mat.Tensor, error) {
+	c := m.Config
+	if expected := 2 * c.MaxSourcePositions; len(features) != expected {
+		return nil, fmt.Errorf("whisper: unexpected number of frames %d, expected %d", len(features), expected)
+	}
+	w := m.Conv1.W.Value().(mat.Matrix)
+	xs := make([]mat.Tensor, len(features))
+	for i, frame := range features {
+		if len(frame) != c.NumMelBins {
+			return nil, fmt.Errorf("whisper: unexpected number of mel bins %d, expected %d", len(frame), c.NumMelBins)
+		}
+		xs[i] = w.NewMatrix(mat.WithBacking(frame))
+	}
+
+	act := activation.MustParseActivation(c.ActivationFunction)
+	ys := activation.New(act).Forward(convolve(m.Conv1, xs, 1)...)
+	ys = activation.New(act).Forward(convolve(m.Conv2, ys, 2)...)
+
+	positions := make([]int, len(ys))
+	for i := range positions {
+		positions[i] = i
+	}
+	ys = ag.Map2(ag.Add, ys, m.Positions.MustEncode(positions))
+	ys = m.Layers.Forward(ys...)
+	return m.LayerNorm.Forward(ys...), nil
+}
+
+// convolve applies a one-dimensional convolution over the sequence, with the
+// given stride and zero padding on both sides. The projection weights are
+// expected in the order [output channel, kernel position, input channel].
+func convolve(m *linear.Model, xs []mat.Tensor, stride int) []mat.Tensor {
+	pad := convKernelSize / 2
+	zeros := xs[0].Value().(mat.Matrix).NewMatrix(mat.WithShape(xs[0].Size()))
+	windows := make([]mat.Tensor, (len(xs)+2*pad-convKernelSize)/stride+1)
+	for i := range windows {
+		window := make([]mat.Tensor, convKernelSize)
+		for k := range window {
+			j := i*stride + k - pad
+			if j < 0 || j >= len(xs) {
+				window[k] = zeros
+			} else {
+				window[k] = xs[j]
+			}
+		}
+		windows[i] = ag.Concat(window...)
+	}
+	return m.Forward(windows...)
+}
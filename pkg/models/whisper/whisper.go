@@ -0,0 +1,54 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package whisper implements the speech recognition model introduced by Radford et al., 2022.
+// "Robust Speech Recognition via Large-Scale Weak Supervision"
+// https://arxiv.org/abs/2212.04356
+package whisper
+
+import (
+	"encoding/gob"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var _ nn.Model = &Model{}
+
+// Model implements a base Whisper encoder-decoder model without any head on top.
+// The decoder is a pre-norm Bart decoder.
+type Model struct {
+	nn.Module
+	// Config is the model configuration.
+	Config Config
+	// Encoder is the audio encoder.
+	Encoder *Encoder
+	// Decoder is the text decoder.
+	Decoder *bart.Decoder
+	// Embeddings contains the token embeddings of the decoder.
+	Embeddings *embedding.Model
+}
+
+func init() {
+	gob.Register(&Model{})
+}
+
+// New returns a new Whisper model.
+func New[T float.DType](c Config) *Model {
+	emb := embedding.New[T](c.VocabSize, c.DModel)
+	return &Model{
+		Encoder:    NewEncoder[T](c),
+		Decoder:    bart.NewDecoder[T](c.BartConfig(), embedding.Shared{Model: emb}),
+		Embeddings: emb,
+		Config:     c,
+	}
+}
+
+// ShareEmbeddings restores the sharing of the token embeddings with the
+// decoder, which is lost when the model is deserialized.
+func (m *Model) ShareEmbeddings() {
+	m.Decoder.Embeddings.SharedEmbeddings = embedding.Shared{Model: m.Embeddings}
+}
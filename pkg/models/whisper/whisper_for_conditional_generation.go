@@ -0,0 +1,90 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whisper
+
+import (
+	"encoding/gob"
+	"sync"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/linear"
+)
+
+var _ nn.Model = &ModelForConditionalGeneration{}
+
+// ModelForConditionalGeneration is a Whisper model with a language modeling
+// head, which generates the transcription of the audio.
+type ModelForConditionalGeneration struct {
+	nn.Module
+	// Whisper is the Whisper model.
+	Whisper *Model
+	// Projection is the projection layer from the decoder output to the
+	// vocabulary, whose weights are tied to the token embeddings.
+	Projection *linear.Model
+}
+
+func init() {
+	gob.Register(&ModelForConditionalGeneration{})
+}
+
+// NewModelForConditionalGeneration returns a new model for conditional generation.
+func NewModelForConditionalGeneration[T float.DType](whisper *Model) *ModelForConditionalGeneration {
+	return &ModelForConditionalGeneration{
+		Whisper:    whisper,
+		Projection: linear.New[T](whisper.Config.DModel, whisper.Config.VocabSize),
+	}
+}
+
+// DecodingInput is the input for the decoding function of the model for conditional generation.
+type DecodingInput struct {
+	// InputIDs are the input IDs for the decoder.
+	InputIDs []int
+	// CurLen is the current length of the generating sequence.
+	CurLen int
+	// Cache is the cache for the decoder.
+	Cache bart.Cache
+}
+
+// DecodingOutput is the output of the decoding function of the model for conditional generation.
+type DecodingOutput struct {
+	// LogProbs contains the log probabilities of the next token.
+	LogProbs mat.Matrix
+	// NextCache is the next cache.
+	NextCache bart.Cache
+}
+
+// DecodingFunc returns a decoding function that works using the given encoder states.
+func (m *ModelForConditionalGeneration) DecodingFunc(encoderStates []mat.Tensor) func(batch []*DecodingInput) []*DecodingOutput {
+	return func(batch []*DecodingInput) []*DecodingOutput {
+		result := make([]*DecodingOutput, len(batch))
+
+		var wg sync.WaitGroup
+		wg.Add(len(batch))
+
+		for i, item := range batch {
+			i, item := i, item
+			go func() {
+				defer wg.Done()
+				result[i] = m.next(encoderStates, item)
+			}()
+		}
+		wg.Wait()
+		return result
+	}
+}
+
+// next returns the log probabilities of the next token.
+func (m *ModelForConditionalGeneration) next(encoderStates []mat.Tensor, input *DecodingInput) *DecodingOutput {
+	decoded, nextCache := m.Whisper.Decoder.Decode(encoderStates, input.InputIDs, input.Cache, input.CurLen)
+	logits := m.Projection.Forward(decoded[len(decoded)-1])[0]
+	return &DecodingOutput{
+		LogProbs:  ag.LogSoftmax(logits).Value().(mat.Matrix),
+		NextCache: nextCache,
+	}
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: multiplechoice/v1/multiplechoice.proto
+
+package multiplechoicev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MultipleChoiceService_Choose_FullMethodName = "/multiplechoice.v1.MultipleChoiceService/Choose"
+)
+
+// MultipleChoiceServiceClient is the client API for MultipleChoiceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MultipleChoiceServiceClient interface {
+	Choose(ctx context.Context, in *ChooseRequest, opts ...grpc.CallOption) (*ChooseResponse, error)
+}
+
+type multipleChoiceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMultipleChoiceServiceClient(cc grpc.ClientConnInterface) MultipleChoiceServiceClient {
+	return &multipleChoiceServiceClient{cc}
+}
+
+func (c *multipleChoiceServiceClient) Choose(ctx context.Context, in *ChooseRequest, opts ...grpc.CallOption) (*ChooseResponse, error) {
+	out := new(ChooseResponse)
+	err := c.cc.Invoke(ctx, MultipleChoiceService_Choose_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MultipleChoiceServiceServer is the server API for MultipleChoiceService service.
+// All implementations must embed UnimplementedMultipleChoiceServiceServer
+// for forward compatibility
+type MultipleChoiceServiceServer interface {
+	Choose(context.Context, *ChooseRequest) (*ChooseResponse, error)
+	mustEmbedUnimplementedMultipleChoiceServiceServer()
+}
+
+// UnimplementedMultipleChoiceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMultipleChoiceServiceServer struct {
+}
+
+func (UnimplementedMultipleChoiceServiceServer) Choose(context.Context, *ChooseRequest) (*ChooseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Choose not implemented")
+}
+func (UnimplementedMultipleChoiceServiceServer) mustEmbedUnimplementedMultipleChoiceServiceServer() {}
+
+// UnsafeMultipleChoiceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MultipleChoiceServiceServer will
+// result in compilation errors.
+type UnsafeMultipleChoiceServiceServer interface {
+	mustEmbedUnimplementedMultipleChoiceServiceServer()
+}
+
+func RegisterMultipleChoiceServiceServer(s grpc.ServiceRegistrar, srv MultipleChoiceServiceServer) {
+	s.RegisterService(&MultipleChoiceService_ServiceDesc, srv)
+}
+
+func _MultipleChoiceService_Choose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChooseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MultipleChoiceServiceServer).Choose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MultipleChoiceService_Choose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MultipleChoiceServiceServer).Choose(ctx, req.(*ChooseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MultipleChoiceService_ServiceDesc is the grpc.ServiceDesc for MultipleChoiceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MultipleChoiceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "multiplechoice.v1.MultipleChoiceService",
+	HandlerType: (*MultipleChoiceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Choose",
+			Handler:    _MultipleChoiceService_Choose_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "multiplechoice/v1/multiplechoice.proto",
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: reranking/v1/reranking.proto
+
+package rerankingv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RerankingService_Rerank_FullMethodName = "/reranking.v1.RerankingService/Rerank"
+)
+
+// RerankingServiceClient is the client API for RerankingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RerankingServiceClient interface {
+	Rerank(ctx context.Context, in *RerankRequest, opts ...grpc.CallOption) (*RerankResponse, error)
+}
+
+type rerankingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRerankingServiceClient(cc grpc.ClientConnInterface) RerankingServiceClient {
+	return &rerankingServiceClient{cc}
+}
+
+func (c *rerankingServiceClient) Rerank(ctx context.Context, in *RerankRequest, opts ...grpc.CallOption) (*RerankResponse, error) {
+	out := new(RerankResponse)
+	err := c.cc.Invoke(ctx, RerankingService_Rerank_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RerankingServiceServer is the server API for RerankingService service.
+// All implementations must embed UnimplementedRerankingServiceServer
+// for forward compatibility
+type RerankingServiceServer interface {
+	Rerank(context.Context, *RerankRequest) (*RerankResponse, error)
+	mustEmbedUnimplementedRerankingServiceServer()
+}
+
+// UnimplementedRerankingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRerankingServiceServer struct {
+}
+
+func (UnimplementedRerankingServiceServer) Rerank(context.Context, *RerankRequest) (*RerankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rerank not implemented")
+}
+func (UnimplementedRerankingServiceServer) mustEmbedUnimplementedRerankingServiceServer() {}
+
+// UnsafeRerankingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RerankingServiceServer will
+// result in compilation errors.
+type UnsafeRerankingServiceServer interface {
+	mustEmbedUnimplementedRerankingServiceServer()
+}
+
+func RegisterRerankingServiceServer(s grpc.ServiceRegistrar, srv RerankingServiceServer) {
+	s.RegisterService(&RerankingService_ServiceDesc, srv)
+}
+
+func _RerankingService_Rerank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RerankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RerankingServiceServer).Rerank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RerankingService_Rerank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RerankingServiceServer).Rerank(ctx, req.(*RerankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RerankingService_ServiceDesc is the grpc.ServiceDesc for RerankingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RerankingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reranking.v1.RerankingService",
+	HandlerType: (*RerankingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Rerank",
+			Handler:    _RerankingService_Rerank_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reranking/v1/reranking.proto",
+}
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	TextGenerationService_Generate_FullMethodName = "/textgeneration.v1.TextGenerationService/Generate"
+	TextGenerationService_Generate_FullMethodName       = "/textgeneration.v1.TextGenerationService/Generate"
+	TextGenerationService_GenerateStream_FullMethodName = "/textgeneration.v1.TextGenerationService/GenerateStream"
 )
 
 // TextGenerationServiceClient is the client API for TextGenerationService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TextGenerationServiceClient interface {
 	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	GenerateStream(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (TextGenerationService_GenerateStreamClient, error)
 }
 
 type textGenerationServiceClient struct {
@@ -46,11 +48,44 @@ func (c *textGenerationServiceClient) Generate(ctx context.Context, in *Generate
 	return out, nil
 }
 
+func (c *textGenerationServiceClient) GenerateStream(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (TextGenerationService_GenerateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TextGenerationService_ServiceDesc.Streams[0], TextGenerationService_GenerateStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &textGenerationServiceGenerateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TextGenerationService_GenerateStreamClient interface {
+	Recv() (*GenerateStreamResponse, error)
+	grpc.ClientStream
+}
+
+type textGenerationServiceGenerateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *textGenerationServiceGenerateStreamClient) Recv() (*GenerateStreamResponse, error) {
+	m := new(GenerateStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TextGenerationServiceServer is the server API for TextGenerationService service.
 // All implementations must embed UnimplementedTextGenerationServiceServer
 // for forward compatibility
 type TextGenerationServiceServer interface {
 	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	GenerateStream(*GenerateRequest, TextGenerationService_GenerateStreamServer) error
 	mustEmbedUnimplementedTextGenerationServiceServer()
 }
 
@@ -61,6 +96,9 @@ type UnimplementedTextGenerationServiceServer struct {
 func (UnimplementedTextGenerationServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
 }
+func (UnimplementedTextGenerationServiceServer) GenerateStream(*GenerateRequest, TextGenerationService_GenerateStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateStream not implemented")
+}
 func (UnimplementedTextGenerationServiceServer) mustEmbedUnimplementedTextGenerationServiceServer() {}
 
 // UnsafeTextGenerationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +130,27 @@ func _TextGenerationService_Generate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TextGenerationService_GenerateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TextGenerationServiceServer).GenerateStream(m, &textGenerationServiceGenerateStreamServer{stream})
+}
+
+type TextGenerationService_GenerateStreamServer interface {
+	Send(*GenerateStreamResponse) error
+	grpc.ServerStream
+}
+
+type textGenerationServiceGenerateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *textGenerationServiceGenerateStreamServer) Send(m *GenerateStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // TextGenerationService_ServiceDesc is the grpc.ServiceDesc for TextGenerationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,6 +163,12 @@ var TextGenerationService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _TextGenerationService_Generate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateStream",
+			Handler:       _TextGenerationService_GenerateStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "textgeneration/v1/texgeneration.proto",
 }
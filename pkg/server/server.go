@@ -16,7 +16,9 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/reranking"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textencoding"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
@@ -79,6 +81,10 @@ func ResolveRequestHandler(model any) (RequestHandler, error) {
 		return NewServerForTokenClassification(m), nil
 	case languagemodeling.Interface:
 		return NewServerForLanguageModeling(m), nil
+	case reranking.Interface:
+		return NewServerForReranking(m), nil
+	case multiplechoice.Interface:
+		return NewServerForMultipleChoice(m), nil
 	default:
 		return nil, fmt.Errorf("failed to resolve register funcs for model/task type %T", m)
 	}
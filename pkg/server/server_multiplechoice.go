@@ -0,0 +1,46 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	multiplechoicev1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/multiplechoice/v1"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice"
+	"google.golang.org/grpc"
+)
+
+// serverForMultipleChoice is a server that provides gRPC and HTTP/2 APIs for Multiple Choice task.
+type serverForMultipleChoice struct {
+	multiplechoicev1.UnimplementedMultipleChoiceServiceServer
+	model multiplechoice.Interface
+}
+
+func NewServerForMultipleChoice(model multiplechoice.Interface) RequestHandler {
+	return &serverForMultipleChoice{model: model}
+}
+
+func (s *serverForMultipleChoice) RegisterServer(r grpc.ServiceRegistrar) error {
+	multiplechoicev1.RegisterMultipleChoiceServiceServer(r, s)
+	return nil
+}
+
+func (s *serverForMultipleChoice) RegisterHandlerServer(ctx context.Context, mux *runtime.ServeMux) error {
+	return multiplechoicev1.RegisterMultipleChoiceServiceHandlerServer(ctx, mux, s)
+}
+
+// Choose handles the Choose request.
+func (s *serverForMultipleChoice) Choose(ctx context.Context, req *multiplechoicev1.ChooseRequest) (*multiplechoicev1.ChooseResponse, error) {
+	result, err := s.model.Choose(ctx, req.GetInput(), req.GetChoices())
+	if err != nil {
+		return nil, err
+	}
+	resp := &multiplechoicev1.ChooseResponse{
+		Scores: result.Scores,
+		Best:   int32(result.Best),
+	}
+	return resp, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	rerankingv1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/reranking/v1"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/reranking"
+	"google.golang.org/grpc"
+)
+
+// serverForReranking is a server that provides gRPC and HTTP/2 APIs for Reranking task.
+type serverForReranking struct {
+	rerankingv1.UnimplementedRerankingServiceServer
+	reranker reranking.Interface
+}
+
+func NewServerForReranking(reranker reranking.Interface) RequestHandler {
+	return &serverForReranking{reranker: reranker}
+}
+
+func (s *serverForReranking) RegisterServer(r grpc.ServiceRegistrar) error {
+	rerankingv1.RegisterRerankingServiceServer(r, s)
+	return nil
+}
+
+func (s *serverForReranking) RegisterHandlerServer(ctx context.Context, mux *runtime.ServeMux) error {
+	return rerankingv1.RegisterRerankingServiceHandlerServer(ctx, mux, s)
+}
+
+// Rerank handles the Rerank request.
+func (s *serverForReranking) Rerank(ctx context.Context, req *rerankingv1.RerankRequest) (*rerankingv1.RerankResponse, error) {
+	result, err := s.reranker.Rerank(ctx, req.GetQuery(), req.GetDocuments(), int(req.GetTopK()))
+	if err != nil {
+		return nil, err
+	}
+	resp := &rerankingv1.RerankResponse{
+		Documents: result.Documents,
+		Indices:   make([]int32, len(result.Indices)),
+		Scores:    result.Scores,
+	}
+	for i, index := range result.Indices {
+		resp.Indices[i] = int32(index)
+	}
+	return resp, nil
+}
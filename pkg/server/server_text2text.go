@@ -6,14 +6,26 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	textgenerationv1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/textgeneration/v1"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
 	"github.com/nlpodyssey/cybertron/pkg/utils/nullable"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// generateStreamPath is the HTTP path of the GenerateStream method, which
+// sends the generated tokens as Server-Sent Events.
+const generateStreamPath = "/v1/generate/stream"
+
 // serverForTextGeneration is a server that provides gRPC and HTTP/2 APIs for Interface task.
 type serverForTextGeneration struct {
 	textgenerationv1.UnimplementedTextGenerationServiceServer
@@ -29,24 +41,23 @@ func (s *serverForTextGeneration) RegisterServer(r grpc.ServiceRegistrar) error
 	return nil
 }
 
+// RegisterHandlerServer registers the HTTP handlers. The gateway does not
+// support streaming calls in-process, so GenerateStream is served with
+// Server-Sent Events by a dedicated handler.
 func (s *serverForTextGeneration) RegisterHandlerServer(ctx context.Context, mux *runtime.ServeMux) error {
-	return textgenerationv1.RegisterTextGenerationServiceHandlerServer(ctx, mux, s)
+	if err := textgenerationv1.RegisterTextGenerationServiceHandlerServer(ctx, mux, s); err != nil {
+		return err
+	}
+	return mux.HandlePath(http.MethodPost, generateStreamPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		s.serveGenerateStreamEvents(mux, w, r)
+	})
 }
 
 // Generate handles the Generate request.
 func (s *serverForTextGeneration) Generate(ctx context.Context, req *textgenerationv1.GenerateRequest) (*textgenerationv1.GenerateResponse, error) {
-	opts := req.GetParameters()
-	if opts == nil {
-		opts = &textgenerationv1.TextGenerationParameters{}
-	}
-	result, err := s.generator.Generate(ctx, req.GetInput(), &textgeneration.Options{
-		Temperature: nullable.Any(opts.Temperature),
-		Sample:      nullable.Any(opts.DoSample),
-		TopK:        nullable.Int(opts.TopK),
-		TopP:        nullable.Any(opts.TopP),
-	})
+	result, err := s.generator.Generate(ctx, req.GetInput(), generateOptions(req.GetParameters()))
 	if err != nil {
-		return nil, err
+		return nil, generateError(err)
 	}
 	resp := &textgenerationv1.GenerateResponse{
 		Texts:  result.Texts,
@@ -54,3 +65,151 @@ func (s *serverForTextGeneration) Generate(ctx context.Context, req *textgenerat
 	}
 	return resp, nil
 }
+
+// GenerateStream handles the GenerateStream request, sending each generated
+// token as soon as it is produced, followed by the final result.
+func (s *serverForTextGeneration) GenerateStream(req *textgenerationv1.GenerateRequest, stream textgenerationv1.TextGenerationService_GenerateStreamServer) error {
+	return s.generateStream(stream.Context(), req, stream.Send)
+}
+
+func (s *serverForTextGeneration) generateStream(ctx context.Context, req *textgenerationv1.GenerateRequest, send func(*textgenerationv1.GenerateStreamResponse) error) error {
+	generator, ok := s.generator.(textgeneration.StreamInterface)
+	if !ok {
+		return status.Error(codes.Unimplemented, "the model doesn't support streaming text generation")
+	}
+	result, err := generator.GenerateStream(ctx, req.GetInput(), generateOptions(req.GetParameters()), func(token textgeneration.Token) error {
+		return send(&textgenerationv1.GenerateStreamResponse{
+			Event: &textgenerationv1.GenerateStreamResponse_Token{
+				Token: &textgenerationv1.GeneratedToken{
+					Id:   int64(token.ID),
+					Text: token.Text,
+				},
+			},
+		})
+	})
+	if err != nil {
+		return generateError(err)
+	}
+	return send(&textgenerationv1.GenerateStreamResponse{
+		Event: &textgenerationv1.GenerateStreamResponse_Result{
+			Result: &textgenerationv1.GenerateResponse{
+				Texts:  result.Texts,
+				Scores: result.Scores,
+			},
+		},
+	})
+}
+
+// serveGenerateStreamEvents serves the GenerateStream request over HTTP,
+// sending a "token" event for each generated token and a final "result"
+// event. If the generation fails, an "error" event carries the status.
+func (s *serverForTextGeneration) serveGenerateStreamEvents(mux *runtime.ServeMux, w http.ResponseWriter, r *http.Request) {
+	inbound, outbound := runtime.MarshalerForRequest(mux, r)
+
+	req := new(textgenerationv1.GenerateRequest)
+	if err := inbound.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		runtime.HTTPError(r.Context(), mux, outbound, w, r, status.Errorf(codes.InvalidArgument, "%v", err))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		runtime.HTTPError(r.Context(), mux, outbound, w, r, status.Error(codes.Internal, "streaming unsupported by the connection"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	writeEvent := func(event string, msg proto.Message) error {
+		data, err := outbound.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	err := s.generateStream(r.Context(), req, func(resp *textgenerationv1.GenerateStreamResponse) error {
+		if resp.GetToken() != nil {
+			return writeEvent("token", resp)
+		}
+		return writeEvent("result", resp)
+	})
+	if err != nil {
+		if e := writeEvent("error", status.Convert(err).Proto()); e != nil {
+			log.Err(e).Msg("failed to send the error event")
+		}
+	}
+}
+
+// generateError returns the error of the text generation as a gRPC status
+// error, reporting the invalid options as an invalid argument.
+func generateError(err error) error {
+	if errors.Is(err, textgeneration.ErrInvalidOptions) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// generateOptions returns the text generation options for the request parameters.
+func generateOptions(params *textgenerationv1.TextGenerationParameters) *textgeneration.Options {
+	if params == nil {
+		params = &textgenerationv1.TextGenerationParameters{}
+	}
+	return &textgeneration.Options{
+		Temperature:        nullable.Any(params.Temperature),
+		Sample:             nullable.Any(params.DoSample),
+		TopK:               nullable.Int(params.TopK),
+		TopP:               nullable.Any(params.TopP),
+		SourceLanguage:     params.GetSourceLanguage(),
+		TargetLanguage:     params.GetTargetLanguage(),
+		NumBeams:           nullable.Int(params.NumBeams),
+		MinLength:          nullable.Int(params.MinLength),
+		MaxLength:          nullable.Int(params.MaxLength),
+		MaxNewTokens:       nullable.Int(params.MaxNewTokens),
+		LengthPenalty:      nullable.Any(params.LengthPenalty),
+		NoRepeatNGramSize:  nullable.Int(params.NoRepeatNgramSize),
+		EarlyStopping:      nullable.Any(params.EarlyStopping),
+		BadWordsIDs:        badWordsIDs(params.GetBadWordsIds()),
+		NumReturnSequences: nullable.Int(params.NumReturnSequences),
+		RepetitionPenalty:  nullable.Any(params.RepetitionPenalty),
+		PresencePenalty:    nullable.Any(params.PresencePenalty),
+		FrequencyPenalty:   nullable.Any(params.FrequencyPenalty),
+		LogitBias:          params.GetLogitBias(),
+		TypicalP:           nullable.Any(params.TypicalP),
+		MinP:               nullable.Any(params.MinP),
+		ForceWords:         forceWords(params.GetForceWords()),
+	}
+}
+
+// forceWords converts the alternative phrases of the request.
+func forceWords(constraints []*textgenerationv1.PhraseAlternatives) [][]string {
+	if len(constraints) == 0 {
+		return nil
+	}
+	result := make([][]string, len(constraints))
+	for i, c := range constraints {
+		result[i] = c.GetPhrases()
+	}
+	return result
+}
+
+// badWordsIDs converts the token ID sequences of the request.
+func badWordsIDs(sequences []*textgenerationv1.TokenIDs) [][]int {
+	if len(sequences) == 0 {
+		return nil
+	}
+	result := make([][]int, len(sequences))
+	for i, sequence := range sequences {
+		result[i] = make([]int, len(sequence.GetIds()))
+		for j, id := range sequence.GetIds() {
+			result[i][j] = int(id)
+		}
+	}
+	return result
+}
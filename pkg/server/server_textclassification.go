@@ -11,6 +11,8 @@ import (
 	textclassificationv1 "github.com/nlpodyssey/cybertron/pkg/server/gen/proto/go/textclassification/v1"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // serverForTextClassification is a server that provides gRPC and HTTP/2 APIs for Text Classification task.
@@ -33,8 +35,9 @@ func (s *serverForTextClassification) RegisterHandlerServer(ctx context.Context,
 }
 
 // Classify handles the Classify request.
+// When the request has a text pair, it performs the sentence-pair classification.
 func (s *serverForTextClassification) Classify(ctx context.Context, req *textclassificationv1.ClassifyRequest) (*textclassificationv1.ClassifyResponse, error) {
-	result, err := s.classifier.Classify(ctx, req.GetInput())
+	result, err := s.classify(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -44,3 +47,14 @@ func (s *serverForTextClassification) Classify(ctx context.Context, req *textcla
 	}
 	return resp, nil
 }
+
+func (s *serverForTextClassification) classify(ctx context.Context, req *textclassificationv1.ClassifyRequest) (textclassification.Response, error) {
+	if req.TextPair == nil {
+		return s.classifier.Classify(ctx, req.GetInput())
+	}
+	classifier, ok := s.classifier.(textclassification.PairInterface)
+	if !ok {
+		return textclassification.Response{}, status.Error(codes.Unimplemented, "the model doesn't support sentence-pair classification")
+	}
+	return classifier.ClassifyPair(ctx, req.GetInput(), req.GetTextPair())
+}
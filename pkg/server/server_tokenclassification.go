@@ -37,6 +37,7 @@ func (s *serverForTokenClassification) RegisterHandlerServer(ctx context.Context
 func (s *serverForTokenClassification) Classify(ctx context.Context, req *tokenclassificationv1.ClassifyRequest) (*tokenclassificationv1.ClassifyResponse, error) {
 	result, err := s.classifier.Classify(ctx, req.GetInput(), tokenclassification.Parameters{
 		AggregationStrategy: convAggregationStrategy(req.AggregationStrategy),
+		DecodingStrategy:    convDecodingStrategy(req.DecodingStrategy),
 	})
 	if err != nil {
 		return nil, err
@@ -68,3 +69,16 @@ func convAggregationStrategy(strategy tokenclassificationv1.ClassifyRequest_Aggr
 		panic(fmt.Sprintf("server: invalid aggregation strategy [%s] for token classification", strategy))
 	}
 }
+
+func convDecodingStrategy(strategy tokenclassificationv1.ClassifyRequest_DecodingStrategy) tokenclassification.DecodingStrategy {
+	switch strategy {
+	case tokenclassificationv1.ClassifyRequest_AUTO:
+		return ""
+	case tokenclassificationv1.ClassifyRequest_ARGMAX:
+		return tokenclassification.DecodingStrategyArgmax
+	case tokenclassificationv1.ClassifyRequest_VITERBI:
+		return tokenclassification.DecodingStrategyViterbi
+	default:
+		panic(fmt.Sprintf("server: invalid decoding strategy [%s] for token classification", strategy))
+	}
+}
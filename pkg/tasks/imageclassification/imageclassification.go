@@ -0,0 +1,31 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageclassification
+
+import (
+	"context"
+	"image"
+)
+
+const (
+	// DefaultModel is a ViT model fine-tuned on ImageNet-1k, predicting one of its 1000 classes.
+	// Model card: https://huggingface.co/google/vit-base-patch16-224
+	DefaultModel = "google/vit-base-patch16-224"
+)
+
+// Interface defines the main functions for image classification task.
+type Interface interface {
+	// Classify returns the classification of the given image.
+	Classify(ctx context.Context, img image.Image) (Response, error)
+}
+
+// Response contains the response from image classification.
+type Response struct {
+	// The list of labels, sorted in descending order
+	// by probability that the input corresponds to the label.
+	Labels []string
+	// a list of floats that correspond the probability of label, in the same order as labels.
+	Scores []float64
+}
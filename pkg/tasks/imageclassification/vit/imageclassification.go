@@ -0,0 +1,78 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vit
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"path"
+	"sort"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/cybertron/pkg/models/vit"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/imageclassification"
+	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ imageclassification.Interface = &ImageClassification{}
+
+// ImageClassification is an image classification model.
+type ImageClassification struct {
+	// Model is the model used for image classification.
+	Model *vit.ModelForImageClassification
+	// Processor converts the images into the model input.
+	Processor *imageprocessing.Processor
+	// Labels is the list of labels used for classification.
+	Labels []string
+}
+
+// LoadImageClassification returns an ImageClassification loading the model and the image processor from a directory.
+func LoadImageClassification(modelPath string) (*ImageClassification, error) {
+	// The ViT image processor resizes the images to a square, regardless of their aspect ratio.
+	processor, err := imageprocessing.NewFromModelFolder(modelPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image processor for image classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*vit.ModelForImageClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vit model: %w", err)
+	}
+
+	return &ImageClassification{
+		Model:     m,
+		Processor: processor,
+		Labels:    bert_for_text_classification.ID2Label(m.ViT.Config.ID2Label),
+	}, nil
+}
+
+// Classify returns the classification of the given image.
+func (m *ImageClassification) Classify(_ context.Context, img image.Image) (imageclassification.Response, error) {
+	pixels, err := m.Processor.Process(img)
+	if err != nil {
+		return imageclassification.Response{}, err
+	}
+	logits, err := m.Model.Classify(pixels)
+	if err != nil {
+		return imageclassification.Response{}, err
+	}
+
+	result := sliceutils.NewIndexedSlice[float64](logits.Value().(mat.Matrix).Softmax().Data().F64())
+	sort.Stable(sort.Reverse(result))
+
+	labels := make([]string, len(m.Labels))
+	for i, ii := range result.Indices {
+		labels[i] = m.Labels[ii]
+	}
+
+	return imageclassification.Response{
+		Labels: labels,
+		Scores: result.Slice,
+	}, nil
+}
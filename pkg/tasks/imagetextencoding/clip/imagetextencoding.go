@@ -0,0 +1,85 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/cybertron/pkg/models/clip"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/imagetextencoding"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/cliptokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ imagetextencoding.Interface = &ImageTextEncoding{}
+
+// ImageTextEncoding is a dual encoder of images and texts.
+type ImageTextEncoding struct {
+	// Model is the CLIP model.
+	Model *clip.Model
+	// Processor converts the images into the model input.
+	Processor *imageprocessing.Processor
+	// Tokenizer is the tokenizer of the texts.
+	Tokenizer *cliptokenizer.CLIPTokenizer
+}
+
+// LoadImageTextEncoding returns an ImageTextEncoding loading the model, the image processor
+// and the tokenizer from a directory.
+func LoadImageTextEncoding(modelPath string) (*ImageTextEncoding, error) {
+	processor, err := imageprocessing.NewFromModelFolder(modelPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image processor for image-text encoding: %w", err)
+	}
+
+	tokenizer, err := cliptokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for image-text encoding: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*clip.Model](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load clip model: %w", err)
+	}
+
+	return &ImageTextEncoding{
+		Model:     m,
+		Processor: processor,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// EncodeImage returns the dense encoded representation of the given image.
+func (m *ImageTextEncoding) EncodeImage(_ context.Context, img image.Image) (imagetextencoding.Response, error) {
+	pixels, err := m.Processor.Process(img)
+	if err != nil {
+		return imagetextencoding.Response{}, err
+	}
+	encoded, err := m.Model.EncodeImage(pixels)
+	if err != nil {
+		return imagetextencoding.Response{}, err
+	}
+	return imagetextencoding.Response{
+		Vector: encoded.Value().(mat.Matrix),
+	}, nil
+}
+
+// EncodeText returns the dense encoded representation of the given text.
+func (m *ImageTextEncoding) EncodeText(_ context.Context, text string) (imagetextencoding.Response, error) {
+	tokenized, err := m.Tokenizer.Encode(text)
+	if err != nil {
+		return imagetextencoding.Response{}, err
+	}
+	if l, k := len(tokenized), m.Model.Config.TextConfig.MaxPositionEmbeddings; l > k {
+		return imagetextencoding.Response{}, fmt.Errorf("%w: %d > %d", imagetextencoding.ErrInputSequenceTooLong, l, k)
+	}
+	return imagetextencoding.Response{
+		Vector: m.Model.EncodeText(tokenized).Value().(mat.Matrix),
+	}, nil
+}
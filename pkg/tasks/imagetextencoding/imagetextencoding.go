@@ -0,0 +1,38 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imagetextencoding
+
+import (
+	"context"
+	"errors"
+	"image"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+const (
+	// DefaultModel is a CLIP model that maps images and texts to a shared vector space,
+	// where the images are close to the texts describing them.
+	// Model card: https://huggingface.co/openai/clip-vit-base-patch32
+	DefaultModel = "openai/clip-vit-base-patch32"
+)
+
+// ErrInputSequenceTooLong means that pre-processing the input text
+// produced a sequence that exceeds the maximum allowed length.
+var ErrInputSequenceTooLong = errors.New("input sequence too long")
+
+// Interface defines the main functions for the encoding of images and texts in a shared vector space.
+type Interface interface {
+	// EncodeImage returns the encoded representation of the given image.
+	EncodeImage(ctx context.Context, img image.Image) (Response, error)
+	// EncodeText returns the encoded representation of the given text.
+	EncodeText(ctx context.Context, text string) (Response, error)
+}
+
+// Response contains the response from image or text encoding.
+type Response struct {
+	// the encoded representation, comparable with the cosine similarity
+	Vector mat.Matrix
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/intentslotfilling"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+	bert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ intentslotfilling.Interface = &IntentSlotFilling{}
+
+// IntentSlotFilling is a joint intent detection and slot filling model based on BERT.
+type IntentSlotFilling struct {
+	// Model is the model used for intent detection and slot filling.
+	Model *bert.ModelForIntentAndSlots
+	// Tokenizer is the tokenizer used to tokenize the utterances.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+	// IntentLabels is the list of labels used for intent classification.
+	IntentLabels []string
+	// SlotLabels is the list of labels used for slot classification.
+	SlotLabels []string
+	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
+	doLowerCase bool
+}
+
+// LoadIntentSlotFilling returns an IntentSlotFilling loading the model and the tokenizer from a directory.
+// The intent and slot labels are read from the "intent_id2label" and "slot_id2label" maps of the configuration.
+func LoadIntentSlotFilling(modelPath string) (*IntentSlotFilling, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for intent and slot filling: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for intent and slot filling: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForIntentAndSlots](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bert model: %w", err)
+	}
+
+	return &IntentSlotFilling{
+		Model:        m,
+		Tokenizer:    tokenizer,
+		IntentLabels: bert_for_token_classification.ID2Label(m.Bert.Config.IntentID2Label),
+		SlotLabels:   bert_for_token_classification.ID2Label(m.Bert.Config.SlotID2Label),
+		doLowerCase:  tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Parse returns the intent and the slots of the given utterance.
+func (m *IntentSlotFilling) Parse(_ context.Context, text string, parameters intentslotfilling.Parameters) (intentslotfilling.Response, error) {
+	tokenized := m.tokenize(text)
+	if l, k := len(tokenized)+2, m.Model.Bert.Config.MaxPositionEmbeddings; l > k {
+		return intentslotfilling.Response{}, fmt.Errorf("%w: %d > %d", intentslotfilling.ErrInputSequenceTooLong, l, k)
+	}
+
+	intentLogits, slotLogits := m.Model.Classify(pad(tokenizers.GetStrings(tokenized)))
+	intent, intentScore := bestClass(intentLogits, m.IntentLabels)
+
+	// Each word is labeled by its first sub-word, skipping the class token.
+	wordLogits := wordpiecetokenizer.FirstSubWords(slotLogits[1:len(slotLogits)-1], tokenized)
+	words := wordpiecetokenizer.GroupSubWords(tokenized)
+	transitionScores := tokenclassification.TransitionScores(parameters.DecodingStrategy, nil, m.SlotLabels)
+	labels, scores := tokenclassification.Decode(wordLogits[:len(words)], m.SlotLabels, transitionScores)
+
+	slots := make([]tokenclassification.Token, 0, len(words))
+	for i, word := range words {
+		slots = append(slots, tokenclassification.Token{
+			Text:  text[word.Offsets.Start:word.Offsets.End],
+			Start: word.Offsets.Start,
+			End:   word.Offsets.End,
+			Label: labels[i],
+			Score: scores[i],
+		})
+	}
+
+	if parameters.AggregationStrategy == tokenclassification.AggregationStrategySimple {
+		slots = tokenclassification.FilterNotEntities(tokenclassification.Aggregate(slots))
+	}
+
+	response := intentslotfilling.Response{
+		Intent:      intent,
+		IntentScore: intentScore,
+		Slots:       slots,
+	}
+	return response, nil
+}
+
+// tokenize returns the tokens of the given text (without padding tokens).
+func (m *IntentSlotFilling) tokenize(text string) []tokenizers.StringOffsetsPair {
+	if m.doLowerCase {
+		text = strings.ToLower(text)
+	}
+	return m.Tokenizer.Tokenize(text)
+}
+
+// bestClass returns the most probable label and its probability.
+func bestClass(logits mat.Tensor, labels []string) (label string, score float64) {
+	probs := logits.Value().(mat.Matrix).Softmax()
+	argmax := probs.ArgMax()
+	return labels[argmax], probs.At(argmax).Item().F64()
+}
+
+func pad(tokens []string) []string {
+	return append(append([]string{wordpiecetokenizer.DefaultClassToken}, tokens...), wordpiecetokenizer.DefaultSequenceSeparator)
+}
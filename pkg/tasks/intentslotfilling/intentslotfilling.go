@@ -0,0 +1,41 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package intentslotfilling
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+)
+
+// ErrInputSequenceTooLong means that pre-processing the input text
+// produced a sequence that exceeds the maximum allowed length.
+var ErrInputSequenceTooLong = errors.New("input sequence too long")
+
+// Parameters contains the parameters for joint intent detection and slot filling.
+type Parameters struct {
+	// AggregationStrategy is the strategy used to group the slot tokens into spans.
+	AggregationStrategy tokenclassification.AggregationStrategy
+	// DecodingStrategy is the strategy used to assign the labels to the slot tokens.
+	// If empty, each token gets its most probable label, since the model has no CRF layer.
+	DecodingStrategy tokenclassification.DecodingStrategy
+}
+
+// Interface defines the main functions for joint intent detection and slot filling task.
+type Interface interface {
+	// Parse returns the intent and the slots of the given utterance.
+	Parse(ctx context.Context, text string, parameters Parameters) (Response, error)
+}
+
+// Response contains the response from joint intent detection and slot filling.
+type Response struct {
+	// Intent is the label of the intent.
+	Intent string
+	// IntentScore is the probability of the intent.
+	IntentScore float64
+	// Slots are the labeled tokens, or the labeled spans if aggregated.
+	Slots []tokenclassification.Token
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/models/distilbert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+const defaultTopK = 10
+
+var _ languagemodeling.Interface = &LanguageModel{}
+
+// LanguageModel is a masked language model based on DistilBERT.
+type LanguageModel struct {
+	// Model is the model used for masked language modeling.
+	Model *distilbert.ModelForMaskedLM
+	// Words vocabulary
+	vocab *vocabulary.Vocabulary
+	// Tokenizer is the tokenizer used to tokenize the text.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
+	doLowerCase bool
+}
+
+// LoadMaskedLanguageModel returns a LanguageModel loading the model, the embeddings and the tokenizer from a directory.
+func LoadMaskedLanguageModel(modelPath string) (*LanguageModel, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for language modeling: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for language modeling: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*distilbert.ModelForMaskedLM](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load distilbert model: %w", err)
+	}
+
+	return &LanguageModel{
+		Model:       m,
+		vocab:       vocab,
+		Tokenizer:   tokenizer,
+		doLowerCase: tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Predict returns the predicted tokens
+func (m *LanguageModel) Predict(_ context.Context, text string, parameters languagemodeling.Parameters) (languagemodeling.Response, error) {
+	if parameters.K == 0 {
+		parameters.K = defaultTopK
+	}
+
+	tokenized := pad(m.tokenize(text))
+	if l, k := len(tokenized), m.Model.DistilBert.Config.MaxPositionEmbeddings; l > k {
+		return languagemodeling.Response{}, fmt.Errorf("%w: %d > %d", languagemodeling.ErrInputSequenceTooLong, l, k)
+	}
+
+	prediction := m.Model.Predict(tokenizers.GetStrings(tokenized))
+
+	result := make([]languagemodeling.Token, 0, len(prediction))
+	for i, logits := range prediction {
+		probs := logits.Value().(mat.Matrix).Softmax()
+
+		best := sliceutils.NewIndexedSlice[float64](probs.Data().F64())
+		sort.Sort(sort.Reverse(best))
+		k := parameters.K
+		if k > best.Len() {
+			k = best.Len()
+		}
+
+		words := make([]string, k)
+		for j, id := range best.Indices[:k] {
+			word, ok := m.vocab.Term(id)
+			if !ok {
+				word = wordpiecetokenizer.DefaultUnknownToken // if this is returned, there's a misalignment with the vocabulary
+			}
+			words[j] = word
+		}
+
+		start, end := tokenized[i].Offsets.Start, tokenized[i].Offsets.End
+		result = append(result, languagemodeling.Token{
+			Start:  start,
+			End:    end,
+			Words:  words,
+			Scores: best.Slice[:k],
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Start < result[j].Start
+	})
+
+	return languagemodeling.Response{
+		Tokens: result,
+	}, nil
+}
+
+// tokenize returns the tokens of the given text (without padding tokens).
+func (m *LanguageModel) tokenize(text string) []tokenizers.StringOffsetsPair {
+	if m.doLowerCase {
+		text = strings.ToLower(text)
+	}
+	return m.Tokenizer.Tokenize(text)
+}
+
+func pad(tokens []tokenizers.StringOffsetsPair) []tokenizers.StringOffsetsPair {
+	cls := tokenizers.StringOffsetsPair{String: wordpiecetokenizer.DefaultClassToken}
+	sep := tokenizers.StringOffsetsPair{String: wordpiecetokenizer.DefaultSequenceSeparator}
+	return append(append([]tokenizers.StringOffsetsPair{cls}, tokens...), sep)
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package roberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+const defaultTopK = 10
+
+var _ languagemodeling.Interface = &LanguageModel{}
+
+// LanguageModel is a masked language model based on RoBERTa.
+type LanguageModel struct {
+	// Model is the model used for masked language modeling.
+	Model *bert.ModelForMaskedLM
+	// Tokenizer is the byte-level BPE tokenizer used to tokenize the text.
+	Tokenizer *bpetokenizer.BPETokenizer
+}
+
+// LoadMaskedLanguageModel returns a LanguageModel loading the model, the embeddings and the tokenizer from a directory.
+func LoadMaskedLanguageModel(modelPath string) (*LanguageModel, error) {
+	tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for language modeling: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForMaskedLM](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roberta model: %w", err)
+	}
+
+	return &LanguageModel{
+		Model:     m,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// Predict returns the predicted tokens for each `<mask>` in the text.
+func (m *LanguageModel) Predict(_ context.Context, text string, parameters languagemodeling.Parameters) (languagemodeling.Response, error) {
+	if parameters.K == 0 {
+		parameters.K = defaultTopK
+	}
+
+	tokenized, err := m.tokenize(text)
+	if err != nil {
+		return languagemodeling.Response{}, err
+	}
+	tokenized = pad(tokenized)
+	if l, k := len(tokenized), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return languagemodeling.Response{}, fmt.Errorf("%w: %d > %d", languagemodeling.ErrInputSequenceTooLong, l, k)
+	}
+
+	prediction := m.Model.Predict(tokenizers.GetStrings(tokenized))
+
+	result := make([]languagemodeling.Token, 0, len(prediction))
+	for i, logits := range prediction {
+		probs := logits.Value().(mat.Matrix).Softmax()
+
+		best := sliceutils.NewIndexedSlice[float64](probs.Data().F64())
+		sort.Sort(sort.Reverse(best))
+		k := parameters.K
+		if k > best.Len() {
+			k = best.Len()
+		}
+
+		words := make([]string, k)
+		for j, id := range best.Indices[:k] {
+			words[j] = strings.TrimSpace(m.Tokenizer.Detokenize([]int{id}))
+		}
+
+		start, end := tokenized[i].Offsets.Start, tokenized[i].Offsets.End
+		result = append(result, languagemodeling.Token{
+			Start:  start,
+			End:    end,
+			Words:  words,
+			Scores: best.Slice[:k],
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Start < result[j].Start
+	})
+
+	return languagemodeling.Response{
+		Tokens: result,
+	}, nil
+}
+
+// tokenize returns the tokens of the given text (without padding tokens).
+// The mask tokens are kept as they are, absorbing the preceding whitespace.
+func (m *LanguageModel) tokenize(text string) ([]tokenizers.StringOffsetsPair, error) {
+	mask := bpetokenizer.DefaultMaskToken
+	maskLen := utf8.RuneCountInString(mask)
+
+	result := make([]tokenizers.StringOffsetsPair, 0)
+	offset := 0
+	for i, part := range strings.Split(text, mask) {
+		if i > 0 {
+			result = append(result, tokenizers.StringOffsetsPair{
+				String:  mask,
+				Offsets: tokenizers.OffsetsType{Start: offset, End: offset + maskLen},
+			})
+			offset += maskLen
+		}
+		trimmed := strings.TrimRight(part, " ")
+		tokens, err := m.Tokenizer.Tokenize(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range tokens {
+			token.Offsets.Start += offset
+			token.Offsets.End += offset
+			result = append(result, token)
+		}
+		offset += utf8.RuneCountInString(part)
+	}
+	return result, nil
+}
+
+func pad(tokens []tokenizers.StringOffsetsPair) []tokenizers.StringOffsetsPair {
+	cls := tokenizers.StringOffsetsPair{String: bpetokenizer.DefaultClassToken}
+	sep := tokenizers.StringOffsetsPair{String: bpetokenizer.DefaultSequenceSeparator}
+	return append(append([]tokenizers.StringOffsetsPair{cls}, tokens...), sep)
+}
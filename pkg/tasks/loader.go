@@ -12,30 +12,78 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/converter"
 	"github.com/nlpodyssey/cybertron/pkg/downloader"
 	"github.com/nlpodyssey/cybertron/pkg/models"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/imageclassification"
+	vit_for_image_classification "github.com/nlpodyssey/cybertron/pkg/tasks/imageclassification/vit"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/imagetextencoding"
+	clip_for_image_text_encoding "github.com/nlpodyssey/cybertron/pkg/tasks/imagetextencoding/clip"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/intentslotfilling"
+	bert_for_intent_slot_filling "github.com/nlpodyssey/cybertron/pkg/tasks/intentslotfilling/bert"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling"
 	bert_for_language_modeling "github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling/bert"
+	distilbert_for_language_modeling "github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling/distilbert"
+	roberta_for_language_modeling "github.com/nlpodyssey/cybertron/pkg/tasks/languagemodeling/roberta"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice"
+	bert_for_multiple_choice "github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice/bert"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering"
 	bert_for_question_answering "github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering/bert"
+	distilbert_for_question_answering "github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering/distilbert"
+	roberta_for_question_answering "github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering/roberta"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/replacedtokendetection"
+	electra_for_replaced_token_detection "github.com/nlpodyssey/cybertron/pkg/tasks/replacedtokendetection/electra"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/reranking"
+	bert_for_reranking "github.com/nlpodyssey/cybertron/pkg/tasks/reranking/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/speechrecognition"
+	whisper_for_speech_recognition "github.com/nlpodyssey/cybertron/pkg/tasks/speechrecognition/whisper"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
+	albert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/albert"
+	bart_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bart"
 	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	debertav2_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/debertav2"
+	distilbert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/distilbert"
+	roberta_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/roberta"
+	xlmroberta_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/xlmroberta"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textencoding"
 	bert_for_text_encoding "github.com/nlpodyssey/cybertron/pkg/tasks/textencoding/bert"
+	distilbert_for_text_encoding "github.com/nlpodyssey/cybertron/pkg/tasks/textencoding/distilbert"
+	mpnet_for_text_encoding "github.com/nlpodyssey/cybertron/pkg/tasks/textencoding/mpnet"
+	roberta_for_text_encoding "github.com/nlpodyssey/cybertron/pkg/tasks/textencoding/roberta"
+	xlmroberta_for_text_encoding "github.com/nlpodyssey/cybertron/pkg/tasks/textencoding/xlmroberta"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
 	bart_for_text_to_text "github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration/bart"
+	gpt2_for_text_generation "github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration/gpt2"
+	llama_for_text_generation "github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration/llama"
+	t5_for_text_to_text "github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration/t5"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+	albert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/albert"
 	bert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/bert"
+	debertav2_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/debertav2"
+	distilbert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/distilbert"
+	roberta_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/roberta"
+	xlmroberta_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/xlmroberta"
 	"github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotclassifier"
 	bart_for_zero_shot_classification "github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotclassifier/bart"
+	bert_for_zero_shot_classification "github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotclassifier/bert"
+	debertav2_for_zero_shot_classification "github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotclassifier/debertav2"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotimageclassification"
+	clip_for_zero_shot_image_classification "github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotimageclassification/clip"
 )
 
 var (
-	textGenerationInterface      = reflect.TypeOf((*textgeneration.Interface)(nil)).Elem()
-	zeroshotclassifierInterface  = reflect.TypeOf((*zeroshotclassifier.Interface)(nil)).Elem()
-	questionansweringInterface   = reflect.TypeOf((*questionanswering.Interface)(nil)).Elem()
-	textclassificationInterface  = reflect.TypeOf((*textclassification.Interface)(nil)).Elem()
-	tokenclassificationInterface = reflect.TypeOf((*tokenclassification.Interface)(nil)).Elem()
-	textencodingInterface        = reflect.TypeOf((*textencoding.Interface)(nil)).Elem()
-	languagemodelingInterface    = reflect.TypeOf((*languagemodeling.Interface)(nil)).Elem()
+	textGenerationInterface          = reflect.TypeOf((*textgeneration.Interface)(nil)).Elem()
+	zeroshotclassifierInterface      = reflect.TypeOf((*zeroshotclassifier.Interface)(nil)).Elem()
+	questionansweringInterface       = reflect.TypeOf((*questionanswering.Interface)(nil)).Elem()
+	textclassificationInterface      = reflect.TypeOf((*textclassification.Interface)(nil)).Elem()
+	tokenclassificationInterface     = reflect.TypeOf((*tokenclassification.Interface)(nil)).Elem()
+	textencodingInterface            = reflect.TypeOf((*textencoding.Interface)(nil)).Elem()
+	languagemodelingInterface        = reflect.TypeOf((*languagemodeling.Interface)(nil)).Elem()
+	replacedtokendetectionInterface  = reflect.TypeOf((*replacedtokendetection.Interface)(nil)).Elem()
+	rerankingInterface               = reflect.TypeOf((*reranking.Interface)(nil)).Elem()
+	multiplechoiceInterface          = reflect.TypeOf((*multiplechoice.Interface)(nil)).Elem()
+	intentslotfillingInterface       = reflect.TypeOf((*intentslotfilling.Interface)(nil)).Elem()
+	imageclassificationInterface     = reflect.TypeOf((*imageclassification.Interface)(nil)).Elem()
+	imagetextencodingInterface       = reflect.TypeOf((*imagetextencoding.Interface)(nil)).Elem()
+	zeroshotimageclassifierInterface = reflect.TypeOf((*zeroshotimageclassification.Interface)(nil)).Elem()
+	speechrecognitionInterface       = reflect.TypeOf((*speechrecognition.Interface)(nil)).Elem()
 )
 
 // Load loads a model from file.
@@ -71,6 +119,38 @@ func LoadModelForTokenClassification(conf *Config) (tokenclassification.Interfac
 	return Load[tokenclassification.Interface](conf)
 }
 
+func LoadModelForReplacedTokenDetection(conf *Config) (replacedtokendetection.Interface, error) {
+	return Load[replacedtokendetection.Interface](conf)
+}
+
+func LoadModelForReranking(conf *Config) (reranking.Interface, error) {
+	return Load[reranking.Interface](conf)
+}
+
+func LoadModelForMultipleChoice(conf *Config) (multiplechoice.Interface, error) {
+	return Load[multiplechoice.Interface](conf)
+}
+
+func LoadModelForIntentSlotFilling(conf *Config) (intentslotfilling.Interface, error) {
+	return Load[intentslotfilling.Interface](conf)
+}
+
+func LoadModelForImageClassification(conf *Config) (imageclassification.Interface, error) {
+	return Load[imageclassification.Interface](conf)
+}
+
+func LoadModelForImageTextEncoding(conf *Config) (imagetextencoding.Interface, error) {
+	return Load[imagetextencoding.Interface](conf)
+}
+
+func LoadModelForZeroShotImageClassification(conf *Config) (zeroshotimageclassification.Interface, error) {
+	return Load[zeroshotimageclassification.Interface](conf)
+}
+
+func LoadModelForSpeechRecognition(conf *Config) (speechrecognition.Interface, error) {
+	return Load[speechrecognition.Interface](conf)
+}
+
 type loader[T any] struct {
 	conf Config
 }
@@ -111,6 +191,22 @@ func (l loader[T]) resolveLoadingFunc() (func() (T, error), error) {
 		return l.resolveModelForTextEncoding, nil
 	case t.Implements(languagemodelingInterface):
 		return l.resolveModelForLanguageModeling, nil
+	case t.Implements(replacedtokendetectionInterface):
+		return l.resolveModelForReplacedTokenDetection, nil
+	case t.Implements(rerankingInterface):
+		return l.resolveModelForReranking, nil
+	case t.Implements(multiplechoiceInterface):
+		return l.resolveModelForMultipleChoice, nil
+	case t.Implements(intentslotfillingInterface):
+		return l.resolveModelForIntentSlotFilling, nil
+	case t.Implements(imageclassificationInterface):
+		return l.resolveModelForImageClassification, nil
+	case t.Implements(imagetextencodingInterface):
+		return l.resolveModelForImageTextEncoding, nil
+	case t.Implements(zeroshotimageclassifierInterface):
+		return l.resolveModelForZeroShotImageClassification, nil
+	case t.Implements(speechrecognitionInterface):
+		return l.resolveModelForSpeechRecognition, nil
 	default:
 		return nil, fmt.Errorf("loader: invalid type %T", obj)
 	}
@@ -131,8 +227,14 @@ func (l loader[T]) resolveModelForTextGeneration() (obj T, _ error) {
 	}
 
 	switch modelConfig.ModelType {
-	case "bart", "marian", "pegasus":
+	case "bart", "marian", "pegasus", "mbart", "m2m_100":
 		return typeCheck[T](bart_for_text_to_text.LoadTextGeneration(modelDir))
+	case "t5":
+		return typeCheck[T](t5_for_text_to_text.LoadTextGeneration(modelDir))
+	case "gpt2":
+		return typeCheck[T](gpt2_for_text_generation.LoadTextGeneration(modelDir))
+	case "llama":
+		return typeCheck[T](llama_for_text_generation.LoadTextGeneration(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the text generation task", modelConfig.ModelType)
 	}
@@ -148,6 +250,10 @@ func (l loader[T]) resolveModelForZeroShotClassification() (obj T, _ error) {
 	switch modelConfig.ModelType {
 	case "bart":
 		return typeCheck[T](bart_for_zero_shot_classification.LoadZeroShotClassifier(modelDir))
+	case "bert", "electra", "roberta":
+		return typeCheck[T](bert_for_zero_shot_classification.LoadZeroShotClassifier(modelDir))
+	case "deberta-v2":
+		return typeCheck[T](debertav2_for_zero_shot_classification.LoadZeroShotClassifier(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the zero-shot classification task", modelConfig.ModelType)
 	}
@@ -161,8 +267,12 @@ func (l loader[T]) resolveModelForQuestionAnswering() (obj T, _ error) {
 	}
 
 	switch modelConfig.ModelType {
-	case "bert":
+	case "bert", "electra":
 		return typeCheck[T](bert_for_question_answering.LoadQuestionAnswering(modelDir))
+	case "roberta":
+		return typeCheck[T](roberta_for_question_answering.LoadQuestionAnswering(modelDir))
+	case "distilbert":
+		return typeCheck[T](distilbert_for_question_answering.LoadQuestionAnswering(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the question-answering task", modelConfig.ModelType)
 	}
@@ -176,8 +286,20 @@ func (l loader[T]) resolveModelForTextClassification() (obj T, _ error) {
 	}
 
 	switch modelConfig.ModelType {
-	case "bert":
+	case "bert", "electra":
 		return typeCheck[T](bert_for_text_classification.LoadTextClassification(modelDir))
+	case "roberta":
+		return typeCheck[T](roberta_for_text_classification.LoadTextClassification(modelDir))
+	case "xlm-roberta":
+		return typeCheck[T](xlmroberta_for_text_classification.LoadTextClassification(modelDir))
+	case "albert":
+		return typeCheck[T](albert_for_text_classification.LoadTextClassification(modelDir))
+	case "deberta-v2":
+		return typeCheck[T](debertav2_for_text_classification.LoadTextClassification(modelDir))
+	case "distilbert":
+		return typeCheck[T](distilbert_for_text_classification.LoadTextClassification(modelDir))
+	case "bart":
+		return typeCheck[T](bart_for_text_classification.LoadTextClassification(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the text classification task", modelConfig.ModelType)
 	}
@@ -191,8 +313,18 @@ func (l loader[T]) resolveModelForTokenClassification() (obj T, _ error) {
 	}
 
 	switch modelConfig.ModelType {
-	case "bert":
+	case "bert", "electra":
 		return typeCheck[T](bert_for_token_classification.LoadTokenClassification(modelDir))
+	case "roberta":
+		return typeCheck[T](roberta_for_token_classification.LoadTokenClassification(modelDir))
+	case "xlm-roberta":
+		return typeCheck[T](xlmroberta_for_token_classification.LoadTokenClassification(modelDir))
+	case "albert":
+		return typeCheck[T](albert_for_token_classification.LoadTokenClassification(modelDir))
+	case "deberta-v2":
+		return typeCheck[T](debertav2_for_token_classification.LoadTokenClassification(modelDir))
+	case "distilbert":
+		return typeCheck[T](distilbert_for_token_classification.LoadTokenClassification(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the token classification task", modelConfig.ModelType)
 	}
@@ -208,6 +340,14 @@ func (l loader[T]) resolveModelForTextEncoding() (obj T, _ error) {
 	switch modelConfig.ModelType {
 	case "bert":
 		return typeCheck[T](bert_for_text_encoding.LoadTextEncoding(modelDir))
+	case "roberta":
+		return typeCheck[T](roberta_for_text_encoding.LoadTextEncoding(modelDir))
+	case "xlm-roberta":
+		return typeCheck[T](xlmroberta_for_text_encoding.LoadTextEncoding(modelDir))
+	case "distilbert":
+		return typeCheck[T](distilbert_for_text_encoding.LoadTextEncoding(modelDir))
+	case "mpnet":
+		return typeCheck[T](mpnet_for_text_encoding.LoadTextEncoding(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the text encoding task", modelConfig.ModelType)
 	}
@@ -223,11 +363,135 @@ func (l loader[T]) resolveModelForLanguageModeling() (obj T, _ error) {
 	switch modelConfig.ModelType {
 	case "bert":
 		return typeCheck[T](bert_for_language_modeling.LoadMaskedLanguageModel(modelDir))
+	case "roberta":
+		return typeCheck[T](roberta_for_language_modeling.LoadMaskedLanguageModel(modelDir))
+	case "distilbert":
+		return typeCheck[T](distilbert_for_language_modeling.LoadMaskedLanguageModel(modelDir))
 	default:
 		return obj, fmt.Errorf("model type %#v doesn't support the language modeling task", modelConfig.ModelType)
 	}
 }
 
+func (l loader[T]) resolveModelForReplacedTokenDetection() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "electra":
+		return typeCheck[T](electra_for_replaced_token_detection.LoadReplacedTokenDetection(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the replaced token detection task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForReranking() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "bert", "electra":
+		return typeCheck[T](bert_for_reranking.LoadReranking(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the reranking task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForMultipleChoice() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "bert", "electra", "roberta":
+		return typeCheck[T](bert_for_multiple_choice.LoadMultipleChoice(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the multiple choice task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForIntentSlotFilling() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "bert":
+		return typeCheck[T](bert_for_intent_slot_filling.LoadIntentSlotFilling(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the intent detection and slot filling task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForImageClassification() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "vit":
+		return typeCheck[T](vit_for_image_classification.LoadImageClassification(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the image classification task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForImageTextEncoding() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "clip":
+		return typeCheck[T](clip_for_image_text_encoding.LoadImageTextEncoding(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the image-text encoding task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForZeroShotImageClassification() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "clip":
+		return typeCheck[T](clip_for_zero_shot_image_classification.LoadZeroShotImageClassifier(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the zero-shot image classification task", modelConfig.ModelType)
+	}
+}
+
+func (l loader[T]) resolveModelForSpeechRecognition() (obj T, _ error) {
+	modelDir := l.conf.FullModelPath()
+	modelConfig, err := models.ReadCommonModelConfig(modelDir, "")
+	if err != nil {
+		return obj, err
+	}
+
+	switch modelConfig.ModelType {
+	case "whisper":
+		return typeCheck[T](whisper_for_speech_recognition.LoadSpeechRecognition(modelDir))
+	default:
+		return obj, fmt.Errorf("model type %#v doesn't support the speech recognition task", modelConfig.ModelType)
+	}
+}
+
 func typeCheck[T any](i any, err error) (T, error) {
 	var empty T
 	if err != nil {
@@ -0,0 +1,139 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/multiplechoice"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ multiplechoice.Interface = &MultipleChoice{}
+
+// MultipleChoice is a multiple choice model based on BERT-family models (e.g. BERT, RoBERTa, ELECTRA).
+type MultipleChoice struct {
+	// Model is the model used for multiple choice.
+	Model *bert.ModelForMultipleChoice
+	// tokenizePair returns the tokens of the context followed by a choice.
+	tokenizePair func(text, choice string) ([]string, error)
+}
+
+// LoadMultipleChoice returns a MultipleChoice loading the model and the tokenizer from a directory.
+func LoadMultipleChoice(modelPath string) (*MultipleChoice, error) {
+	config, err := bert.ConfigFromFile[bert.Config](path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for multiple choice: %w", err)
+	}
+
+	tokenizePair, err := loadTokenizer(modelPath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForMultipleChoice](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bert model: %w", err)
+	}
+
+	return &MultipleChoice{
+		Model:        m,
+		tokenizePair: tokenizePair,
+	}, nil
+}
+
+// loadTokenizer returns the pair tokenization function of the byte-level BPE
+// tokenizer for RoBERTa models, and of the WordPiece tokenizer otherwise.
+func loadTokenizer(modelPath string, config bert.Config) (func(text, choice string) ([]string, error), error) {
+	if config.ModelType == "roberta" {
+		tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tokenizer for multiple choice: %w", err)
+		}
+		return func(text, choice string) ([]string, error) {
+			return tokenizeBPEPair(tokenizer, text, choice)
+		}, nil
+	}
+
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for multiple choice: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for multiple choice: %w", err)
+	}
+	return func(text, choice string) ([]string, error) {
+		if tokenizerConfig.DoLowerCase {
+			text, choice = strings.ToLower(text), strings.ToLower(choice)
+		}
+		return tokenizeWordPiecePair(tokenizer, text, choice), nil
+	}, nil
+}
+
+// Choose scores each choice as the continuation of (or the answer to) the given context.
+func (m *MultipleChoice) Choose(_ context.Context, text string, choices []string) (multiplechoice.Response, error) {
+	if len(choices) == 0 {
+		return multiplechoice.Response{}, multiplechoice.ErrMissingChoices
+	}
+
+	logits := make([]mat.Tensor, len(choices))
+	for i, choice := range choices {
+		tokenized, err := m.tokenizePair(text, choice)
+		if err != nil {
+			return multiplechoice.Response{}, err
+		}
+		if l, k := len(tokenized), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+			return multiplechoice.Response{}, fmt.Errorf("%w: %d > %d", multiplechoice.ErrInputSequenceTooLong, l, k)
+		}
+		logits[i] = m.Model.Score(tokenized)
+	}
+
+	probs := ag.Concat(logits...).Value().(mat.Matrix).Softmax()
+	return multiplechoice.Response{
+		Scores: probs.Data().F64(),
+		Best:   probs.ArgMax(),
+	}, nil
+}
+
+// tokenizeWordPiecePair returns the tokens of the pair as "[CLS] text [SEP] choice [SEP]".
+// The embeddings assign the second token type to the tokens following the first separator.
+func tokenizeWordPiecePair(tokenizer *wordpiecetokenizer.WordPieceTokenizer, text, choice string) []string {
+	cls := wordpiecetokenizer.DefaultClassToken
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+	tokens := append([]string{cls}, tokenizers.GetStrings(tokenizer.Tokenize(text))...)
+	tokens = append(append(tokens, sep), tokenizers.GetStrings(tokenizer.Tokenize(choice))...)
+	return append(tokens, sep)
+}
+
+// tokenizeBPEPair returns the tokens of the pair as "<s> text </s></s> choice </s>".
+func tokenizeBPEPair(tokenizer *bpetokenizer.BPETokenizer, text, choice string) ([]string, error) {
+	textTokens, err := tokenizer.Tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	choiceTokens, err := tokenizer.Tokenize(choice)
+	if err != nil {
+		return nil, err
+	}
+	cls := bpetokenizer.DefaultClassToken
+	sep := bpetokenizer.DefaultSequenceSeparator
+	tokens := append([]string{cls}, tokenizers.GetStrings(textTokens)...)
+	tokens = append(append(tokens, sep, sep), tokenizers.GetStrings(choiceTokens)...)
+	return append(tokens, sep), nil
+}
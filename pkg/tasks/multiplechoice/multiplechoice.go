@@ -0,0 +1,38 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiplechoice
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// DefaultModel is a RoBERTa model fine-tuned on RACE, to answer reading comprehension questions.
+	// Each choice is the question followed by one of its candidate answers.
+	// Model card: https://huggingface.co/LIAMF-USP/roberta-large-finetuned-race
+	DefaultModel = "LIAMF-USP/roberta-large-finetuned-race"
+)
+
+// ErrInputSequenceTooLong means that pre-processing the input text
+// produced a sequence that exceeds the maximum allowed length.
+var ErrInputSequenceTooLong = errors.New("input sequence too long")
+
+// ErrMissingChoices means that no choices have been given.
+var ErrMissingChoices = errors.New("at least one choice is required")
+
+// Interface defines the main functions for the multiple choice task.
+type Interface interface {
+	// Choose scores each choice as the continuation of (or the answer to) the given context.
+	Choose(ctx context.Context, text string, choices []string) (Response, error)
+}
+
+// Response contains the response from multiple choice.
+type Response struct {
+	// The probability of each choice, in the same order as the choices sent in the request.
+	Scores []float64
+	// The index of the most probable choice.
+	Best int
+}
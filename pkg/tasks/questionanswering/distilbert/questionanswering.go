@@ -0,0 +1,84 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/distilbert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ questionanswering.Interface = &QuestionAnswering{}
+
+// QuestionAnswering is a question-answering model based on DistilBERT.
+type QuestionAnswering struct {
+	// Model is the model used to answer questions.
+	Model *distilbert.ModelForQuestionAnswering
+	// Tokenizer is the tokenizer used to tokenize questions and passages.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+}
+
+// LoadQuestionAnswering returns a QuestionAnswering loading the model, the embeddings and the tokenizer from a directory.
+func LoadQuestionAnswering(modelPath string) (*QuestionAnswering, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for question-answering: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	m, err := nn.LoadFromFile[*distilbert.ModelForQuestionAnswering](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load distilbert model: %w", err)
+	}
+
+	return &QuestionAnswering{
+		Model:     m,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// ExtractAnswer returns the answers for the given question and passage.
+// The options may assume default values if those are not set.
+func (qa *QuestionAnswering) ExtractAnswer(_ context.Context, question string, passage string, opts *questionanswering.Options) (questionanswering.Response, error) {
+	questionanswering.CheckOptions(opts)
+
+	qt := qa.Tokenizer.Tokenize(question)
+	pt := qa.Tokenizer.Tokenize(passage)
+	if l, k := len(qt)+len(pt)+3, qa.Model.DistilBert.Config.MaxPositionEmbeddings; l > k {
+		return questionanswering.Response{}, fmt.Errorf("%w: %d > %d", questionanswering.ErrInputSequenceTooLong, l, k)
+	}
+
+	starts, ends := qa.Model.Answer(concat(qt, pt))
+	starts, ends = adjustLogitsForInference(starts, ends, qt, pt)
+
+	return questionanswering.Response{
+		Answers: questionanswering.ExtractAnswers(starts, ends, pt, passage, opts),
+	}, nil
+}
+
+// concat concatenates the question and passage tokens.
+func concat(question, passage []tokenizers.StringOffsetsPair) []string {
+	cls := wordpiecetokenizer.DefaultClassToken
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+	tokenized := append([]string{cls}, append(tokenizers.GetStrings(question), sep)...)
+	tokenized = append(tokenized, append(tokenizers.GetStrings(passage), sep)...)
+	return tokenized
+}
+
+// adjustLogitsForInference adjusts the logits for inference.
+func adjustLogitsForInference(starts, ends []mat.Tensor, question, passage []tokenizers.StringOffsetsPair) ([]mat.Tensor, []mat.Tensor) {
+	passageStartIndex := len(question) + 2 // the offset is for [CLS] and [SEP] tokens
+	passageEndIndex := passageStartIndex + len(passage)
+	return starts[passageStartIndex:passageEndIndex], ends[passageStartIndex:passageEndIndex]
+}
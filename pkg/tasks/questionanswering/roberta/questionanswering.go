@@ -0,0 +1,87 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package roberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/questionanswering"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ questionanswering.Interface = &QuestionAnswering{}
+
+// QuestionAnswering is a question-answering model based on RoBERTa.
+type QuestionAnswering struct {
+	// Model is the model used to answer questions.
+	Model *bert.ModelForQuestionAnswering
+	// Tokenizer is the byte-level BPE tokenizer used to tokenize questions and passages.
+	Tokenizer *bpetokenizer.BPETokenizer
+}
+
+// LoadQuestionAnswering returns a QuestionAnswering loading the model, the embeddings and the tokenizer from a directory.
+func LoadQuestionAnswering(modelPath string) (*QuestionAnswering, error) {
+	tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for question-answering: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForQuestionAnswering](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roberta model: %w", err)
+	}
+
+	return &QuestionAnswering{
+		Model:     m,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// ExtractAnswer returns the answers for the given question and passage.
+// The options may assume default values if those are not set.
+func (qa *QuestionAnswering) ExtractAnswer(_ context.Context, question string, passage string, opts *questionanswering.Options) (questionanswering.Response, error) {
+	questionanswering.CheckOptions(opts)
+
+	qt, err := qa.Tokenizer.Tokenize(question)
+	if err != nil {
+		return questionanswering.Response{}, err
+	}
+	pt, err := qa.Tokenizer.Tokenize(passage)
+	if err != nil {
+		return questionanswering.Response{}, err
+	}
+	if l, k := len(qt)+len(pt)+4, qa.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return questionanswering.Response{}, fmt.Errorf("%w: %d > %d", questionanswering.ErrInputSequenceTooLong, l, k)
+	}
+
+	starts, ends := qa.Model.Answer(concat(qt, pt))
+	starts, ends = adjustLogitsForInference(starts, ends, qt, pt)
+
+	return questionanswering.Response{
+		Answers: questionanswering.ExtractAnswers(starts, ends, pt, passage, opts),
+	}, nil
+}
+
+// concat concatenates the question and passage tokens in the form `<s> Q </s></s> P </s>`.
+func concat(question, passage []tokenizers.StringOffsetsPair) []string {
+	cls := bpetokenizer.DefaultClassToken
+	sep := bpetokenizer.DefaultSequenceSeparator
+	tokenized := append([]string{cls}, append(tokenizers.GetStrings(question), sep, sep)...)
+	tokenized = append(tokenized, append(tokenizers.GetStrings(passage), sep)...)
+	return tokenized
+}
+
+// adjustLogitsForInference adjusts the logits for inference.
+func adjustLogitsForInference(starts, ends []mat.Tensor, question, passage []tokenizers.StringOffsetsPair) ([]mat.Tensor, []mat.Tensor) {
+	passageStartIndex := len(question) + 3 // the offset is for <s> and the double </s> tokens
+	passageEndIndex := passageStartIndex + len(passage)
+	return starts[passageStartIndex:passageEndIndex], ends[passageStartIndex:passageEndIndex]
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package questionanswering
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/spago/ag"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+const (
+	defaultMaxAnswerLength = 20
+	defaultMinConfidence   = 0.1
+	defaultMaxCandidates   = 3.0
+	defaultMaxAnswers      = 3
+)
+
+// CheckOptions sets the default values of the options that are not set.
+func CheckOptions(opts *Options) {
+	if opts.MaxAnswers == 0 {
+		opts.MaxAnswers = defaultMaxAnswers
+	}
+	if opts.MaxAnswerLength == 0 {
+		opts.MaxAnswerLength = defaultMaxAnswerLength
+	}
+	if opts.MaxCandidates == 0 {
+		opts.MaxCandidates = defaultMaxCandidates
+	}
+	if opts.MinScore == 0 {
+		opts.MinScore = defaultMinConfidence
+	}
+}
+
+// ExtractAnswers returns the best answers, sorted by descending score, given the
+// "span start logits" and "span end logits" aligned with the passage tokens.
+func ExtractAnswers(starts, ends []mat.Tensor, pt []tokenizers.StringOffsetsPair, passage string, opts *Options) []Answer {
+	startsIdx := getBestIndices(extractScores(starts), opts.MaxCandidates)
+	endsIdx := getBestIndices(extractScores(ends), opts.MaxCandidates)
+	candidates := searchCandidates(startsIdx, endsIdx, starts, ends, pt, passage, opts.MaxAnswerLength)
+	answers := filterUnlikelyCandidates(candidates, opts.MinScore)
+
+	if len(answers) == 0 {
+		return nil
+	}
+
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].Score > answers[j].Score
+	})
+
+	if len(answers) > opts.MaxAnswers {
+		answers = answers[:opts.MaxAnswers]
+	}
+	return answers
+}
+
+// extractScores extracts the scores from the logits.
+func extractScores(logits []mat.Tensor) []float64 {
+	scores := make([]float64, len(logits))
+	for i, node := range logits {
+		scores[i] = node.Value().Item().F64()
+	}
+	return scores
+}
+
+// getBestIndices returns the best indices from the given scores.
+func getBestIndices(logits []float64, size int) []int {
+	s := sliceutils.NewIndexedSlice(logits)
+	sort.Sort(sort.Reverse(s))
+	if len(s.Indices) < size {
+		return s.Indices
+	}
+	return s.Indices[:size]
+}
+
+// searchCandidates searches the candidates from the given starts and ends logits.
+func searchCandidates(startsIdx, endsIdx []int, starts, ends []mat.Tensor, pt []tokenizers.StringOffsetsPair, passage string, maxLen int) []Answer {
+	candidates := make([]Answer, 0)
+	scores := make([]float64, 0) // the scores are aligned with the candidate answers
+	for _, startIndex := range startsIdx {
+		for _, endIndex := range endsIdx {
+			switch {
+			case endIndex < startIndex:
+				continue
+			case endIndex-startIndex+1 > maxLen:
+				continue
+			default:
+				startOffset := pt[startIndex].Offsets.Start
+				endOffset := pt[endIndex].Offsets.End
+				scores = append(scores, ag.Add(starts[startIndex], ends[endIndex]).Value().Item().F64())
+				candidates = append(candidates, Answer{
+					Text:  strings.Trim(string([]rune(passage)[startOffset:endOffset]), " "),
+					Start: startOffset,
+					End:   endOffset,
+				})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return candidates
+	}
+	for i, prob := range mat.NewDense[float64](mat.WithBacking(scores)).Softmax().Data().F64() {
+		candidates[i].Score = prob
+	}
+	return candidates
+}
+
+// filterUnlikelyCandidates filters the candidates that are unlikely to be the answer.
+func filterUnlikelyCandidates(candidates []Answer, min float64) []Answer {
+	answers := make([]Answer, 0)
+	for _, candidate := range candidates {
+		if candidate.Score >= min {
+			answers = append(answers, candidate)
+		}
+	}
+	return answers
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package electra
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/replacedtokendetection"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ replacedtokendetection.Interface = &ReplacedTokenDetection{}
+
+// ReplacedTokenDetection is a replaced token detection model based on the ELECTRA discriminator.
+type ReplacedTokenDetection struct {
+	// Model is the ELECTRA discriminator.
+	Model *bert.ModelForReplacedTokenDetection
+	// Tokenizer is the tokenizer used to tokenize the text.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
+	doLowerCase bool
+}
+
+// LoadReplacedTokenDetection returns a ReplacedTokenDetection loading the model, the embeddings and the tokenizer from a directory.
+func LoadReplacedTokenDetection(modelPath string) (*ReplacedTokenDetection, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for replaced token detection: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for replaced token detection: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForReplacedTokenDetection](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load electra model: %w", err)
+	}
+
+	return &ReplacedTokenDetection{
+		Model:       m,
+		Tokenizer:   tokenizer,
+		doLowerCase: tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Detect returns, for each word of the given text, the probability that it has been replaced.
+// The probability of a word is the one predicted for its first sub-word.
+func (m *ReplacedTokenDetection) Detect(_ context.Context, text string) (replacedtokendetection.Response, error) {
+	tokenized := m.tokenize(text)
+	if l, k := len(tokenized)+2, m.Model.Bert.Config.MaxPositionEmbeddings; l > k {
+		return replacedtokendetection.Response{}, fmt.Errorf("%w: %d > %d", replacedtokendetection.ErrInputSequenceTooLong, l, k)
+	}
+
+	cls := wordpiecetokenizer.DefaultClassToken
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+	probs := m.Model.Discriminate(append(append([]string{cls}, tokenizers.GetStrings(tokenized)...), sep))
+	probs = probs[1 : len(probs)-1]
+
+	scores := make([]float64, 0, len(tokenized))
+	for i, token := range tokenized {
+		if !strings.HasPrefix(token.String, wordpiecetokenizer.DefaultSplitPrefix) {
+			scores = append(scores, probs[i].Value().Item().F64())
+		}
+	}
+
+	runes := []rune(text)
+	words := wordpiecetokenizer.GroupSubWords(tokenized)
+	tokens := make([]replacedtokendetection.Token, len(words))
+	for i, word := range words {
+		tokens[i] = replacedtokendetection.Token{
+			Text:  string(runes[word.Offsets.Start:word.Offsets.End]),
+			Start: word.Offsets.Start,
+			End:   word.Offsets.End,
+			Score: scores[i],
+		}
+	}
+
+	return replacedtokendetection.Response{
+		Tokens: tokens,
+	}, nil
+}
+
+// tokenize returns the tokens of the given text (without padding tokens).
+func (m *ReplacedTokenDetection) tokenize(text string) []tokenizers.StringOffsetsPair {
+	if m.doLowerCase {
+		text = strings.ToLower(text)
+	}
+	return m.Tokenizer.Tokenize(text)
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package replacedtokendetection
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// DefaultEnglishModel is an ELECTRA discriminator pre-trained on English language to detect replaced tokens.
+	// Model card: https://huggingface.co/google/electra-base-discriminator
+	DefaultEnglishModel = "google/electra-base-discriminator"
+)
+
+// ErrInputSequenceTooLong means that pre-processing the input text
+// produced a sequence that exceeds the maximum allowed length.
+var ErrInputSequenceTooLong = errors.New("input sequence too long")
+
+// Interface defines the main functions for replaced token detection task.
+type Interface interface {
+	// Detect returns, for each word of the given text, the probability that it has been replaced.
+	Detect(ctx context.Context, text string) (Response, error)
+}
+
+// Token is a text token paired with the probability that it has been replaced.
+type Token struct {
+	Text  string
+	Start int
+	End   int
+	Score float64
+}
+
+// Response contains the response from replaced token detection.
+type Response struct {
+	Tokens []Token
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/reranking"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+	"golang.org/x/sync/errgroup"
+)
+
+var _ reranking.Interface = &Reranking{}
+
+// Reranking is a cross-encoder scoring the relevance of query-document pairs.
+type Reranking struct {
+	// Model is the model used to score the query-document pairs.
+	Model *bert.ModelForSequenceClassification
+	// Tokenizer is the tokenizer used to tokenize queries and documents.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
+	doLowerCase bool
+}
+
+// LoadReranking returns a Reranking loading the model and the tokenizer from a directory.
+func LoadReranking(modelPath string) (*Reranking, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for reranking: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for reranking: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bert model: %w", err)
+	}
+
+	return &Reranking{
+		Model:       m,
+		Tokenizer:   tokenizer,
+		doLowerCase: tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Rerank returns the documents sorted in descending order by relevance to the query.
+func (m *Reranking) Rerank(ctx context.Context, query string, documents []string, topK int) (reranking.Response, error) {
+	cls := wordpiecetokenizer.DefaultClassToken
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+
+	queryTokens := append([]string{cls}, append(m.tokenize(query), sep)...)
+	maxLength := m.Model.Bert.Config.MaxSequenceLength()
+
+	scores := make([]float64, len(documents))
+
+	ch := make(chan struct{}, runtime.NumCPU())
+	eg, ctx := errgroup.WithContext(ctx)
+
+	for i := range documents {
+		ch <- struct{}{}
+		i := i
+		eg.Go(func() error {
+			defer func() { <-ch }()
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			// The embeddings assign the second token type to the document,
+			// that is, to the tokens following the first separator.
+			documentTokens := m.tokenize(documents[i])
+			tokens := make([]string, 0, len(queryTokens)+len(documentTokens)+1)
+			tokens = append(tokens, queryTokens...)
+			tokens = append(tokens, documentTokens...)
+			tokens = append(tokens, sep)
+			if l := len(tokens); l > maxLength {
+				return fmt.Errorf("%w: %d > %d", reranking.ErrInputSequenceTooLong, l, maxLength)
+			}
+			scores[i] = relevance(m.Model.Classify(tokens).Value().(mat.Matrix))
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return reranking.Response{}, err
+	}
+	close(ch)
+
+	result := sliceutils.NewIndexedSlice[float64](scores)
+	sort.Stable(sort.Reverse(result))
+
+	n := len(documents)
+	if topK > 0 && topK < n {
+		n = topK
+	}
+
+	response := reranking.Response{
+		Documents: make([]string, n),
+		Indices:   result.Indices[:n],
+		Scores:    result.Slice[:n],
+	}
+	for i, ii := range response.Indices {
+		response.Documents[i] = documents[ii]
+	}
+	return response, nil
+}
+
+// tokenize returns the tokens of the given text, without special tokens.
+func (m *Reranking) tokenize(text string) []string {
+	if m.doLowerCase {
+		text = strings.ToLower(text)
+	}
+	return tokenizers.GetStrings(m.Tokenizer.Tokenize(text))
+}
+
+// relevance converts the logits into a relevance score between 0 and 1.
+// Models with a single output (e.g. MS MARCO cross-encoders) are squashed with
+// the sigmoid function; otherwise, the score is the probability of the last label.
+func relevance(logits mat.Matrix) float64 {
+	if logits.Size() == 1 {
+		return 1 / (1 + math.Exp(-logits.ScalarAt(0).F64()))
+	}
+	probs := logits.Softmax()
+	return probs.ScalarAt(probs.Size() - 1).F64()
+}
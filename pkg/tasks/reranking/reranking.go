@@ -0,0 +1,43 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reranking
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// DefaultModel is a cross-encoder trained on the MS MARCO passage ranking task.
+	// Given a query and a passage, it predicts how relevant the passage is for the query.
+	// Model card: https://huggingface.co/cross-encoder/ms-marco-MiniLM-L-6-v2
+	DefaultModel = "cross-encoder/ms-marco-MiniLM-L-6-v2"
+
+	// DefaultModelElectra is an ELECTRA cross-encoder trained on the MS MARCO passage ranking task,
+	// more accurate than DefaultModel at a higher computational cost.
+	// Model card: https://huggingface.co/cross-encoder/ms-marco-electra-base
+	DefaultModelElectra = "cross-encoder/ms-marco-electra-base"
+)
+
+// ErrInputSequenceTooLong means that pre-processing the input text
+// produced a sequence that exceeds the maximum allowed length.
+var ErrInputSequenceTooLong = errors.New("input sequence too long")
+
+// Interface defines the main functions for the reranking task.
+type Interface interface {
+	// Rerank returns the documents sorted in descending order by relevance to the query.
+	// If topK is greater than zero, only the topK most relevant documents are returned.
+	Rerank(ctx context.Context, query string, documents []string, topK int) (Response, error)
+}
+
+// Response contains the response from reranking.
+type Response struct {
+	// The documents sent in the request, sorted in descending order by relevance score.
+	Documents []string
+	// The position of each document in the request, in the same order as Documents.
+	Indices []int
+	// The relevance scores of the documents, in the same order as Documents.
+	Scores []float64
+}
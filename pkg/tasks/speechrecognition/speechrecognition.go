@@ -0,0 +1,65 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package speechrecognition
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nlpodyssey/cybertron/pkg/audioprocessing"
+)
+
+const (
+	// DefaultModel is a multilingual Whisper model, which transcribes speech in
+	// about a hundred languages and translates it into English.
+	// Model card: https://huggingface.co/openai/whisper-base
+	DefaultModel = "openai/whisper-base"
+
+	// DefaultEnglishModel is an English-only Whisper model.
+	// Model card: https://huggingface.co/openai/whisper-base.en
+	DefaultEnglishModel = "openai/whisper-base.en"
+)
+
+// ErrUnsupportedLanguage means that the requested language is not supported by the model.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// Interface defines the main functions for the speech recognition task.
+type Interface interface {
+	// Transcribe returns the transcription of the speech in the given audio.
+	Transcribe(ctx context.Context, audio audioprocessing.Audio, opts *Options) (Response, error)
+}
+
+// Options defines the options for the speech recognition.
+type Options struct {
+	// Language is the code of the spoken language (e.g. "en"). If empty,
+	// multilingual models detect it from the first 30 seconds of audio.
+	Language string
+	// Translate reports whether to translate the speech into English,
+	// instead of transcribing it in the spoken language.
+	Translate bool
+	// NumBeams is the number of beams for the decoding search. If zero,
+	// the greedy search is used.
+	NumBeams int
+}
+
+// Response contains the result of the speech recognition.
+type Response struct {
+	// Text is the whole transcription.
+	Text string
+	// Language is the code of the spoken language, as given or as detected.
+	Language string
+	// Segments contains the transcribed segments, with their timestamps.
+	Segments []Segment
+}
+
+// Segment is a transcribed segment of the audio.
+type Segment struct {
+	// Start is the start time of the segment, in seconds.
+	Start float64
+	// End is the end time of the segment, in seconds.
+	End float64
+	// Text is the transcription of the segment.
+	Text string
+}
@@ -0,0 +1,415 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whisper
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/audioprocessing"
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/cybertron/pkg/models/whisper"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/speechrecognition"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/whispertokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+// maxInitialTimestampIndex is the index of the latest timestamp allowed at
+// the beginning of each window, corresponding to 1 second.
+const maxInitialTimestampIndex = 50
+
+var _ speechrecognition.Interface = &SpeechRecognition{}
+
+// SpeechRecognition contains the Whisper model, the feature extractor and the
+// tokenizer used for speech recognition.
+type SpeechRecognition struct {
+	// Model is the model used for speech recognition.
+	Model *whisper.ModelForConditionalGeneration
+	// FeatureExtractor converts the audio into the model input.
+	FeatureExtractor *audioprocessing.FeatureExtractor
+	// Tokenizer is the tokenizer used to decode the transcriptions.
+	Tokenizer *whispertokenizer.WhisperTokenizer
+	// tokens contains the IDs of the special tokens.
+	tokens specialTokens
+}
+
+// specialTokens contains the IDs of the special tokens used to prompt the
+// model and to interpret the timestamps.
+type specialTokens struct {
+	startOfTranscript int
+	endOfText         int
+	translate         int
+	transcribe        int
+	noTimestamps      int
+	// timestampBegin is the ID of the first timestamp token, "<|0.00|>".
+	timestampBegin int
+	// languages maps the language codes to their token IDs.
+	// It is empty for English-only models.
+	languages map[string]int
+}
+
+// LoadSpeechRecognition returns a SpeechRecognition loading the model, the
+// feature extractor and the tokenizer from a directory.
+func LoadSpeechRecognition(modelPath string) (*SpeechRecognition, error) {
+	featureExtractor, err := audioprocessing.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feature extractor for speech recognition: %w", err)
+	}
+
+	tokenizer, err := whispertokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for speech recognition: %w", err)
+	}
+	tokens, err := resolveSpecialTokens(tokenizer)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := nn.LoadFromFile[*whisper.ModelForConditionalGeneration](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load whisper model: %w", err)
+	}
+	m.Whisper.ShareEmbeddings()
+
+	return &SpeechRecognition{
+		Model:            m,
+		FeatureExtractor: featureExtractor,
+		Tokenizer:        tokenizer,
+		tokens:           tokens,
+	}, nil
+}
+
+// resolveSpecialTokens returns the IDs of the special tokens of the tokenizer.
+func resolveSpecialTokens(tokenizer *whispertokenizer.WhisperTokenizer) (specialTokens, error) {
+	tokens := specialTokens{
+		endOfText: tokenizer.EndOfTextID(),
+		languages: make(map[string]int),
+	}
+	required := []struct {
+		token string
+		id    *int
+	}{
+		{"<|startoftranscript|>", &tokens.startOfTranscript},
+		{"<|translate|>", &tokens.translate},
+		{"<|transcribe|>", &tokens.transcribe},
+		{"<|notimestamps|>", &tokens.noTimestamps},
+	}
+	for _, t := range required {
+		id, ok := tokenizer.TokenID(t.token)
+		if !ok {
+			return specialTokens{}, fmt.Errorf("whisper: missing special token %s", t.token)
+		}
+		*t.id = id
+	}
+	tokens.timestampBegin = tokens.noTimestamps + 1
+	for _, code := range tokenizer.Languages() {
+		tokens.languages[code], _ = tokenizer.TokenID("<|" + code + "|>")
+	}
+	return tokens, nil
+}
+
+// Transcribe returns the transcription of the speech in the given audio.
+// The audio longer than 30 seconds is transcribed in consecutive windows,
+// each starting at the end of the last complete segment of the previous one.
+func (m *SpeechRecognition) Transcribe(ctx context.Context, audio audioprocessing.Audio, opts *speechrecognition.Options) (speechrecognition.Response, error) {
+	if opts == nil {
+		opts = &speechrecognition.Options{}
+	}
+	if len(m.tokens.languages) == 0 && (opts.Translate || (opts.Language != "" && opts.Language != "en")) {
+		return speechrecognition.Response{}, fmt.Errorf("%w: the model is English-only", speechrecognition.ErrUnsupportedLanguage)
+	}
+
+	c := m.FeatureExtractor.Config
+	samples := audioprocessing.Resample(audio.Mono(), audio.SampleRate, c.SamplingRate)
+	duration := float64(len(samples)) / float64(c.SamplingRate)
+	// Each timestamp token corresponds to two frames, since the encoder halves the frames.
+	samplesPerTimestamp := 2 * c.HopLength
+	timestampStep := float64(samplesPerTimestamp) / float64(c.SamplingRate)
+
+	language := opts.Language
+	var segments []speechrecognition.Segment
+	var text strings.Builder
+	for seek := 0; seek < len(samples); {
+		if ctx.Err() != nil {
+			break // return what has been transcribed so far
+		}
+		window := samples[seek:min(seek+c.NSamples, len(samples))]
+		encoderStates, err := m.Model.Whisper.Encoder.Encode(m.FeatureExtractor.Extract(window))
+		if err != nil {
+			return speechrecognition.Response{}, err
+		}
+		next := m.Model.DecodingFunc(encoderStates)
+		if language == "" && len(m.tokens.languages) > 0 {
+			language = m.detectLanguage(next)
+		}
+		prompt, err := m.prompt(language, opts.Translate)
+		if err != nil {
+			return speechrecognition.Response{}, err
+		}
+
+		tokens := m.decode(ctx, next, prompt, max(1, opts.NumBeams))
+		offset := float64(seek) / float64(c.SamplingRate)
+		windowSegments, consumed := m.segments(tokens, len(window)/samplesPerTimestamp)
+		for _, s := range windowSegments {
+			text.WriteString(s.Text)
+			s.Start = min(offset+s.Start*timestampStep, duration)
+			s.End = min(offset+s.End*timestampStep, duration)
+			s.Text = strings.TrimSpace(s.Text)
+			if s.Text != "" {
+				segments = append(segments, s)
+			}
+		}
+		if advance := consumed * samplesPerTimestamp; advance > 0 && advance < len(window) {
+			seek += advance
+		} else {
+			seek += len(window)
+		}
+	}
+
+	if len(m.tokens.languages) == 0 {
+		language = "en"
+	}
+	return speechrecognition.Response{
+		Text:     strings.TrimSpace(text.String()),
+		Language: language,
+		Segments: segments,
+	}, nil
+}
+
+// detectLanguage returns the code of the most likely language, predicted as
+// the token following the start-of-transcript token.
+func (m *SpeechRecognition) detectLanguage(next func(batch []*whisper.DecodingInput) []*whisper.DecodingOutput) string {
+	logProbs := next([]*whisper.DecodingInput{{
+		InputIDs: []int{m.tokens.startOfTranscript},
+		CurLen:   1,
+	}})[0].LogProbs.Data().F64()
+
+	best, bestLogProb := "", math.Inf(-1)
+	for code, id := range m.tokens.languages {
+		if logProbs[id] > bestLogProb {
+			best, bestLogProb = code, logProbs[id]
+		}
+	}
+	return best
+}
+
+// prompt returns the start-of-transcript token, followed by the language and
+// task tokens for multilingual models.
+func (m *SpeechRecognition) prompt(language string, translate bool) ([]int, error) {
+	if len(m.tokens.languages) == 0 {
+		return []int{m.tokens.startOfTranscript}, nil
+	}
+	languageID, ok := m.tokens.languages[language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", speechrecognition.ErrUnsupportedLanguage, language)
+	}
+	task := m.tokens.transcribe
+	if translate {
+		task = m.tokens.translate
+	}
+	return []int{m.tokens.startOfTranscript, languageID, task}, nil
+}
+
+// decode generates the tokens of a window, following the prompt, excluding
+// the prompt and the end-of-text token.
+func (m *SpeechRecognition) decode(ctx context.Context, next func(batch []*whisper.DecodingInput) []*whisper.DecodingOutput, prompt []int, numBeams int) []int {
+	cache := make([]bart.Cache, numBeams)
+
+	predictNext := func(decodingInputIDs [][]int, lastBeamIndices []int) []mat.Matrix {
+		cache = reorderCache(cache, lastBeamIndices)
+		batch := make([]*whisper.DecodingInput, len(decodingInputIDs))
+		for i, sequence := range decodingInputIDs {
+			batch[i] = &whisper.DecodingInput{
+				InputIDs: sequence[len(sequence)-1:],
+				Cache:    cache[i],
+				CurLen:   len(sequence),
+			}
+		}
+		logProbs := make([]mat.Matrix, len(batch))
+		for i, result := range next(batch) {
+			logProbs[i], cache[i] = m.processLogProbs(decodingInputIDs[i], len(prompt), result.LogProbs), result.NextCache
+		}
+		return logProbs
+	}
+
+	c := m.Model.Whisper.Config
+	forced := make(map[int]int, len(prompt)-1)
+	for i, id := range prompt[1:] {
+		forced[i+1] = id
+	}
+	decoder := &generationutils.BeamSearchDecoder{
+		Config: generationutils.Config{
+			NumBeams:            numBeams,
+			MinLength:           0,
+			MaxLength:           len(prompt) + c.MaxTargetPositions/2,
+			IsEncoderDecoder:    true,
+			BOSTokenID:          c.BosTokenID,
+			EOSTokenID:          m.tokens.endOfText,
+			PadTokenID:          c.PadTokenID,
+			VocabSize:           c.VocabSize,
+			DecoderStartTokenID: prompt[0],
+			LengthPenalty:       1.0,
+			ForcedDecoderIDs:    forced,
+		},
+		PredictNext: predictNext,
+		SelectNext:  generationutils.SelectNextTopK,
+	}
+	sequences, _ := decoder.Decode(ctx)
+
+	tokens := sequences[0][len(prompt):]
+	if n := len(tokens); n > 0 && tokens[n-1] == m.tokens.endOfText {
+		tokens = tokens[:n-1]
+	}
+	return tokens
+}
+
+// reorderCache reorders the cache according to the last beam indices.
+func reorderCache(cache []bart.Cache, lastBeamIndices []int) []bart.Cache {
+	tmpCache := make([]bart.Cache, len(cache))
+	for i, beamIndex := range lastBeamIndices {
+		tmpCache[i] = cache[beamIndex]
+	}
+	return tmpCache
+}
+
+// processLogProbs suppresses the tokens that cannot follow the given sequence,
+// enforcing the rules of the timestamps: they come in pairs (except before the
+// end of text), they do not decrease, and the first generated token is a timestamp.
+func (m *SpeechRecognition) processLogProbs(sequence []int, beginIndex int, logProbs mat.Matrix) mat.Matrix {
+	if len(sequence) < beginIndex {
+		return logProbs // the prompt tokens are forced by the decoder
+	}
+	var (
+		scores    = logProbs.Data().F64()
+		negInf    = math.Inf(-1)
+		generated = sequence[beginIndex:]
+		tsBegin   = m.tokens.timestampBegin
+	)
+	suppress := func(from, to int) {
+		for i := max(from, 0); i < min(to, len(scores)); i++ {
+			scores[i] = negInf
+		}
+	}
+
+	for _, id := range m.Model.Whisper.Config.SuppressTokens {
+		suppress(id, id+1)
+	}
+	suppress(m.tokens.noTimestamps, m.tokens.noTimestamps+1)
+
+	if len(generated) == 0 {
+		for _, id := range m.Model.Whisper.Config.BeginSuppressTokens {
+			suppress(id, id+1)
+		}
+		suppress(0, tsBegin)
+		suppress(tsBegin+maxInitialTimestampIndex+1, len(scores))
+		return logProbs.NewMatrix(mat.WithShape(logProbs.Shape()...), mat.WithBacking(scores))
+	}
+
+	n := len(generated)
+	lastWasTimestamp := generated[n-1] >= tsBegin
+	penultimateWasTimestamp := n < 2 || generated[n-2] >= tsBegin
+	if lastWasTimestamp {
+		if penultimateWasTimestamp {
+			suppress(tsBegin, len(scores)) // a text token must follow a pair of timestamps
+		} else {
+			suppress(0, m.tokens.endOfText) // a timestamp or the end of text must close the segment
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		if generated[i] < tsBegin {
+			continue
+		}
+		lastTimestamp := generated[i]
+		if !(lastWasTimestamp && !penultimateWasTimestamp) {
+			lastTimestamp++
+		}
+		suppress(tsBegin, lastTimestamp)
+		break
+	}
+
+	// When the timestamps are more likely than any text token, a timestamp is generated.
+	timestampLogProb := logSumExp(scores[tsBegin:])
+	maxTextLogProb := negInf
+	for _, v := range scores[:tsBegin] {
+		maxTextLogProb = math.Max(maxTextLogProb, v)
+	}
+	if timestampLogProb > maxTextLogProb {
+		suppress(0, tsBegin)
+	}
+	return logProbs.NewMatrix(mat.WithShape(logProbs.Shape()...), mat.WithBacking(scores))
+}
+
+// logSumExp returns the logarithm of the sum of the exponentials of the values.
+func logSumExp(xs []float64) float64 {
+	maxValue := math.Inf(-1)
+	for _, x := range xs {
+		maxValue = math.Max(maxValue, x)
+	}
+	if math.IsInf(maxValue, -1) {
+		return maxValue
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += math.Exp(x - maxValue)
+	}
+	return maxValue + math.Log(sum)
+}
+
+// segments splits the tokens of a window into segments delimited by pairs of
+// timestamps, whose start and end are expressed as timestamp indices. It also
+// returns the number of timestamp steps consumed from the window, given its length:
+// when the last segment is incomplete, the next window starts from its beginning.
+func (m *SpeechRecognition) segments(tokens []int, windowLength int) ([]speechrecognition.Segment, int) {
+	tsBegin := m.tokens.timestampBegin
+	isTimestamp := func(i int) bool { return tokens[i] >= tsBegin }
+	n := len(tokens)
+	singleTimestampEnding := n >= 2 && !isTimestamp(n-2) && isTimestamp(n-1)
+
+	var slices []int
+	for i := 1; i < n; i++ {
+		if isTimestamp(i-1) && isTimestamp(i) {
+			slices = append(slices, i)
+		}
+	}
+
+	if len(slices) == 0 {
+		// A single segment covering the whole window, possibly ending at the last timestamp.
+		end := windowLength
+		for i := n - 1; i >= 0; i-- {
+			if isTimestamp(i) {
+				if tokens[i] != tsBegin {
+					end = tokens[i] - tsBegin
+				}
+				break
+			}
+		}
+		segment := speechrecognition.Segment{Start: 0, End: float64(end), Text: m.Tokenizer.Decode(tokens)}
+		return []speechrecognition.Segment{segment}, windowLength
+	}
+
+	if singleTimestampEnding {
+		slices = append(slices, n)
+	}
+	segments := make([]speechrecognition.Segment, 0, len(slices))
+	last := 0
+	for _, current := range slices {
+		sliced := tokens[last:current]
+		segments = append(segments, speechrecognition.Segment{
+			Start: float64(sliced[0] - tsBegin),
+			End:   float64(sliced[len(sliced)-1] - tsBegin),
+			Text:  m.Tokenizer.Decode(sliced),
+		})
+		last = current
+	}
+	if singleTimestampEnding {
+		return segments, windowLength
+	}
+	return segments, tokens[last-1] - tsBegin
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package albert
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
+	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+const (
+	// defaultClassToken is the ALBERT class token, at the beginning of the sequence.
+	defaultClassToken = "[CLS]"
+	// defaultSequenceSeparator is the ALBERT separator token, at the end of the sequence.
+	defaultSequenceSeparator = "[SEP]"
+)
+
+var (
+	_ textclassification.Interface     = &TextClassification{}
+	_ textclassification.PairInterface = &TextClassification{}
+)
+
+// TextClassification is a text classification model based on ALBERT.
+type TextClassification struct {
+	// Model is the model used for text classification.
+	Model *bert.ModelForSequenceClassification
+	// Tokenizer is the sentence-piece tokenizer used to tokenize the text.
+	Tokenizer *sentencepiece.Tokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+	// Regression indicates whether the model predicts a score rather than the labels
+	// (see textclassification.ProblemTypeRegression).
+	Regression bool
+}
+
+// LoadTextClassification returns a TextClassification loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextClassification(modelPath string) (*TextClassification, error) {
+	tokenizer, err := sentencepiece.NewFromFile(path.Join(modelPath, "spiece.model"), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for text classification: %w", err)
+	}
+
+	config, err := bert.ConfigFromFile[bert.Config](path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for text classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load albert model: %w", err)
+	}
+
+	return &TextClassification{
+		Model:      m,
+		Tokenizer:  tokenizer.WithStripAccents(true), // ALBERT lowercases and strips the accents by default
+		Labels:     bert_for_text_classification.ID2Label(config.ID2Label),
+		Regression: config.ProblemType == textclassification.ProblemTypeRegression,
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TextClassification) Classify(_ context.Context, text string) (textclassification.Response, error) {
+	return m.classify(m.tokenize(text))
+}
+
+// ClassifyPair returns the classification of the given pair of texts.
+func (m *TextClassification) ClassifyPair(_ context.Context, text, textPair string) (textclassification.Response, error) {
+	return m.classify(m.tokenizePair(text, textPair))
+}
+
+// classify returns the classification of the given tokens.
+func (m *TextClassification) classify(tokenized []string) (textclassification.Response, error) {
+	if l, k := len(tokenized), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return textclassification.Response{}, fmt.Errorf("%w: %d > %d", textclassification.ErrInputSequenceTooLong, l, k)
+	}
+	logits := m.Model.Classify(tokenized)
+	return textclassification.ResponseFromLogits(logits.Value().(mat.Matrix), m.Labels, m.Regression), nil
+}
+
+// tokenize returns the tokens of the given text (including padding tokens).
+// The pieces missing from the vocabulary are replaced with the unknown token.
+func (m *TextClassification) tokenize(text string) []string {
+	tokens := m.Tokenizer.IDsToTokens(m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)))
+	return append([]string{defaultClassToken}, append(tokens, defaultSequenceSeparator)...)
+}
+
+// tokenizePair returns the tokens of the given pair of texts, as "[CLS] text [SEP] textPair [SEP]".
+// The embeddings assign the second token type to the tokens following the first separator.
+func (m *TextClassification) tokenizePair(text, textPair string) []string {
+	tokens := m.Tokenizer.IDsToTokens(m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(textPair)))
+	return append(m.tokenize(text), append(tokens, defaultSequenceSeparator)...)
+}
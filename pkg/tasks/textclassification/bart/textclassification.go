@@ -0,0 +1,113 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bart
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
+	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var (
+	_ textclassification.Interface     = &TextClassification{}
+	_ textclassification.PairInterface = &TextClassification{}
+)
+
+// TextClassification is a text classification model based on BART.
+type TextClassification struct {
+	// Model is the model used for text classification.
+	Model *bart.ModelForSequenceClassification
+	// Tokenizer is the byte-level BPE tokenizer used to tokenize the text.
+	Tokenizer *bpetokenizer.BPETokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+	// Regression indicates whether the model predicts a score rather than the labels
+	// (see textclassification.ProblemTypeRegression).
+	Regression bool
+}
+
+// LoadTextClassification returns a TextClassification loading the model and the tokenizer from a directory.
+func LoadTextClassification(modelPath string) (*TextClassification, error) {
+	tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for text classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bart.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bart model: %w", err)
+	}
+
+	m.Bart.Encoder.Embeddings.SharedEmbeddings = embedding.Shared{Model: m.Bart.Embeddings}
+	m.Bart.Decoder.Embeddings.SharedEmbeddings = embedding.Shared{Model: m.Bart.Embeddings}
+
+	return &TextClassification{
+		Model:      m,
+		Tokenizer:  tokenizer,
+		Labels:     bert_for_text_classification.ID2Label(m.Bart.Config.ID2Label),
+		Regression: m.Bart.Config.ProblemType == textclassification.ProblemTypeRegression,
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TextClassification) Classify(_ context.Context, text string) (textclassification.Response, error) {
+	tokenized, err := m.tokenize(text)
+	if err != nil {
+		return textclassification.Response{}, err
+	}
+	return m.classify(tokenized)
+}
+
+// ClassifyPair returns the classification of the given pair of texts.
+func (m *TextClassification) ClassifyPair(_ context.Context, text, textPair string) (textclassification.Response, error) {
+	tokenized, err := m.tokenizePair(text, textPair)
+	if err != nil {
+		return textclassification.Response{}, err
+	}
+	return m.classify(tokenized)
+}
+
+// classify returns the classification of the given token IDs.
+func (m *TextClassification) classify(tokenized []int) (textclassification.Response, error) {
+	if l, k := len(tokenized), m.Model.Bart.Config.MaxPositionEmbeddings; l > k {
+		return textclassification.Response{}, fmt.Errorf("%w: %d > %d", textclassification.ErrInputSequenceTooLong, l, k)
+	}
+	logits := m.Model.Forward(tokenized)
+	return textclassification.ResponseFromLogits(logits.Value().(mat.Matrix), m.Labels, m.Regression), nil
+}
+
+// tokenize returns the token IDs of the given text, surrounded by the BOS and EOS tokens.
+func (m *TextClassification) tokenize(text string) ([]int, error) {
+	encoded, err := m.Tokenizer.Encode(text)
+	if err != nil {
+		return nil, err
+	}
+	tokenized := make([]int, 0, len(encoded.IDs)+2)
+	tokenized = append(tokenized, m.Model.Bart.Config.BosTokenID)
+	tokenized = append(tokenized, encoded.IDs...)
+	return append(tokenized, m.Model.Bart.Config.EosTokenID), nil
+}
+
+// tokenizePair returns the token IDs of the given pair of texts, as "<s> text </s></s> textPair </s>".
+func (m *TextClassification) tokenizePair(text, textPair string) ([]int, error) {
+	tokenized, err := m.tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	pair, err := m.tokenize(textPair)
+	if err != nil {
+		return nil, err
+	}
+	pair[0] = m.Model.Bart.Config.EosTokenID
+	return append(tokenized, pair...), nil
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/nlpodyssey/spago/mat"
 	"path"
 	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -18,7 +17,6 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
-	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
 	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
 	"github.com/nlpodyssey/spago/nn"
 	"github.com/rs/zerolog/log"
@@ -32,6 +30,9 @@ type TextClassification struct {
 	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
 	// Labels is the list of labels used for classification.
 	Labels []string
+	// Regression indicates whether the model predicts a score rather than the labels
+	// (see textclassification.ProblemTypeRegression).
+	Regression bool
 	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
 	doLowerCase bool
 }
@@ -64,6 +65,7 @@ func LoadTextClassification(modelPath string) (*TextClassification, error) {
 		Model:       m,
 		Tokenizer:   tokenizer,
 		Labels:      labels,
+		Regression:  config.ProblemType == textclassification.ProblemTypeRegression,
 		doLowerCase: tokenizerConfig.DoLowerCase,
 	}, nil
 }
@@ -85,26 +87,21 @@ func ID2Label(value map[string]string) []string {
 
 // Classify returns the classification of the given text.
 func (m *TextClassification) Classify(_ context.Context, text string) (textclassification.Response, error) {
-	tokenized := m.tokenize(text)
+	return m.classify(m.tokenize(text))
+}
+
+// ClassifyPair returns the classification of the given pair of texts.
+func (m *TextClassification) ClassifyPair(_ context.Context, text, textPair string) (textclassification.Response, error) {
+	return m.classify(m.tokenizePair(text, textPair))
+}
+
+// classify returns the classification of the given tokens.
+func (m *TextClassification) classify(tokenized []string) (textclassification.Response, error) {
 	if l, k := len(tokenized), m.Model.Bert.Config.MaxPositionEmbeddings; l > k {
 		return textclassification.Response{}, fmt.Errorf("%w: %d > %d", textclassification.ErrInputSequenceTooLong, l, k)
 	}
 	logits := m.Model.Classify(tokenized)
-	probs := logits.Value().(mat.Matrix).Softmax()
-
-	result := sliceutils.NewIndexedSlice[float64](probs.Data().F64())
-	sort.Stable(sort.Reverse(result))
-
-	labels := make([]string, len(m.Labels))
-	for i, ii := range result.Indices {
-		labels[i] = m.Labels[ii]
-	}
-
-	response := textclassification.Response{
-		Labels: labels,
-		Scores: result.Slice,
-	}
-	return response, nil
+	return textclassification.ResponseFromLogits(logits.Value().(mat.Matrix), m.Labels, m.Regression), nil
 }
 
 // tokenize returns the tokens of the given text (including padding tokens).
@@ -116,3 +113,13 @@ func (m *TextClassification) tokenize(text string) []string {
 	sep := wordpiecetokenizer.DefaultSequenceSeparator
 	return append([]string{cls}, append(tokenizers.GetStrings(m.Tokenizer.Tokenize(text)), sep)...)
 }
+
+// tokenizePair returns the tokens of the given pair of texts, as "[CLS] text [SEP] textPair [SEP]".
+// The embeddings assign the second token type to the tokens following the first separator.
+func (m *TextClassification) tokenizePair(text, textPair string) []string {
+	if m.doLowerCase {
+		textPair = strings.ToLower(textPair)
+	}
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+	return append(m.tokenize(text), append(tokenizers.GetStrings(m.Tokenizer.Tokenize(textPair)), sep)...)
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/debertav2"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
+	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+const (
+	// defaultClassToken is the DeBERTa-v2 class token, at the beginning of the sequence.
+	defaultClassToken = "[CLS]"
+	// defaultSequenceSeparator is the DeBERTa-v2 separator token, at the end of the sequence.
+	defaultSequenceSeparator = "[SEP]"
+)
+
+var (
+	_ textclassification.Interface     = &TextClassification{}
+	_ textclassification.PairInterface = &TextClassification{}
+)
+
+// TextClassification is a text classification model based on DeBERTa-v2.
+type TextClassification struct {
+	// Model is the model used for text classification.
+	Model *debertav2.ModelForSequenceClassification
+	// Tokenizer is the sentence-piece tokenizer used to tokenize the text.
+	Tokenizer *sentencepiece.Tokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+	// Regression indicates whether the model predicts a score rather than the labels
+	// (see textclassification.ProblemTypeRegression).
+	Regression bool
+}
+
+// LoadTextClassification returns a TextClassification loading the model and the tokenizer from a directory.
+func LoadTextClassification(modelPath string) (*TextClassification, error) {
+	tokenizer, err := sentencepiece.NewFromFile(path.Join(modelPath, "spm.model"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for text classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*debertav2.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deberta-v2 model: %w", err)
+	}
+
+	return &TextClassification{
+		Model:      m,
+		Tokenizer:  tokenizer,
+		Labels:     bert_for_text_classification.ID2Label(m.DeBERTa.Config.ID2Label),
+		Regression: m.DeBERTa.Config.ProblemType == textclassification.ProblemTypeRegression,
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TextClassification) Classify(_ context.Context, text string) (textclassification.Response, error) {
+	return m.classify(m.tokenize(text))
+}
+
+// ClassifyPair returns the classification of the given pair of texts.
+func (m *TextClassification) ClassifyPair(_ context.Context, text, textPair string) (textclassification.Response, error) {
+	return m.classify(m.tokenizePair(text, textPair))
+}
+
+// classify returns the classification of the given tokens.
+func (m *TextClassification) classify(tokenized []int) (textclassification.Response, error) {
+	if l, k := len(tokenized), m.Model.DeBERTa.Config.MaxSequenceLength(); l > k {
+		return textclassification.Response{}, fmt.Errorf("%w: %d > %d", textclassification.ErrInputSequenceTooLong, l, k)
+	}
+	logits := m.Model.Classify(tokenized, nil)
+	return textclassification.ResponseFromLogits(logits.Value().(mat.Matrix), m.Labels, m.Regression), nil
+}
+
+// tokenize returns the token IDs of the given text, surrounded by the class and separator tokens.
+func (m *TextClassification) tokenize(text string) []int {
+	tokens := append([]string{defaultClassToken}, m.Tokenizer.Tokenize(text)...)
+	return m.Tokenizer.TokensToIDs(append(tokens, defaultSequenceSeparator))
+}
+
+// tokenizePair returns the token IDs of the given pair of texts, as "[CLS] text [SEP] textPair [SEP]".
+func (m *TextClassification) tokenizePair(text, textPair string) []int {
+	tokens := append([]string{defaultClassToken}, m.Tokenizer.Tokenize(text)...)
+	tokens = append(append(tokens, defaultSequenceSeparator), m.Tokenizer.Tokenize(textPair)...)
+	return m.Tokenizer.TokensToIDs(append(tokens, defaultSequenceSeparator))
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/models/distilbert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
+	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var (
+	_ textclassification.Interface     = &TextClassification{}
+	_ textclassification.PairInterface = &TextClassification{}
+)
+
+// TextClassification is a text classification model based on DistilBERT.
+type TextClassification struct {
+	// Model is the model used for text classification.
+	Model *distilbert.ModelForSequenceClassification
+	// Tokenizer is the tokenizer used to tokenize the text.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+	// Regression indicates whether the model predicts a score rather than the labels
+	// (see textclassification.ProblemTypeRegression).
+	Regression bool
+	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
+	doLowerCase bool
+}
+
+// LoadTextClassification returns a TextClassification loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextClassification(modelPath string) (*TextClassification, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for text classification: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for text classification: %w", err)
+	}
+
+	config, err := distilbert.ConfigFromFile(path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for text classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*distilbert.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load distilbert model: %w", err)
+	}
+
+	return &TextClassification{
+		Model:       m,
+		Tokenizer:   tokenizer,
+		Labels:      bert_for_text_classification.ID2Label(config.ID2Label),
+		Regression:  config.ProblemType == textclassification.ProblemTypeRegression,
+		doLowerCase: tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TextClassification) Classify(_ context.Context, text string) (textclassification.Response, error) {
+	return m.classify(m.tokenize(text))
+}
+
+// ClassifyPair returns the classification of the given pair of texts.
+func (m *TextClassification) ClassifyPair(_ context.Context, text, textPair string) (textclassification.Response, error) {
+	return m.classify(m.tokenizePair(text, textPair))
+}
+
+// classify returns the classification of the given tokens.
+func (m *TextClassification) classify(tokenized []string) (textclassification.Response, error) {
+	if l, k := len(tokenized), m.Model.DistilBert.Config.MaxPositionEmbeddings; l > k {
+		return textclassification.Response{}, fmt.Errorf("%w: %d > %d", textclassification.ErrInputSequenceTooLong, l, k)
+	}
+	logits := m.Model.Classify(tokenized)
+	return textclassification.ResponseFromLogits(logits.Value().(mat.Matrix), m.Labels, m.Regression), nil
+}
+
+// tokenize returns the tokens of the given text (including padding tokens).
+func (m *TextClassification) tokenize(text string) []string {
+	if m.doLowerCase {
+		text = strings.ToLower(text)
+	}
+	cls := wordpiecetokenizer.DefaultClassToken
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+	return append([]string{cls}, append(tokenizers.GetStrings(m.Tokenizer.Tokenize(text)), sep)...)
+}
+
+// tokenizePair returns the tokens of the given pair of texts, as "[CLS] text [SEP] textPair [SEP]".
+func (m *TextClassification) tokenizePair(text, textPair string) []string {
+	if m.doLowerCase {
+		textPair = strings.ToLower(textPair)
+	}
+	sep := wordpiecetokenizer.DefaultSequenceSeparator
+	return append(m.tokenize(text), append(tokenizers.GetStrings(m.Tokenizer.Tokenize(textPair)), sep)...)
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package roberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textclassification"
+	bert_for_text_classification "github.com/nlpodyssey/cybertron/pkg/tasks/textclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var (
+	_ textclassification.Interface     = &TextClassification{}
+	_ textclassification.PairInterface = &TextClassification{}
+)
+
+// TextClassification is a text classification model based on RoBERTa.
+type TextClassification struct {
+	// Model is the model used for text classification.
+	Model *bert.ModelForSequenceClassification
+	// Tokenizer is the byte-level BPE tokenizer used to tokenize the text.
+	Tokenizer *bpetokenizer.BPETokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+	// Regression indicates whether the model predicts a score rather than the labels
+	// (see textclassification.ProblemTypeRegression).
+	Regression bool
+}
+
+// LoadTextClassification returns a TextClassification loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextClassification(modelPath string) (*TextClassification, error) {
+	tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for text classification: %w", err)
+	}
+
+	config, err := bert.ConfigFromFile[bert.Config](path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for text classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roberta model: %w", err)
+	}
+
+	return &TextClassification{
+		Model:      m,
+		Tokenizer:  tokenizer,
+		Labels:     bert_for_text_classification.ID2Label(config.ID2Label),
+		Regression: config.ProblemType == textclassification.ProblemTypeRegression,
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TextClassification) Classify(_ context.Context, text string) (textclassification.Response, error) {
+	tokenized, err := m.tokenize(text)
+	if err != nil {
+		return textclassification.Response{}, err
+	}
+	return m.classify(tokenized)
+}
+
+// ClassifyPair returns the classification of the given pair of texts.
+func (m *TextClassification) ClassifyPair(_ context.Context, text, textPair string) (textclassification.Response, error) {
+	tokenized, err := m.tokenizePair(text, textPair)
+	if err != nil {
+		return textclassification.Response{}, err
+	}
+	return m.classify(tokenized)
+}
+
+// classify returns the classification of the given tokens.
+func (m *TextClassification) classify(tokenized []string) (textclassification.Response, error) {
+	if l, k := len(tokenized), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return textclassification.Response{}, fmt.Errorf("%w: %d > %d", textclassification.ErrInputSequenceTooLong, l, k)
+	}
+	logits := m.Model.Classify(tokenized)
+	return textclassification.ResponseFromLogits(logits.Value().(mat.Matrix), m.Labels, m.Regression), nil
+}
+
+// tokenize returns the tokens of the given text (including padding tokens).
+func (m *TextClassification) tokenize(text string) ([]string, error) {
+	tokens, err := m.Tokenizer.Tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	cls := bpetokenizer.DefaultClassToken
+	sep := bpetokenizer.DefaultSequenceSeparator
+	return append([]string{cls}, append(tokenizers.GetStrings(tokens), sep)...), nil
+}
+
+// tokenizePair returns the tokens of the given pair of texts, as "<s> text </s></s> textPair </s>".
+func (m *TextClassification) tokenizePair(text, textPair string) ([]string, error) {
+	tokenized, err := m.tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := m.Tokenizer.Tokenize(textPair)
+	if err != nil {
+		return nil, err
+	}
+	sep := bpetokenizer.DefaultSequenceSeparator
+	return append(tokenized, append([]string{sep}, append(tokenizers.GetStrings(tokens), sep)...)...), nil
+}
@@ -7,6 +7,11 @@ package textclassification
 import (
 	"context"
 	"errors"
+	"math"
+	"sort"
+
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/spago/mat"
 )
 
 const (
@@ -19,8 +24,23 @@ const (
 	// classification of news headlines. It predicts the ISO 3166-1 alpha-3 country codes.
 	// Model card: https://huggingface.co/nlpodyssey/bert-multilingual-uncased-geo-countries-headlines
 	DefaultModelForGeographicCategorizationMulti = "nlpodyssey/bert-multilingual-uncased-geo-countries-headlines"
+
+	// DefaultModelForSemanticTextualSimilarity is a cross-encoder that predicts the semantic similarity
+	// of a pair of texts, as a score between 0 and 1. It must be used with sentence-pair classification.
+	// Model card: https://huggingface.co/cross-encoder/stsb-roberta-base
+	DefaultModelForSemanticTextualSimilarity = "cross-encoder/stsb-roberta-base"
+
+	// DefaultModelForDuplicateQuestionDetection is a cross-encoder that predicts whether two questions
+	// are duplicates. It must be used with sentence-pair classification.
+	// Model card: https://huggingface.co/cross-encoder/quora-distilroberta-base
+	DefaultModelForDuplicateQuestionDetection = "cross-encoder/quora-distilroberta-base"
 )
 
+// ProblemTypeRegression is the problem type of the configuration of the models
+// trained for regression, such as Semantic Textual Similarity models predicting a
+// score between 0 and 5.
+const ProblemTypeRegression = "regression"
+
 // ErrInputSequenceTooLong means that pre-processing the input text
 // produced a sequence that exceeds the maximum allowed length.
 var ErrInputSequenceTooLong = errors.New("input sequence too long")
@@ -31,15 +51,60 @@ type Interface interface {
 	Classify(ctx context.Context, text string) (Response, error)
 }
 
+// PairInterface defines the main functions for sentence-pair classification task,
+// such as Natural Language Inference, paraphrase identification and Semantic Textual Similarity.
+type PairInterface interface {
+	// ClassifyPair returns the classification of the given pair of texts.
+	ClassifyPair(ctx context.Context, text, textPair string) (Response, error)
+}
+
 // Response contains the response from text classification.
 type Response struct {
 	// The list of labels sent in the request, sorted in descending order
 	// by probability that the input corresponds to the label.
 	Labels []string
 	// a list of floats that correspond the probability of label, in the same order as labels.
+	// Models with a single output (e.g. for Semantic Textual Similarity) return a single label,
+	// whose score is the output of the regression models, or its sigmoid otherwise.
 	Scores []float64
 }
 
+// ResponseFromLogits returns the Response for the logits of a classification model.
+// The single output of the regression models (see ProblemTypeRegression) is returned
+// as it is, while that of the other models with a single output (e.g. cross-encoders
+// trained with binary cross-entropy) is squashed with the sigmoid function into a
+// score between 0 and 1, like sentence-transformers does; otherwise the labels
+// are sorted by their softmax probability.
+func ResponseFromLogits(logits mat.Matrix, labels []string, regression bool) Response {
+	if logits.Size() == 1 {
+		label := "LABEL_0"
+		if len(labels) > 0 {
+			label = labels[0]
+		}
+		score := logits.ScalarAt(0).F64()
+		if !regression {
+			score = 1 / (1 + math.Exp(-score))
+		}
+		return Response{
+			Labels: []string{label},
+			Scores: []float64{score},
+		}
+	}
+
+	result := sliceutils.NewIndexedSlice[float64](logits.Softmax().Data().F64())
+	sort.Stable(sort.Reverse(result))
+
+	sortedLabels := make([]string, len(labels))
+	for i, ii := range result.Indices {
+		sortedLabels[i] = labels[ii]
+	}
+
+	return Response{
+		Labels: sortedLabels,
+		Scores: result.Slice,
+	}
+}
+
 // Filter returns a function to filter the classification response with respect to two parameters, keepThreshold and
 // keepSumThreshold, which are used to check whether to consider the single prediction, and to check whether the sum
 // of all collected prediction scores allows a result to be returned or not, respectively.
@@ -0,0 +1,40 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package textclassification
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func TestResponseFromLogits(t *testing.T) {
+	t.Run("single output", func(t *testing.T) {
+		got := ResponseFromLogits(mat.NewDense[float64](mat.WithBacking([]float64{math.Log(3)})), nil, false)
+		if !reflect.DeepEqual(got.Labels, []string{"LABEL_0"}) || math.Abs(got.Scores[0]-0.75) > 1e-9 {
+			t.Errorf("got %+v, want LABEL_0 with score 0.75", got)
+		}
+	})
+
+	t.Run("regression", func(t *testing.T) {
+		got := ResponseFromLogits(mat.NewDense[float64](mat.WithBacking([]float64{4.2})), []string{"similarity"}, true)
+		if !reflect.DeepEqual(got.Labels, []string{"similarity"}) || math.Abs(got.Scores[0]-4.2) > 1e-9 {
+			t.Errorf("got %+v, want similarity with score 4.2", got)
+		}
+	})
+
+	t.Run("multiple outputs", func(t *testing.T) {
+		logits := mat.NewDense[float64](mat.WithBacking([]float64{0, math.Log(3)}))
+		got := ResponseFromLogits(logits, []string{"negative", "positive"}, false)
+		if !reflect.DeepEqual(got.Labels, []string{"positive", "negative"}) {
+			t.Errorf("got labels %v, want positive first", got.Labels)
+		}
+		if math.Abs(got.Scores[0]-0.75) > 1e-9 || math.Abs(got.Scores[1]-0.25) > 1e-9 {
+			t.Errorf("got scores %v, want [0.75 0.25]", got.Scores)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package roberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textencoding"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ textencoding.Interface = &TextEncoding{}
+
+// TextEncoding is a text encoding model based on RoBERTa.
+type TextEncoding struct {
+	// Model is the model used to encode the text.
+	Model *bert.ModelForSequenceEncoding
+	// Tokenizer is the byte-level BPE tokenizer used to tokenize the text.
+	Tokenizer *bpetokenizer.BPETokenizer
+}
+
+// LoadTextEncoding returns a TextEncoding loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextEncoding(modelPath string) (*TextEncoding, error) {
+	tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for text encoding: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForSequenceEncoding](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roberta model: %w", err)
+	}
+
+	return &TextEncoding{
+		Model:     m,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// Encode returns the dense encoded representation of the given text.
+func (m *TextEncoding) Encode(_ context.Context, text string, poolingStrategy int) (textencoding.Response, error) {
+	tokenized, err := m.tokenize(text)
+	if err != nil {
+		return textencoding.Response{}, err
+	}
+	if l, k := len(tokenized), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return textencoding.Response{}, fmt.Errorf("%w: %d > %d", textencoding.ErrInputSequenceTooLong, l, k)
+	}
+	encoded, err := m.Model.Encode(tokenized, bert.PoolingStrategyType(poolingStrategy))
+	if err != nil {
+		return textencoding.Response{}, err
+	}
+
+	response := textencoding.Response{
+		Vector: encoded.Value().(mat.Matrix),
+	}
+	return response, nil
+}
+
+// tokenize returns the tokens of the given text (including padding tokens).
+func (m *TextEncoding) tokenize(text string) ([]string, error) {
+	tokens, err := m.Tokenizer.Tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	cls := bpetokenizer.DefaultClassToken
+	sep := bpetokenizer.DefaultSequenceSeparator
+	return append([]string{cls}, append(tokenizers.GetStrings(tokens), sep)...), nil
+}
@@ -23,6 +23,11 @@ const (
 	// It doesn't perform so well for assessing the similarity of sentence pairs that are not translations of each other.
 	// Model card: https://huggingface.co/sentence-transformers/LaBSE
 	DefaultModelMulti = "sentence-transformers/LaBSE"
+
+	// DefaultModelMPNet is a sentence-transformers model based on MPNet, to be used with the MeanPooling strategy.
+	// It provides higher quality embeddings than DefaultModel, at a higher computational cost.
+	// Model card: https://huggingface.co/sentence-transformers/all-mpnet-base-v2
+	DefaultModelMPNet = "sentence-transformers/all-mpnet-base-v2"
 )
 
 // ErrInputSequenceTooLong means that pre-processing the input text
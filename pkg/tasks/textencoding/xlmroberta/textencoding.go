@@ -0,0 +1,76 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xlmroberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textencoding"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+const (
+	// defaultClassToken is the XLM-RoBERTa class token, at the beginning of the sequence.
+	defaultClassToken = "<s>"
+	// defaultSequenceSeparator is the XLM-RoBERTa separator token, at the end of the sequence.
+	defaultSequenceSeparator = "</s>"
+)
+
+var _ textencoding.Interface = &TextEncoding{}
+
+// TextEncoding is a text encoding model based on XLM-RoBERTa.
+type TextEncoding struct {
+	// Model is the model used to encode the text.
+	Model *bert.ModelForSequenceEncoding
+	// Tokenizer is the sentence-piece tokenizer used to tokenize the text.
+	Tokenizer *sentencepiece.Tokenizer
+}
+
+// LoadTextEncoding returns a TextEncoding loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextEncoding(modelPath string) (*TextEncoding, error) {
+	tokenizer, err := sentencepiece.NewFairseqFromFile(path.Join(modelPath, "sentencepiece.bpe.model"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for text encoding: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForSequenceEncoding](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load xlm-roberta model: %w", err)
+	}
+
+	return &TextEncoding{
+		Model:     m,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// Encode returns the dense encoded representation of the given text.
+func (m *TextEncoding) Encode(_ context.Context, text string, poolingStrategy int) (textencoding.Response, error) {
+	tokenized := m.tokenize(text)
+	if l, k := len(tokenized), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return textencoding.Response{}, fmt.Errorf("%w: %d > %d", textencoding.ErrInputSequenceTooLong, l, k)
+	}
+	encoded, err := m.Model.Encode(tokenized, bert.PoolingStrategyType(poolingStrategy))
+	if err != nil {
+		return textencoding.Response{}, err
+	}
+
+	response := textencoding.Response{
+		Vector: encoded.Value().(mat.Matrix),
+	}
+	return response, nil
+}
+
+// tokenize returns the tokens of the given text (including padding tokens).
+// The pieces missing from the vocabulary are replaced with the unknown token.
+func (m *TextEncoding) tokenize(text string) []string {
+	tokens := m.Tokenizer.IDsToTokens(m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)))
+	return append([]string{defaultClassToken}, append(tokens, defaultSequenceSeparator)...)
+}
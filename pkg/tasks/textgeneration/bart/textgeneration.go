@@ -7,7 +7,6 @@ package bart
 import (
 	"context"
 	"fmt"
-	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -23,7 +22,10 @@ import (
 	"github.com/nlpodyssey/spago/nn/embedding"
 )
 
-var _ textgeneration.Interface = &TextGeneration{}
+var (
+	_ textgeneration.Interface       = &TextGeneration{}
+	_ textgeneration.StreamInterface = &TextGeneration{}
+)
 
 // TextGeneration contains the ModelForConditionalGeneration and the Tokenizer
 // used for conditional generation tasks.
@@ -40,6 +42,20 @@ type Tokenizer interface {
 	Detokenize(tokenIds []int, stripPaddingTokens bool) string
 }
 
+// TokenResolver is implemented by the tokenizers that can resolve the tokens
+// of the vocabulary to their IDs, as required by the logit bias option.
+type TokenResolver interface {
+	// TokenID returns the ID of a token of the vocabulary, and whether it exists.
+	TokenID(token string) (int, bool)
+}
+
+// PhraseTokenizer is implemented by the tokenizers that can tokenize a phrase
+// as part of a text, as required by the forced words option.
+type PhraseTokenizer interface {
+	// TokenizePhrase returns the token IDs of the phrase, without special tokens.
+	TokenizePhrase(text string) ([]int, error)
+}
+
 // LoadTextGeneration returns a TextGeneration loading the model, the embeddings and the tokenizer from a directory.
 func LoadTextGeneration(modelPath string) (*TextGeneration, error) {
 	m, err := nn.LoadFromFile[*bart.ModelForConditionalGeneration](path.Join(modelPath, "spago_model.bin"))
@@ -62,6 +78,9 @@ func LoadTextGeneration(modelPath string) (*TextGeneration, error) {
 }
 
 func resolveTokenizer(path string, config bart.Config) (Tokenizer, error) {
+	if config.ModelType == "m2m_100" || config.ModelType == "mbart" {
+		return loadMultilingualTokenizer(path, config)
+	}
 	if doesFileExist(filepath.Join(path, "spiece.model")) || doesFileExist(filepath.Join(path, "source.spm")) {
 		return loadSentencePieceTokenizer(path, config)
 	}
@@ -82,6 +101,51 @@ func loadSentencePieceTokenizer(path string, config bart.Config) (Tokenizer, err
 	}, nil
 }
 
+func loadMultilingualTokenizer(path string, config bart.Config) (Tokenizer, error) {
+	spmFilename := filepath.Join(path, "sentencepiece.bpe.model")
+	vocabFilename := filepath.Join(path, "vocab.json")
+
+	// M2M100 provides the vocabulary of the sentence-piece tokens, followed by
+	// the language-code tokens. NLLB and mBART-50 follow the fairseq dictionary
+	// instead, where the language-code tokens come right after the
+	// sentence-pieces, just before the final "<mask>" token.
+	var tok *sentencepiece.Tokenizer
+	var err error
+	var langOffset int
+	if doesFileExist(vocabFilename) {
+		tok, err = sentencepiece.NewFromFileWithVocabulary(spmFilename, vocabFilename, false)
+		if err == nil {
+			langOffset = tok.VocabSize()
+		}
+	} else {
+		tok, err = sentencepiece.NewFairseqFromFile(spmFilename, false)
+		if err == nil {
+			langOffset = tok.VocabSize() - 1
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sentencepiece tokenizer for text generation: %w", err)
+	}
+
+	langTokens, err := readLanguageTokens(filepath.Join(path, "special_tokens_map.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load language tokens for text generation: %w", err)
+	}
+	languages := make(map[string]int, len(langTokens))
+	for i, token := range langTokens {
+		languages[token] = langOffset + i
+	}
+
+	return &MultilingualSentencePieceTokenizer{
+		Tokenizer:           tok,
+		Languages:           languages,
+		EosTokenID:          config.EosTokenID,
+		BosTokenID:          config.BosTokenID,
+		PadTokenID:          config.PadTokenID,
+		DecoderStartTokenID: config.DecoderStartTokenID,
+	}, nil
+}
+
 func loadBPETokenizer(path string, config bart.Config) (Tokenizer, error) {
 	tok, err := bpetokenizer.NewFromModelFolder(path)
 	if err != nil {
@@ -106,6 +170,23 @@ func doesFileExist(fileName string) bool {
 
 // Generate generates a text from the input.
 func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textgeneration.Options) (textgeneration.Response, error) {
+	return m.generate(ctx, text, opts, nil)
+}
+
+// GenerateStream generates a text from the input, with greedy or sampling
+// decoding, calling fn with each token as soon as it is generated.
+func (m *TextGeneration) GenerateStream(ctx context.Context, text string, opts *textgeneration.Options, fn textgeneration.StreamFunc) (textgeneration.Response, error) {
+	detokenize := func(tokenIDs []int) string {
+		return m.Tokenizer.Detokenize(tokenIDs, true)
+	}
+	return textgeneration.Stream(ctx, detokenize, fn, func(ctx context.Context, onToken func(int)) (textgeneration.Response, error) {
+		return m.generate(ctx, text, opts, onToken)
+	})
+}
+
+// generate generates a text from the input. If onToken is not nil, the
+// decoding is streamed, calling it with each generated token.
+func (m *TextGeneration) generate(ctx context.Context, text string, opts *textgeneration.Options, onToken func(int)) (textgeneration.Response, error) {
 	if opts == nil {
 		opts = &textgeneration.Options{
 			Temperature: nullable.Type[float64]{Value: 1.0, Valid: true},
@@ -114,7 +195,7 @@ func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textge
 			TopP:        nullable.Type[float64]{Valid: false},
 		}
 	}
-	tokenized, err := m.Tokenizer.Tokenize(text)
+	tokenized, forcedBOS, err := m.tokenize(text, *opts)
 	if err != nil {
 		return textgeneration.Response{}, err
 	}
@@ -122,7 +203,23 @@ func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textge
 		return textgeneration.Response{}, fmt.Errorf("%w: %d > %d", textgeneration.ErrInputSequenceTooLong, l, k)
 	}
 
-	sequences, scores := m.process(ctx, tokenized, *opts)
+	config, err := m.decodingConfig(*opts)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+	config.ForcedBOSTokenID = forcedBOS
+	if onToken != nil {
+		if config, err = opts.StreamingConfig(config); err != nil {
+			return textgeneration.Response{}, err
+		}
+	}
+
+	scoreProc, err := opts.ScoreProcessor(m.tokenID(), config.NumBeams)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+
+	sequences, scores := opts.TopSequences(m.process(ctx, tokenized, config, scoreProc, opts.DecodingStrategy(), opts.PrefixAllowedTokens, onToken))
 	result := textgeneration.Response{
 		Texts:  make([]string, len(sequences)),
 		Scores: make([]float64, len(scores)),
@@ -133,13 +230,43 @@ func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textge
 	return result, nil
 }
 
-func (m *TextGeneration) process(ctx context.Context, inputIDs []int, opts textgeneration.Options) ([][]int, []float64) {
-	next := m.Model.DecodingFunc(inputIDs, m.logProbProcessor(opts), true)
-	cache := make([]bart.Cache, m.Model.Bart.Config.NumBeams)
+// tokenize returns the token IDs of the input text. For multilingual models,
+// it also returns the language-code token of the target language, to be
+// forced as the first generated token.
+func (m *TextGeneration) tokenize(text string, opts textgeneration.Options) ([]int, nullable.Type[int], error) {
+	tok, isMultilingual := m.Tokenizer.(MultilingualTokenizer)
+	if !isMultilingual {
+		if opts.SourceLanguage != "" || opts.TargetLanguage != "" {
+			return nil, nullable.Type[int]{}, fmt.Errorf("%w: the source and target languages are only supported by multilingual models", textgeneration.ErrInvalidOptions)
+		}
+		tokenized, err := m.Tokenizer.Tokenize(text)
+		return tokenized, nullable.Type[int]{}, err
+	}
+
+	if opts.SourceLanguage == "" {
+		return nil, nullable.Type[int]{}, fmt.Errorf("%w: %w", textgeneration.ErrInvalidOptions, ErrMissingSourceLanguage)
+	}
+	if opts.TargetLanguage == "" {
+		return nil, nullable.Type[int]{}, fmt.Errorf("%w: the target language is required by multilingual models", textgeneration.ErrInvalidOptions)
+	}
+	targetID, err := tok.LanguageID(opts.TargetLanguage)
+	if err != nil {
+		return nil, nullable.Type[int]{}, err
+	}
+	tokenized, err := tok.TokenizeWithLanguage(text, opts.SourceLanguage)
+	if err != nil {
+		return nil, nullable.Type[int]{}, err
+	}
+	return tokenized, nullable.Type[int]{Value: targetID, Valid: true}, nil
+}
+
+func (m *TextGeneration) process(ctx context.Context, inputIDs []int, config generationutils.Config, scoreProc generationutils.ScoreProcessor, selectNext generationutils.DecodingStrategyFunc, prefixAllowedTokens generationutils.PrefixAllowedTokensFunc, onToken func(int)) ([][]int, []float64) {
+	next := m.Model.DecodingFunc(inputIDs, true)
+	cache := make([]bart.Cache, config.NumBeams)
 
 	predictNext := func(decodingInputIDs [][]int, lastBeamIndices []int) []mat.Matrix {
 		cache = reorderCache(cache, lastBeamIndices)
-		batch := m.batch(decodingInputIDs, cache)
+		batch := m.batch(decodingInputIDs, cache, config.MaxLength)
 		logProbValues := make([]mat.Matrix, len(batch))
 
 		for i, result := range next(batch) {
@@ -149,9 +276,12 @@ func (m *TextGeneration) process(ctx context.Context, inputIDs []int, opts textg
 	}
 
 	decoder := &generationutils.BeamSearchDecoder{
-		Config:      decoderConfig(m.Model.Bart.Config),
-		PredictNext: predictNext,
-		SelectNext:  decodingStrategy(opts),
+		Config:              config,
+		PredictNext:         predictNext,
+		SelectNext:          selectNext,
+		OnToken:             onToken,
+		PrefixAllowedTokens: prefixAllowedTokens,
+		ProcessScores:       scoreProc,
 	}
 	return decoder.Decode(ctx)
 }
@@ -165,40 +295,33 @@ func reorderCache(cache []bart.Cache, lastBeamIndices []int) []bart.Cache {
 	return tmpCache
 }
 
-func (m *TextGeneration) batch(sequences [][]int, cache []bart.Cache) []*bart.DecodingInput {
+func (m *TextGeneration) batch(sequences [][]int, cache []bart.Cache, maxLength int) []*bart.DecodingInput {
 	batch := make([]*bart.DecodingInput, len(sequences))
 	for i, sequence := range sequences {
 		batch[i] = &bart.DecodingInput{
-			InputIDs: sequence[len(sequence)-1:],
-			Cache:    cache[i],
-			CurLen:   len(sequence),
+			InputIDs:  sequence[len(sequence)-1:],
+			Cache:     cache[i],
+			CurLen:    len(sequence),
+			MaxLength: maxLength,
 		}
 	}
 	return batch
 }
 
-func decodingStrategy(opts textgeneration.Options) generationutils.DecodingStrategyFunc {
-	if opts.Sample.Valid && opts.Sample.Value {
-		return generationutils.SelectNextMultinomial
+// tokenID returns the function resolving the tokens to their IDs, or nil if
+// the tokenizer cannot resolve them.
+func (m *TextGeneration) tokenID() func(token string) (int, bool) {
+	if tok, ok := m.Tokenizer.(TokenResolver); ok {
+		return tok.TokenID
 	}
-	return generationutils.SelectNextTopK
+	return nil
 }
 
-// logProbProcessor returns a function that processes the log-probabilities.
-func (m *TextGeneration) logProbProcessor(opts textgeneration.Options) generationutils.ScoreProcessor {
-	procs := make([]generationutils.ScoreProcessor, 0, 3)
-	if opts.Temperature.Valid {
-		procs = append(procs, generationutils.TemperatureProcessor(opts.Temperature.Value))
-	}
-	if opts.TopK.Valid {
-		procs = append(procs, generationutils.TopKProcessor(opts.TopK.Value, math.Inf(-1)))
-	}
-	if opts.TopP.Valid {
-		minSize := 1
-		if m.Model.Bart.Config.NumBeams > 1 {
-			minSize = 2
-		}
-		procs = append(procs, generationutils.TopPProcessor(opts.TopP.Value, math.Inf(-1), minSize))
+// tokenizePhrase returns the token IDs of a phrase of the forced words.
+func (m *TextGeneration) tokenizePhrase(text string) ([]int, error) {
+	tok, ok := m.Tokenizer.(PhraseTokenizer)
+	if !ok {
+		return nil, fmt.Errorf("%w: the forced words are not supported by the model tokenizer", textgeneration.ErrInvalidOptions)
 	}
-	return generationutils.ProcessScores(procs...)
+	return tok.TokenizePhrase(text)
 }
@@ -7,6 +7,7 @@ package bart
 import (
 	"github.com/nlpodyssey/cybertron/pkg/generationutils"
 	"github.com/nlpodyssey/cybertron/pkg/models/bart"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
 )
 
 // decoderConfig converts the Bart model Config to a generationutils.Config.
@@ -27,3 +28,24 @@ func decoderConfig(c bart.Config) generationutils.Config {
 		NoRepeatNGramSize:   c.NoRepeatNGramSize,
 	}
 }
+
+// decodingConfig returns the configuration of the decoding search for the
+// model, overridden by the options. The decoded sequences start with the
+// decoder start token, which is counted by the maximum length, and they
+// cannot exceed the maximum number of positions.
+func (m *TextGeneration) decodingConfig(opts textgeneration.Options) (generationutils.Config, error) {
+	config, err := opts.DecoderConfig(decoderConfig(m.Model.Bart.Config))
+	if err != nil {
+		return generationutils.Config{}, err
+	}
+	if opts.MaxNewTokens.Valid {
+		config.MaxLength = opts.MaxNewTokens.Value + 1
+	} else if opts.MaxLength.Valid {
+		config.MaxLength = opts.MaxLength.Value
+	}
+	config.MaxLength = min(config.MaxLength, m.Model.Bart.Config.MaxPositionEmbeddings)
+	if config.Constraints, err = opts.Constraints(m.tokenizePhrase); err != nil {
+		return generationutils.Config{}, err
+	}
+	return config, nil
+}
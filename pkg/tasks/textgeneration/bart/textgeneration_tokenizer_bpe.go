@@ -30,6 +30,16 @@ func (m *BPETokenizer) Tokenize(text string) ([]int, error) {
 	return tokenized, nil
 }
 
+// TokenizePhrase returns the token IDs of the phrase, as a word following a
+// space, without special tokens.
+func (m *BPETokenizer) TokenizePhrase(text string) ([]int, error) {
+	encoded, err := m.BPETokenizer.Encode(" " + text)
+	if err != nil {
+		return nil, err
+	}
+	return encoded.IDs, nil
+}
+
 // Detokenize returns the text of the input token IDs removing the padding token.
 func (m *BPETokenizer) Detokenize(tokenIds []int, stripPaddingTokens bool) string {
 	if !stripPaddingTokens {
@@ -0,0 +1,145 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+)
+
+// ErrMissingSourceLanguage means that a multilingual tokenizer has been
+// used without specifying the language of the input text.
+var ErrMissingSourceLanguage = errors.New("bart: the source language is required by multilingual models")
+
+// MultilingualTokenizer is a Tokenizer for many-to-many translation models
+// (e.g. M2M100, NLLB and mBART-50), where each language is identified by a language-code token.
+type MultilingualTokenizer interface {
+	Tokenizer
+	// TokenizeWithLanguage returns the token IDs of the input text, written in the given language.
+	TokenizeWithLanguage(text, lang string) ([]int, error)
+	// LanguageID returns the ID of the language-code token of the given language.
+	LanguageID(lang string) (int, error)
+}
+
+var _ MultilingualTokenizer = &MultilingualSentencePieceTokenizer{}
+
+// MultilingualSentencePieceTokenizer is the sentence-piece tokenizer of
+// M2M100, NLLB and mBART-50 models.
+type MultilingualSentencePieceTokenizer struct {
+	*sentencepiece.Tokenizer
+	// Languages maps each language-code token (e.g. "__en__", "eng_Latn" or "en_XX") to its ID.
+	Languages           map[string]int
+	EosTokenID          int
+	BosTokenID          int
+	PadTokenID          int
+	DecoderStartTokenID int
+}
+
+// Tokenize always fails, since the source language is required.
+func (m *MultilingualSentencePieceTokenizer) Tokenize(_ string) ([]int, error) {
+	return nil, ErrMissingSourceLanguage
+}
+
+// TokenizeWithLanguage returns the token IDs of the input text, prefixed by the
+// language-code token and followed by the EOS token.
+func (m *MultilingualSentencePieceTokenizer) TokenizeWithLanguage(text, lang string) ([]int, error) {
+	langID, err := m.LanguageID(lang)
+	if err != nil {
+		return nil, err
+	}
+	ids := m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text))
+	result := make([]int, 0, len(ids)+2)
+	result = append(result, langID)
+	result = append(result, ids...)
+	return append(result, m.EosTokenID), nil
+}
+
+// LanguageID returns the ID of the language-code token of the given language.
+// The language can be given either as the token itself, or as the bare
+// language code of M2M100 models (e.g. "en" for "__en__").
+func (m *MultilingualSentencePieceTokenizer) LanguageID(lang string) (int, error) {
+	if id, ok := m.Languages[lang]; ok {
+		return id, nil
+	}
+	if id, ok := m.Languages["__"+lang+"__"]; ok {
+		return id, nil
+	}
+	return -1, fmt.Errorf("%w: unsupported language %#v", textgeneration.ErrInvalidOptions, lang)
+}
+
+// TokenID returns the ID of a token of the vocabulary, including the
+// language-code tokens, and whether it exists.
+func (m *MultilingualSentencePieceTokenizer) TokenID(token string) (int, bool) {
+	if id, ok := m.Languages[token]; ok {
+		return id, true
+	}
+	return m.Tokenizer.TokenID(token)
+}
+
+// TokenizePhrase returns the token IDs of the phrase, without special tokens.
+func (m *MultilingualSentencePieceTokenizer) TokenizePhrase(text string) ([]int, error) {
+	return m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)), nil
+}
+
+// Detokenize returns the text of the input token IDs removing the special
+// and language-code tokens.
+func (m *MultilingualSentencePieceTokenizer) Detokenize(tokenIds []int, stripPaddingTokens bool) string {
+	isLanguage := make(map[int]bool, len(m.Languages))
+	for _, id := range m.Languages {
+		isLanguage[id] = true
+	}
+
+	result := make([]int, 0, len(tokenIds))
+	for _, id := range tokenIds {
+		if isLanguage[id] || id >= m.Tokenizer.VocabSize() {
+			continue
+		}
+		if stripPaddingTokens && (id == m.EosTokenID || id == m.PadTokenID || id == m.BosTokenID || id == m.DecoderStartTokenID) {
+			continue
+		}
+		result = append(result, id)
+	}
+	return m.Tokenizer.Detokenize(m.Tokenizer.IDsToTokens(result))
+}
+
+// readLanguageTokens reads the language-code tokens from the
+// "additional_special_tokens" of a special tokens map JSON file.
+func readLanguageTokens(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var specialTokens struct {
+		AdditionalSpecialTokens []json.RawMessage `json:"additional_special_tokens"`
+	}
+	if err := json.Unmarshal(data, &specialTokens); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	tokens := make([]string, len(specialTokens.AdditionalSpecialTokens))
+	for i, raw := range specialTokens.AdditionalSpecialTokens {
+		// Each token is either a plain string, or an object with its content.
+		if err := json.Unmarshal(raw, &tokens[i]); err == nil {
+			continue
+		}
+		var token struct {
+			Content string `json:"content"`
+		}
+		if err := json.Unmarshal(raw, &token); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+		tokens[i] = strings.TrimSpace(token.Content)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no language tokens found in %s", filename)
+	}
+	return tokens, nil
+}
@@ -19,6 +19,11 @@ func (m *SentencePieceTokenizer) Tokenize(text string) ([]int, error) {
 	return append(m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)), m.EosTokenID), nil
 }
 
+// TokenizePhrase returns the token IDs of the phrase, without special tokens.
+func (m *SentencePieceTokenizer) TokenizePhrase(text string) ([]int, error) {
+	return m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)), nil
+}
+
 // Detokenize returns the text of the input token IDs removing the padding token.
 func (m *SentencePieceTokenizer) Detokenize(tokenIds []int, stripPaddingTokens bool) string {
 	if !stripPaddingTokens {
@@ -0,0 +1,151 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package textgeneration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+// DecoderOnly continues a prompt with a decoder-only model (e.g. GPT-2 or
+// Llama), whose cache of the past key-values is of type C.
+//
+// The decoding search starts from the last token of the prompt, as if it were
+// the decoder start token of an encoder-decoder model.
+type DecoderOnly[C any] struct {
+	// Config is the configuration of the decoding search of the model, whose
+	// MaxLength is the number of tokens to generate.
+	Config generationutils.Config
+	// MaxPositions is the maximum number of positions of the model.
+	MaxPositions int
+	// Predict is the forward function of the model.
+	Predict DecoderOnlyPredictFunc[C]
+	// TokenID returns the ID of a token of the vocabulary, for the logit bias.
+	TokenID func(token string) (int, bool)
+	// TokenizePhrase returns the token IDs of a phrase of the forced words.
+	TokenizePhrase func(text string) ([]int, error)
+	// Detokenize returns the text of the generated token IDs.
+	Detokenize func(tokenIDs []int) string
+}
+
+// DecoderOnlyInput is the input of a decoding step of a decoder-only model.
+type DecoderOnlyInput[C any] struct {
+	// InputIDs are the input IDs not processed yet: the whole prompt at the
+	// first step, then the last generated token.
+	InputIDs []int
+	// CurLen is the current length of the sequence, including the InputIDs.
+	CurLen int
+	// Cache is the cache of the previous steps, empty at the first step.
+	Cache C
+}
+
+// DecoderOnlyPredictFunc returns the log-probabilities of the next token of
+// each input, along with the next caches.
+type DecoderOnlyPredictFunc[C any] func(inputs []DecoderOnlyInput[C]) ([]mat.Matrix, []C)
+
+// Generate continues the prompt, returning only the generated continuation.
+// If onToken is not nil, the decoding is streamed, calling it with each
+// generated token.
+func (d *DecoderOnly[C]) Generate(ctx context.Context, prompt []int, opts *Options, onToken func(int)) (Response, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if l, k := len(prompt), d.MaxPositions; l >= k {
+		return Response{}, fmt.Errorf("%w: %d >= %d", ErrInputSequenceTooLong, l, k)
+	}
+
+	config, err := d.decodingConfig(*opts, prompt)
+	if err != nil {
+		return Response{}, err
+	}
+	if onToken != nil {
+		if config, err = opts.StreamingConfig(config); err != nil {
+			return Response{}, err
+		}
+	}
+
+	scoreProc, err := opts.ScoreProcessor(d.TokenID, config.NumBeams)
+	if err != nil {
+		return Response{}, err
+	}
+
+	sequences, scores := opts.TopSequences(d.decode(ctx, prompt, config, scoreProc, opts.DecodingStrategy(), opts.PrefixAllowedTokens, onToken))
+	result := Response{
+		Texts:  make([]string, len(sequences)),
+		Scores: make([]float64, len(scores)),
+	}
+	for i, sequence := range sequences {
+		result.Texts[i], result.Scores[i] = d.Detokenize(sequence[1:]), scores[i]
+	}
+	return result, nil
+}
+
+// decodingConfig returns the configuration of the decoding search for the
+// prompt, overridden by the options. The MaxLength option includes the prompt,
+// while the maximum length of the model configuration is the number of tokens
+// to generate, like MaxNewTokens. Both are limited by the maximum number of positions.
+func (d *DecoderOnly[C]) decodingConfig(opts Options, prompt []int) (generationutils.Config, error) {
+	maxNewTokens := d.Config.MaxLength
+	if opts.MaxNewTokens.Valid {
+		maxNewTokens = opts.MaxNewTokens.Value
+	} else if opts.MaxLength.Valid {
+		if opts.MaxLength.Value <= len(prompt) {
+			return generationutils.Config{}, fmt.Errorf("%w: the maximum length (%d) must exceed the length of the prompt (%d)",
+				ErrInvalidOptions, opts.MaxLength.Value, len(prompt))
+		}
+		maxNewTokens = opts.MaxLength.Value - len(prompt)
+	}
+
+	config := d.Config
+	config.IsEncoderDecoder = false
+	config.DecoderStartTokenID = prompt[len(prompt)-1]
+	config.MaxLength = min(maxNewTokens, d.MaxPositions-len(prompt)) + 1 // the last prompt token included
+	config, err := opts.DecoderConfig(config)
+	if err != nil {
+		return generationutils.Config{}, err
+	}
+	if config.Constraints, err = opts.Constraints(d.TokenizePhrase); err != nil {
+		return generationutils.Config{}, err
+	}
+	return config, nil
+}
+
+// decode runs the decoding search. All the prompt tokens but the last are
+// processed together at the first step, then the sequences grow from there.
+func (d *DecoderOnly[C]) decode(ctx context.Context, prompt []int, config generationutils.Config, scoreProc generationutils.ScoreProcessor, selectNext generationutils.DecodingStrategyFunc, prefixAllowedTokens generationutils.PrefixAllowedTokensFunc, onToken func(int)) ([][]int, []float64) {
+	var cache []C
+	pastLength := len(prompt) - 1
+
+	predictNext := func(decodingInputIDs [][]int, lastBeamIndices []int) []mat.Matrix {
+		inputs := make([]DecoderOnlyInput[C], len(decodingInputIDs))
+		for i, sequence := range decodingInputIDs {
+			inputs[i] = DecoderOnlyInput[C]{
+				InputIDs: sequence[len(sequence)-1:],
+				CurLen:   pastLength + len(sequence),
+			}
+			if cache == nil {
+				inputs[i].InputIDs = append(prompt[:pastLength:pastLength], sequence...)
+			} else {
+				inputs[i].Cache = cache[lastBeamIndices[i]]
+			}
+		}
+		var logProbs []mat.Matrix
+		logProbs, cache = d.Predict(inputs)
+		return logProbs
+	}
+
+	decoder := &generationutils.BeamSearchDecoder{
+		Config:              config,
+		PredictNext:         predictNext,
+		SelectNext:          selectNext,
+		OnToken:             onToken,
+		PrefixAllowedTokens: prefixAllowedTokens,
+		ProcessScores:       scoreProc,
+	}
+	return decoder.Decode(ctx)
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package textgeneration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/cybertron/pkg/utils/nullable"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func TestDecoderOnly_Generate(t *testing.T) {
+	const eosTokenID = 4
+	// The cache of the stub model is the whole sequence processed so far,
+	// and the most probable next token depends on it, so that any error in
+	// carrying the caches along the beams changes the generated tokens.
+	predict := func(inputs []DecoderOnlyInput[[]int]) ([]mat.Matrix, [][]int) {
+		logProbs := make([]mat.Matrix, len(inputs))
+		caches := make([][]int, len(inputs))
+		for i, input := range inputs {
+			caches[i] = append(append([]int{}, input.Cache...), input.InputIDs...)
+			if len(caches[i]) != input.CurLen {
+				t.Fatalf("got current length %d, want %d", input.CurLen, len(caches[i]))
+			}
+			sum := 0
+			for _, id := range caches[i] {
+				sum += id
+			}
+			probs := []float64{0.1, 0.1, 0.1, 0.1, 0.01}
+			probs[sum%4] = 0.69
+			values := make([]float64, len(probs))
+			for j, p := range probs {
+				values[j] = math.Log(p)
+			}
+			logProbs[i] = mat.NewDense[float64](mat.WithBacking(values))
+		}
+		return logProbs, caches
+	}
+	newDecoderOnly := func() *DecoderOnly[[]int] {
+		return &DecoderOnly[[]int]{
+			Config: generationutils.Config{
+				NumBeams:      1,
+				MaxLength:     3,
+				EOSTokenID:    eosTokenID,
+				LengthPenalty: 1,
+			},
+			MaxPositions: 10,
+			Predict:      predict,
+			Detokenize: func(tokenIDs []int) string {
+				if n := len(tokenIDs); n > 0 && tokenIDs[n-1] == eosTokenID {
+					tokenIDs = tokenIDs[:n-1]
+				}
+				return fmt.Sprint(tokenIDs)
+			},
+		}
+	}
+	prompt := []int{1, 2, 3}
+
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{"model max length", &Options{}, "[2 0 0]"},
+		{"max new tokens", &Options{MaxNewTokens: nullable.Type[int]{Value: 2, Valid: true}}, "[2 0]"},
+		{"max length with the prompt", &Options{MaxLength: nullable.Type[int]{Value: 4, Valid: true}}, "[2]"},
+		{"max positions", &Options{MaxNewTokens: nullable.Type[int]{Value: 20, Valid: true}}, "[2 0 0 0 0 0 0]"},
+		{"beams", &Options{NumBeams: nullable.Type[int]{Value: 3, Valid: true}}, "[2 0 0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newDecoderOnly().Generate(context.Background(), prompt, tt.opts, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Texts) == 0 || got.Texts[0] != tt.want {
+				t.Errorf("got %v, want %s first", got.Texts, tt.want)
+			}
+		})
+	}
+
+	t.Run("streaming", func(t *testing.T) {
+		var tokens []int
+		got, err := newDecoderOnly().Generate(context.Background(), prompt, &Options{}, func(tokenID int) {
+			tokens = append(tokens, tokenID)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []int{2, 0, 0}; !reflect.DeepEqual(tokens, want) || got.Texts[0] != fmt.Sprint(want) {
+			t.Errorf("got tokens %v and %v, want %v", tokens, got.Texts, want)
+		}
+	})
+
+	t.Run("max length within the prompt", func(t *testing.T) {
+		opts := &Options{MaxLength: nullable.Type[int]{Value: 3, Valid: true}}
+		if _, err := newDecoderOnly().Generate(context.Background(), prompt, opts, nil); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+		}
+	})
+
+	t.Run("prompt too long", func(t *testing.T) {
+		long := make([]int, 10)
+		if _, err := newDecoderOnly().Generate(context.Background(), long, nil, nil); !errors.Is(err, ErrInputSequenceTooLong) {
+			t.Errorf("got error %v, want %v", err, ErrInputSequenceTooLong)
+		}
+	})
+}
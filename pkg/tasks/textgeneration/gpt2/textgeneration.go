@@ -0,0 +1,136 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/gpt2"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var (
+	_ textgeneration.Interface       = &TextGeneration{}
+	_ textgeneration.StreamInterface = &TextGeneration{}
+)
+
+// TextGeneration contains the ModelForCausalLM and the Tokenizer
+// used to continue a text prompt (e.g. completion, autocomplete).
+type TextGeneration struct {
+	// Model is the model used for causal language modeling.
+	Model *gpt2.ModelForCausalLM
+	// Tokenizer is the tokenizer used for text generation.
+	Tokenizer *bpetokenizer.BPETokenizer
+}
+
+// LoadTextGeneration returns a TextGeneration loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextGeneration(modelPath string) (*TextGeneration, error) {
+	m, err := nn.LoadFromFile[*gpt2.ModelForCausalLM](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gpt2 model: %w", err)
+	}
+
+	tok, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bpe tokenizer for text generation: %w", err)
+	}
+
+	return &TextGeneration{
+		Model:     m,
+		Tokenizer: tok,
+	}, nil
+}
+
+// Generate continues the input text, returning only the generated continuation.
+func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textgeneration.Options) (textgeneration.Response, error) {
+	prompt, err := m.tokenize(text)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+	return m.decoderOnly().Generate(ctx, prompt, opts, nil)
+}
+
+// GenerateStream continues the input text like Generate, with greedy or
+// sampling decoding, calling fn with each token as soon as it is generated.
+func (m *TextGeneration) GenerateStream(ctx context.Context, text string, opts *textgeneration.Options, fn textgeneration.StreamFunc) (textgeneration.Response, error) {
+	prompt, err := m.tokenize(text)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+	return textgeneration.Stream(ctx, m.detokenize, fn, func(ctx context.Context, onToken func(int)) (textgeneration.Response, error) {
+		return m.decoderOnly().Generate(ctx, prompt, opts, onToken)
+	})
+}
+
+// tokenize returns the token IDs of the prompt. An empty prompt is replaced
+// by the beginning-of-sequence token.
+func (m *TextGeneration) tokenize(text string) ([]int, error) {
+	encoded, err := m.Tokenizer.Encode(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(encoded.IDs) == 0 {
+		return []int{m.Model.GPT2.Config.BosTokenID}, nil
+	}
+	return encoded.IDs, nil
+}
+
+// tokenizePhrase returns the token IDs of a phrase of the forced words, as a
+// word following a space.
+func (m *TextGeneration) tokenizePhrase(text string) ([]int, error) {
+	encoded, err := m.Tokenizer.Encode(" " + text)
+	if err != nil {
+		return nil, err
+	}
+	return encoded.IDs, nil
+}
+
+// detokenize returns the text of the token IDs, removing the end-of-sequence tokens.
+func (m *TextGeneration) detokenize(tokenIDs []int) string {
+	result := make([]int, 0, len(tokenIDs))
+	for _, id := range tokenIDs {
+		if id == m.Model.GPT2.Config.EosTokenID {
+			continue
+		}
+		result = append(result, id)
+	}
+	return m.Tokenizer.Decode(result)
+}
+
+// decoderOnly returns the textgeneration.DecoderOnly continuing the prompts with the model.
+func (m *TextGeneration) decoderOnly() *textgeneration.DecoderOnly[gpt2.Cache] {
+	return &textgeneration.DecoderOnly[gpt2.Cache]{
+		Config:         decoderConfig(m.Model.GPT2.Config),
+		MaxPositions:   m.Model.GPT2.Config.NPositions,
+		Predict:        m.predict,
+		TokenID:        m.Tokenizer.TokenID,
+		TokenizePhrase: m.tokenizePhrase,
+		Detokenize:     m.detokenize,
+	}
+}
+
+// predict returns the log-probabilities of the next token of each input,
+// along with the next caches.
+func (m *TextGeneration) predict(inputs []textgeneration.DecoderOnlyInput[gpt2.Cache]) ([]mat.Matrix, []gpt2.Cache) {
+	batch := make([]*gpt2.DecodingInput, len(inputs))
+	for i, input := range inputs {
+		batch[i] = &gpt2.DecodingInput{
+			InputIDs: input.InputIDs,
+			CurLen:   input.CurLen,
+			Cache:    input.Cache,
+		}
+	}
+	logProbs := make([]mat.Matrix, len(batch))
+	caches := make([]gpt2.Cache, len(batch))
+	for i, result := range m.Model.DecodingFunc()(batch) {
+		logProbs[i], caches[i] = result.LogProbValue, result.NextCache
+	}
+	return logProbs, caches
+}
@@ -0,0 +1,28 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gpt2
+
+import (
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/cybertron/pkg/models/gpt2"
+)
+
+// decoderConfig converts the GPT-2 model Config to a generationutils.Config,
+// whose MaxLength is the number of tokens to generate.
+func decoderConfig(c gpt2.Config) generationutils.Config {
+	return generationutils.Config{
+		NumBeams:          c.NumBeams,
+		MinLength:         c.MinLength,
+		MaxLength:         c.MaxLength,
+		BOSTokenID:        c.BosTokenID,
+		EOSTokenID:        c.EosTokenID,
+		PadTokenID:        c.EosTokenID,
+		VocabSize:         c.VocabSize,
+		LengthPenalty:     c.LengthPenalty,
+		EarlyStopping:     c.EarlyStopping,
+		BadWordsIDs:       c.BadWordsIDs,
+		NoRepeatNGramSize: c.NoRepeatNGramSize,
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package llama
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/llama"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var (
+	_ textgeneration.Interface       = &TextGeneration{}
+	_ textgeneration.StreamInterface = &TextGeneration{}
+)
+
+// TextGeneration contains the ModelForCausalLM and the Tokenizer
+// used to continue a text prompt (e.g. completion, chat, instruction following).
+type TextGeneration struct {
+	// Model is the model used for causal language modeling.
+	Model *llama.ModelForCausalLM
+	// Tokenizer is the tokenizer used for text generation.
+	Tokenizer *sentencepiece.Tokenizer
+}
+
+// LoadTextGeneration returns a TextGeneration loading the model and the tokenizer from a directory.
+func LoadTextGeneration(modelPath string) (*TextGeneration, error) {
+	m, err := nn.LoadFromFile[*llama.ModelForCausalLM](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load llama model: %w", err)
+	}
+
+	tok, err := sentencepiece.NewFromFile(path.Join(modelPath, "tokenizer.model"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sentencepiece tokenizer for text generation: %w", err)
+	}
+
+	return &TextGeneration{
+		Model:     m,
+		Tokenizer: tok,
+	}, nil
+}
+
+// Generate continues the input text, returning only the generated continuation.
+func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textgeneration.Options) (textgeneration.Response, error) {
+	prompt := m.tokenize(text)
+	return m.decoderOnly().Generate(ctx, prompt, opts, nil)
+}
+
+// GenerateStream continues the input text like Generate, with greedy or
+// sampling decoding, calling fn with each token as soon as it is generated.
+func (m *TextGeneration) GenerateStream(ctx context.Context, text string, opts *textgeneration.Options, fn textgeneration.StreamFunc) (textgeneration.Response, error) {
+	prompt := m.tokenize(text)
+	return textgeneration.Stream(ctx, m.detokenize, fn, func(ctx context.Context, onToken func(int)) (textgeneration.Response, error) {
+		return m.decoderOnly().Generate(ctx, prompt, opts, onToken)
+	})
+}
+
+// tokenize returns the token IDs of the prompt, preceded by the beginning-of-sequence token.
+func (m *TextGeneration) tokenize(text string) []int {
+	ids := []int{m.Model.Llama.Config.BosTokenID}
+	if text == "" {
+		return ids
+	}
+	return append(ids, m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text))...)
+}
+
+// tokenizePhrase returns the token IDs of a phrase of the forced words.
+func (m *TextGeneration) tokenizePhrase(text string) ([]int, error) {
+	return m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)), nil
+}
+
+// detokenize returns the text of the token IDs, removing the special tokens.
+func (m *TextGeneration) detokenize(tokenIDs []int) string {
+	config := m.Model.Llama.Config
+	vocabSize := m.Tokenizer.VocabSize()
+	result := make([]int, 0, len(tokenIDs))
+	for _, id := range tokenIDs {
+		if id == config.EosTokenID || id == config.BosTokenID || id >= vocabSize {
+			continue
+		}
+		result = append(result, id)
+	}
+	return m.Tokenizer.Detokenize(m.Tokenizer.IDsToTokens(result))
+}
+
+// decoderOnly returns the textgeneration.DecoderOnly continuing the prompts with the model.
+func (m *TextGeneration) decoderOnly() *textgeneration.DecoderOnly[llama.Cache] {
+	return &textgeneration.DecoderOnly[llama.Cache]{
+		Config:         decoderConfig(m.Model.Llama.Config),
+		MaxPositions:   m.Model.Llama.Config.MaxPositionEmbeddings,
+		Predict:        m.predict,
+		TokenID:        m.Tokenizer.TokenID,
+		TokenizePhrase: m.tokenizePhrase,
+		Detokenize:     m.detokenize,
+	}
+}
+
+// predict returns the log-probabilities of the next token of each input,
+// along with the next caches.
+func (m *TextGeneration) predict(inputs []textgeneration.DecoderOnlyInput[llama.Cache]) ([]mat.Matrix, []llama.Cache) {
+	batch := make([]*llama.DecodingInput, len(inputs))
+	for i, input := range inputs {
+		batch[i] = &llama.DecodingInput{
+			InputIDs: input.InputIDs,
+			CurLen:   input.CurLen,
+			Cache:    input.Cache,
+		}
+	}
+	logProbs := make([]mat.Matrix, len(batch))
+	caches := make([]llama.Cache, len(batch))
+	for i, result := range m.Model.DecodingFunc()(batch) {
+		logProbs[i], caches[i] = result.LogProbValue, result.NextCache
+	}
+	return logProbs, caches
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package textgeneration
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// Token is a token produced by a streaming text generation.
+type Token struct {
+	// ID is the token ID.
+	ID int
+	// Text is the text added by the token to the generated text. It is empty
+	// for special tokens, and for tokens whose bytes are not yet a complete
+	// character: their text comes with the following tokens.
+	Text string
+}
+
+// StreamFunc is called with each token of a streaming text generation.
+type StreamFunc func(token Token) error
+
+// GenerateFunc generates text, calling onToken with each generated token ID.
+type GenerateFunc func(ctx context.Context, onToken func(tokenID int)) (Response, error)
+
+// Stream runs the generate function, sending each generated token to fn along
+// with the text it adds, which is computed by detokenizing the tokens whose text
+// is pending after the last tokens already sent. As soon as fn returns an error,
+// the context given to the generate function is canceled, and the error is returned.
+func Stream(ctx context.Context, detokenize func(tokenIDs []int) string, fn StreamFunc, generate GenerateFunc) (Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s := &streamer{detokenize: detokenize}
+	var streamErr error
+	result, err := generate(ctx, func(tokenID int) {
+		if streamErr != nil {
+			return
+		}
+		if streamErr = fn(s.next(tokenID)); streamErr != nil {
+			cancel()
+		}
+	})
+	if err != nil {
+		return Response{}, err
+	}
+	if streamErr != nil {
+		return Response{}, streamErr
+	}
+	return result, nil
+}
+
+// streamer keeps track of the tokens whose text is pending, which are
+// detokenized along with the last tokens already sent, as their context.
+type streamer struct {
+	detokenize func(tokenIDs []int) string
+	// tokenIDs are the tokens of the context followed by the pending ones.
+	tokenIDs []int
+	// numContext is the number of tokens of the context.
+	numContext int
+}
+
+// next returns the Token for the given token ID, appending it to the pending
+// ones. The text of an incomplete character is held back until it is completed.
+func (s *streamer) next(tokenID int) Token {
+	s.tokenIDs = append(s.tokenIDs, tokenID)
+	contextText := ""
+	if s.numContext > 0 {
+		contextText = s.detokenize(s.tokenIDs[:s.numContext])
+	}
+	text := s.detokenize(s.tokenIDs)
+	if r, _ := utf8.DecodeLastRuneInString(text); r == utf8.RuneError || len(text) <= len(contextText) {
+		return Token{ID: tokenID}
+	}
+	// the tokens just sent are the context of the next ones
+	s.tokenIDs = append(s.tokenIDs[:0], s.tokenIDs[s.numContext:]...)
+	s.numContext = len(s.tokenIDs)
+	return Token{ID: tokenID, Text: text[len(contextText):]}
+}
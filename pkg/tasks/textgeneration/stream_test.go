@@ -0,0 +1,127 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package textgeneration
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	// The "è" character is split over the tokens 3 and 4, and the token 0 is special.
+	pieces := map[int]string{0: "", 1: "Hello", 2: " caff", 3: "\xc3", 4: "\xa8", 5: "!"}
+	detokenize := func(tokenIDs []int) string {
+		var sb strings.Builder
+		for _, id := range tokenIDs {
+			sb.WriteString(pieces[id])
+		}
+		return strings.ToValidUTF8(sb.String(), "�")
+	}
+	generateIDs := func(ids ...int) GenerateFunc {
+		return func(ctx context.Context, onToken func(tokenID int)) (Response, error) {
+			for _, id := range ids {
+				if ctx.Err() != nil {
+					return Response{Texts: []string{"partial"}}, nil
+				}
+				onToken(id)
+			}
+			return Response{Texts: []string{detokenize(ids)}, Scores: []float64{-1}}, nil
+		}
+	}
+
+	t.Run("tokens", func(t *testing.T) {
+		var got []Token
+		result, err := Stream(context.Background(), detokenize, func(token Token) error {
+			got = append(got, token)
+			return nil
+		}, generateIDs(0, 1, 2, 3, 4, 5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Token{{0, ""}, {1, "Hello"}, {2, " caff"}, {3, ""}, {4, "è"}, {5, "!"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got tokens %v, want %v", got, want)
+		}
+		if result.Texts[0] != "Hello caffè!" {
+			t.Errorf("got result %q", result.Texts[0])
+		}
+	})
+
+	t.Run("word boundaries", func(t *testing.T) {
+		// Like sentence-piece, the word boundaries become spaces, except at the beginning of the text.
+		words := map[int]string{1: "▁Hello", 2: "▁caff", 3: "è", 4: "▁world"}
+		detokenize := func(tokenIDs []int) string {
+			var sb strings.Builder
+			for _, id := range tokenIDs {
+				sb.WriteString(strings.ReplaceAll(words[id], "▁", " "))
+			}
+			return strings.TrimPrefix(sb.String(), " ")
+		}
+		var got []string
+		_, err := Stream(context.Background(), detokenize, func(token Token) error {
+			got = append(got, token.Text)
+			return nil
+		}, generateIDs(1, 2, 3, 4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"Hello", " caff", "è", " world"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("bounded detokenization", func(t *testing.T) {
+		longest := 0
+		countingDetokenize := func(tokenIDs []int) string {
+			longest = max(longest, len(tokenIDs))
+			return detokenize(tokenIDs)
+		}
+		ids := make([]int, 0, 300)
+		for i := 0; i < 100; i++ {
+			ids = append(ids, 2, 3, 4)
+		}
+		var sb strings.Builder
+		_, err := Stream(context.Background(), countingDetokenize, func(token Token) error {
+			sb.WriteString(token.Text)
+			return nil
+		}, generateIDs(ids...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strings.Repeat(" caffè", 100); sb.String() != want {
+			t.Errorf("got text %q, want %q", sb.String(), want)
+		}
+		if longest > 3 {
+			t.Errorf("got %d tokens detokenized at once, want at most 3", longest)
+		}
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		n := 0
+		_, err := Stream(context.Background(), detokenize, func(token Token) error {
+			n++
+			return errStop
+		}, generateIDs(1, 2, 5))
+		if !errors.Is(err, errStop) {
+			t.Errorf("got error %v, want %v", err, errStop)
+		}
+		if n != 1 {
+			t.Errorf("got %d calls, want 1", n)
+		}
+	})
+
+	t.Run("generate error", func(t *testing.T) {
+		errGenerate := errors.New("generate")
+		_, err := Stream(context.Background(), detokenize, func(Token) error { return nil },
+			func(context.Context, func(int)) (Response, error) { return Response{}, errGenerate })
+		if !errors.Is(err, errGenerate) {
+			t.Errorf("got error %v, want %v", err, errGenerate)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/cybertron/pkg/models/t5"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+	"github.com/nlpodyssey/spago/nn/embedding"
+)
+
+var (
+	_ textgeneration.Interface       = &TextGeneration{}
+	_ textgeneration.StreamInterface = &TextGeneration{}
+)
+
+// TextGeneration contains the ModelForConditionalGeneration and the Tokenizer
+// used for conditional generation tasks with T5 and Flan-T5 models.
+// For example, Summarization, Translation and instruction following.
+type TextGeneration struct {
+	// Model is the model used for conditional generation.
+	Model *t5.ModelForConditionalGeneration
+	// Tokenizer is the tokenizer used for conditional generation.
+	Tokenizer *Tokenizer
+}
+
+// LoadTextGeneration returns a TextGeneration loading the model, the embeddings and the tokenizer from a directory.
+func LoadTextGeneration(modelPath string) (*TextGeneration, error) {
+	m, err := nn.LoadFromFile[*t5.ModelForConditionalGeneration](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load t5 model: %w", err)
+	}
+
+	m.T5.Encoder.Embeddings = embedding.Shared{Model: m.T5.Embeddings}
+	m.T5.Decoder.Embeddings = embedding.Shared{Model: m.T5.Embeddings}
+
+	tok, err := sentencepiece.NewFromFile(path.Join(modelPath, "spiece.model"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sentencepiece tokenizer for text generation: %w", err)
+	}
+
+	return &TextGeneration{
+		Model: m,
+		Tokenizer: &Tokenizer{
+			Tokenizer:           tok,
+			EosTokenID:          m.T5.Config.EosTokenID,
+			PadTokenID:          m.T5.Config.PadTokenID,
+			DecoderStartTokenID: m.T5.Config.DecoderStartTokenID,
+		},
+	}, nil
+}
+
+// Generate generates a text from the input.
+func (m *TextGeneration) Generate(ctx context.Context, text string, opts *textgeneration.Options) (textgeneration.Response, error) {
+	return m.generate(ctx, text, opts, nil)
+}
+
+// GenerateStream generates a text from the input, with greedy or sampling
+// decoding, calling fn with each token as soon as it is generated.
+func (m *TextGeneration) GenerateStream(ctx context.Context, text string, opts *textgeneration.Options, fn textgeneration.StreamFunc) (textgeneration.Response, error) {
+	detokenize := func(tokenIDs []int) string {
+		return m.Tokenizer.Detokenize(tokenIDs, true)
+	}
+	return textgeneration.Stream(ctx, detokenize, fn, func(ctx context.Context, onToken func(int)) (textgeneration.Response, error) {
+		return m.generate(ctx, text, opts, onToken)
+	})
+}
+
+// generate generates a text from the input. If onToken is not nil, the
+// decoding is streamed, calling it with each generated token.
+func (m *TextGeneration) generate(ctx context.Context, text string, opts *textgeneration.Options, onToken func(int)) (textgeneration.Response, error) {
+	if opts == nil {
+		opts = textgeneration.DefaultOptions()
+	}
+	tokenized, err := m.Tokenizer.Tokenize(text)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+	if l, k := len(tokenized), m.Model.T5.Config.NPositions; l > k {
+		return textgeneration.Response{}, fmt.Errorf("%w: %d > %d", textgeneration.ErrInputSequenceTooLong, l, k)
+	}
+
+	config, err := m.decodingConfig(*opts)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+	if onToken != nil {
+		if config, err = opts.StreamingConfig(config); err != nil {
+			return textgeneration.Response{}, err
+		}
+	}
+
+	scoreProc, err := opts.ScoreProcessor(m.Tokenizer.TokenID, config.NumBeams)
+	if err != nil {
+		return textgeneration.Response{}, err
+	}
+
+	sequences, scores := opts.TopSequences(m.process(ctx, tokenized, config, scoreProc, opts.DecodingStrategy(), opts.PrefixAllowedTokens, onToken))
+	result := textgeneration.Response{
+		Texts:  make([]string, len(sequences)),
+		Scores: make([]float64, len(scores)),
+	}
+	for i, sequence := range sequences {
+		result.Texts[i], result.Scores[i] = m.Tokenizer.Detokenize(sequence, true), scores[i]
+	}
+	return result, nil
+}
+
+func (m *TextGeneration) process(ctx context.Context, inputIDs []int, config generationutils.Config, scoreProc generationutils.ScoreProcessor, selectNext generationutils.DecodingStrategyFunc, prefixAllowedTokens generationutils.PrefixAllowedTokensFunc, onToken func(int)) ([][]int, []float64) {
+	next := m.Model.DecodingFunc(inputIDs, true)
+	cache := make([]t5.Cache, config.NumBeams)
+
+	predictNext := func(decodingInputIDs [][]int, lastBeamIndices []int) []mat.Matrix {
+		cache = reorderCache(cache, lastBeamIndices)
+		batch := m.batch(decodingInputIDs, cache, config.MaxLength)
+		logProbValues := make([]mat.Matrix, len(batch))
+
+		for i, result := range next(batch) {
+			logProbValues[i], cache[i] = result.LogProbValue, result.NextCache
+		}
+		return logProbValues
+	}
+
+	decoder := &generationutils.BeamSearchDecoder{
+		Config:              config,
+		PredictNext:         predictNext,
+		SelectNext:          selectNext,
+		OnToken:             onToken,
+		PrefixAllowedTokens: prefixAllowedTokens,
+		ProcessScores:       scoreProc,
+	}
+	return decoder.Decode(ctx)
+}
+
+// reorderCache reorders the cache according to the last beam indices.
+func reorderCache(cache []t5.Cache, lastBeamIndices []int) []t5.Cache {
+	tmpCache := make([]t5.Cache, len(cache))
+	for i, beamIndex := range lastBeamIndices {
+		tmpCache[i] = cache[beamIndex]
+	}
+	return tmpCache
+}
+
+func (m *TextGeneration) batch(sequences [][]int, cache []t5.Cache, maxLength int) []*t5.DecodingInput {
+	batch := make([]*t5.DecodingInput, len(sequences))
+	for i, sequence := range sequences {
+		batch[i] = &t5.DecodingInput{
+			InputIDs:  sequence[len(sequence)-1:],
+			Cache:     cache[i],
+			CurLen:    len(sequence),
+			MaxLength: maxLength,
+		}
+	}
+	return batch
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import (
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/cybertron/pkg/models/t5"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/textgeneration"
+)
+
+// decoderConfig converts the T5 model Config to a generationutils.Config.
+func decoderConfig(c t5.Config) generationutils.Config {
+	return generationutils.Config{
+		NumBeams:            c.NumBeams,
+		MinLength:           c.MinLength,
+		MaxLength:           c.MaxLength,
+		IsEncoderDecoder:    c.IsEncoderDecoder,
+		BOSTokenID:          -1,
+		EOSTokenID:          c.EosTokenID,
+		PadTokenID:          c.PadTokenID,
+		VocabSize:           c.VocabSize,
+		DecoderStartTokenID: c.DecoderStartTokenID,
+		LengthPenalty:       c.LengthPenalty,
+		EarlyStopping:       c.EarlyStopping,
+		BadWordsIDs:         c.BadWordsIDs,
+		NoRepeatNGramSize:   c.NoRepeatNGramSize,
+	}
+}
+
+// decodingConfig returns the configuration of the decoding search for the
+// model, overridden by the options. The decoded sequences start with the
+// decoder start token, which is counted by the maximum length.
+func (m *TextGeneration) decodingConfig(opts textgeneration.Options) (generationutils.Config, error) {
+	config, err := opts.DecoderConfig(decoderConfig(m.Model.T5.Config))
+	if err != nil {
+		return generationutils.Config{}, err
+	}
+	if opts.MaxNewTokens.Valid {
+		config.MaxLength = opts.MaxNewTokens.Value + 1
+	} else if opts.MaxLength.Valid {
+		config.MaxLength = opts.MaxLength.Value
+	}
+	if config.Constraints, err = opts.Constraints(m.Tokenizer.TokenizePhrase); err != nil {
+		return generationutils.Config{}, err
+	}
+	return config, nil
+}
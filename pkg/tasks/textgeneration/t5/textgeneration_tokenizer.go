@@ -0,0 +1,44 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package t5
+
+import "github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+
+// Tokenizer is the T5 sentence-piece tokenizer.
+type Tokenizer struct {
+	*sentencepiece.Tokenizer
+	EosTokenID          int
+	PadTokenID          int
+	DecoderStartTokenID int
+}
+
+// Tokenize returns the token IDs of the input text applying the EOS token.
+func (m *Tokenizer) Tokenize(text string) ([]int, error) {
+	return append(m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)), m.EosTokenID), nil
+}
+
+// TokenizePhrase returns the token IDs of the phrase, without special tokens.
+func (m *Tokenizer) TokenizePhrase(text string) ([]int, error) {
+	return m.Tokenizer.TokensToIDs(m.Tokenizer.Tokenize(text)), nil
+}
+
+// Detokenize returns the text of the input token IDs.
+// If stripPaddingTokens is true, it removes the special tokens, including the
+// sentinel tokens (<extra_id_N>) that are not part of the sentence-piece vocabulary.
+func (m *Tokenizer) Detokenize(tokenIds []int, stripPaddingTokens bool) string {
+	if !stripPaddingTokens {
+		return m.Tokenizer.Detokenize(m.Tokenizer.IDsToTokens(tokenIds))
+	}
+
+	vocabSize := m.Tokenizer.VocabSize()
+	result := make([]int, 0, len(tokenIds))
+	for _, id := range tokenIds {
+		if id == m.EosTokenID || id == m.PadTokenID || id == m.DecoderStartTokenID || id >= vocabSize {
+			continue
+		}
+		result = append(result, id)
+	}
+	return m.Tokenizer.Detokenize(m.Tokenizer.IDsToTokens(result))
+}
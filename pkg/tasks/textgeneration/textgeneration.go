@@ -8,8 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
 	"github.com/nlpodyssey/cybertron/pkg/utils/nullable"
 )
 
@@ -44,6 +46,18 @@ const (
 	// DefaultModelForKeywordsGeneration is a text generation model that produces a concatenated sequence of keyphrases.
 	// Model card: https://huggingface.co/bloomberg/KeyBART
 	DefaultModelForKeywordsGeneration = "bloomberg/KeyBART"
+
+	// DefaultModelForMultilingualMachineTranslation is a many-to-many translation model,
+	// covering every direction among its languages. The source and target languages
+	// must be given with the Options.
+	// Model card: https://huggingface.co/facebook/m2m100_418M
+	DefaultModelForMultilingualMachineTranslation = "facebook/m2m100_418M"
+
+	// DefaultModelForMultilingualMachineTranslation2 is a many-to-many translation model,
+	// covering every direction among its languages. The source and target languages
+	// must be given with the Options.
+	// Model card: https://huggingface.co/facebook/mbart-large-50-many-to-many-mmt
+	DefaultModelForMultilingualMachineTranslation2 = "facebook/mbart-large-50-many-to-many-mmt"
 )
 
 // DefaultModelForMachineTranslation specializes the model template for the source and target languages (iso-a2).
@@ -57,6 +71,16 @@ type Interface interface {
 	Generate(ctx context.Context, text string, opts *Options) (Response, error)
 }
 
+// StreamInterface defines the functions for generating text incrementally.
+type StreamInterface interface {
+	// GenerateStream generates text like Generate, with greedy or sampling
+	// decoding, calling fn with each token as soon as it is generated.
+	// If fn returns an error, the generation stops and the error is returned.
+	// The options requiring the beam search (i.e. NumBeams or
+	// NumReturnSequences greater than 1, or ForceWords) are not valid.
+	GenerateStream(ctx context.Context, text string, opts *Options, fn StreamFunc) (Response, error)
+}
+
 // Options defines the options for generating text.
 type Options struct {
 	// Temperature is the temperature used for sampling.
@@ -67,6 +91,265 @@ type Options struct {
 	TopK nullable.Type[int]
 	// TopP is the top-p candidates to be considered during generation.
 	TopP nullable.Type[float64]
+	// SourceLanguage is the language of the input text, required by
+	// multilingual models (e.g. "en" for M2M100, "eng_Latn" for NLLB, or "en_XX" for mBART-50).
+	SourceLanguage string
+	// TargetLanguage is the language of the generated text, required by
+	// multilingual models (e.g. "it" for M2M100, "ita_Latn" for NLLB, or "it_IT" for mBART-50).
+	TargetLanguage string
+	// NumBeams is the number of beams for the decoding search (1 means no beam search).
+	NumBeams nullable.Type[int]
+	// MinLength is the minimum length of the generated sequence.
+	MinLength nullable.Type[int]
+	// MaxLength is the maximum length of the sequence, which includes the
+	// prompt for decoder-only models (e.g. GPT-2 and Llama).
+	MaxLength nullable.Type[int]
+	// MaxNewTokens is the maximum number of generated tokens, regardless of
+	// the length of the input. It takes precedence over MaxLength.
+	MaxNewTokens nullable.Type[int]
+	// LengthPenalty is the exponential penalty to the length of the sequences
+	// scored by the beam search: values < 1.0 encourage shorter sequences,
+	// values > 1.0 encourage longer ones.
+	LengthPenalty nullable.Type[float64]
+	// NoRepeatNGramSize, when positive, is the size of the n-grams which can
+	// occur only once in the generated sequence.
+	NoRepeatNGramSize nullable.Type[int]
+	// EarlyStopping reports whether the beam search stops as soon as
+	// NumBeams sequences are finished.
+	EarlyStopping nullable.Type[bool]
+	// BadWordsIDs is a list of token ID sequences that are not allowed to be
+	// generated. If not empty, it replaces the one of the model configuration.
+	BadWordsIDs [][]int
+	// NumReturnSequences is the number of generated sequences to return,
+	// among the best ones. It cannot exceed NumBeams. If unset, a sequence
+	// is returned for each beam.
+	NumReturnSequences nullable.Type[int]
+	// RepetitionPenalty multiplies the log-probability of the tokens already
+	// generated. 1.0 means no penalty, while values > 1.0 discourage repetitions.
+	// Like the presence and frequency penalties, it only considers the
+	// generated tokens, not the input text or the prompt.
+	RepetitionPenalty nullable.Type[float64]
+	// PresencePenalty is subtracted from the log-probability of each token
+	// that has already been generated at least once.
+	PresencePenalty nullable.Type[float64]
+	// FrequencyPenalty is subtracted from the log-probability of each token
+	// as many times as it has already been generated.
+	FrequencyPenalty nullable.Type[float64]
+	// LogitBias maps tokens of the model vocabulary, as strings (e.g. "▁hello"
+	// for sentence-piece models or "Ġhello" for byte-level BPE models), to
+	// the bias added to their scores. Negative values discourage the tokens,
+	// while positive values encourage them.
+	LogitBias map[string]float64
+	// TypicalP, when set, enables locally typical sampling, keeping the most
+	// typical tokens whose probabilities add up to TypicalP.
+	TypicalP nullable.Type[float64]
+	// MinP, when set, filters out the tokens whose probability is lower than
+	// MinP times the one of the most probable token.
+	MinP nullable.Type[float64]
+	// ForceWords is a list of constraints on the generated text, each being
+	// a list of alternative phrases, one of which must appear verbatim in the
+	// generated text (e.g. {{"Cybertron"}, {"NLP", "natural language processing"}}).
+	// The phrases are tokenized as words within the text. They enable the
+	// constrained beam search, which requires at least 2 beams and cannot be streamed.
+	ForceWords [][]string
+	// PrefixAllowedTokens, if not nil, limits the tokens that can be generated
+	// after each sequence, which starts with the decoder start token (or with
+	// the last prompt token, for decoder-only models).
+	// It is not supported by the gRPC client.
+	PrefixAllowedTokens generationutils.PrefixAllowedTokensFunc
+}
+
+// DecoderConfig returns the configuration of the decoding search, overriding
+// the configuration of the model c with the options that are set.
+// The maximum length is not overridden, since its meaning depends on the model.
+func (o *Options) DecoderConfig(c generationutils.Config) (generationutils.Config, error) {
+	if err := o.validate(); err != nil {
+		return generationutils.Config{}, err
+	}
+	if o.NumBeams.Valid {
+		c.NumBeams = o.NumBeams.Value
+	}
+	if o.MinLength.Valid {
+		c.MinLength = o.MinLength.Value
+	}
+	if o.LengthPenalty.Valid {
+		c.LengthPenalty = o.LengthPenalty.Value
+	}
+	if o.NoRepeatNGramSize.Valid {
+		c.NoRepeatNGramSize = o.NoRepeatNGramSize.Value
+	}
+	if o.EarlyStopping.Valid {
+		c.EarlyStopping = o.EarlyStopping.Value
+	}
+	if len(o.BadWordsIDs) > 0 {
+		c.BadWordsIDs = o.BadWordsIDs
+	}
+	if o.RepetitionPenalty.Valid {
+		c.RepetitionPenalty = o.RepetitionPenalty.Value
+	}
+	if o.PresencePenalty.Valid {
+		c.PresencePenalty = o.PresencePenalty.Value
+	}
+	if o.FrequencyPenalty.Valid {
+		c.FrequencyPenalty = o.FrequencyPenalty.Value
+	}
+	if o.NumReturnSequences.Valid && o.NumReturnSequences.Value > c.NumBeams {
+		return generationutils.Config{}, fmt.Errorf("%w: the number of return sequences (%d) exceeds the number of beams (%d)",
+			ErrInvalidOptions, o.NumReturnSequences.Value, c.NumBeams)
+	}
+	if len(o.ForceWords) > 0 && c.NumBeams < 2 {
+		return generationutils.Config{}, fmt.Errorf("%w: the forced words require at least 2 beams, got %d", ErrInvalidOptions, c.NumBeams)
+	}
+	return c, nil
+}
+
+// StreamingConfig returns the configuration c of the decoding search adapted
+// for streaming the generated tokens (see generationutils.Config.StreamingConfig),
+// or an error if the options require the beam search, which cannot be streamed.
+func (o *Options) StreamingConfig(c generationutils.Config) (generationutils.Config, error) {
+	if o.NumBeams.Valid && o.NumBeams.Value > 1 {
+		return generationutils.Config{}, fmt.Errorf("%w: the beam search is not supported when streaming, got %d beams", ErrInvalidOptions, o.NumBeams.Value)
+	}
+	if o.NumReturnSequences.Valid && o.NumReturnSequences.Value > 1 {
+		return generationutils.Config{}, fmt.Errorf("%w: a single sequence can be returned when streaming, got %d", ErrInvalidOptions, o.NumReturnSequences.Value)
+	}
+	if len(o.ForceWords) > 0 {
+		return generationutils.Config{}, fmt.Errorf("%w: the forced words are not supported when streaming", ErrInvalidOptions)
+	}
+	return c.StreamingConfig(), nil
+}
+
+// TopSequences returns the first NumReturnSequences of the sequences generated
+// by the decoding search, which are sorted by score, along with their scores.
+// If NumReturnSequences is not set, all the sequences are returned.
+func (o *Options) TopSequences(sequences [][]int, scores []float64) ([][]int, []float64) {
+	if !o.NumReturnSequences.Valid || o.NumReturnSequences.Value >= len(sequences) {
+		return sequences, scores
+	}
+	n := o.NumReturnSequences.Value
+	return sequences[:n], scores[:n]
+}
+
+// validate checks that the options that are set have valid values.
+func (o *Options) validate() error {
+	checks := []struct {
+		name  string
+		value nullable.Type[int]
+		min   int
+	}{
+		{"number of beams", o.NumBeams, 1},
+		{"minimum length", o.MinLength, 0},
+		{"maximum length", o.MaxLength, 1},
+		{"maximum number of new tokens", o.MaxNewTokens, 1},
+		{"no-repeat n-gram size", o.NoRepeatNGramSize, 0},
+		{"number of return sequences", o.NumReturnSequences, 1},
+	}
+	for _, c := range checks {
+		if c.value.Valid && c.value.Value < c.min {
+			return fmt.Errorf("%w: the %s must be at least %d, got %d", ErrInvalidOptions, c.name, c.min, c.value.Value)
+		}
+	}
+	if o.RepetitionPenalty.Valid && o.RepetitionPenalty.Value <= 0 {
+		return fmt.Errorf("%w: the repetition penalty must be positive, got %g", ErrInvalidOptions, o.RepetitionPenalty.Value)
+	}
+	if o.TypicalP.Valid && (o.TypicalP.Value <= 0 || o.TypicalP.Value > 1) {
+		return fmt.Errorf("%w: the typical-p must be in (0, 1], got %g", ErrInvalidOptions, o.TypicalP.Value)
+	}
+	if o.MinP.Valid && (o.MinP.Value < 0 || o.MinP.Value > 1) {
+		return fmt.Errorf("%w: the min-p must be in [0, 1], got %g", ErrInvalidOptions, o.MinP.Value)
+	}
+	return nil
+}
+
+// TokenLogitBias returns the LogitBias mapped by token ID, resolving each
+// token through the tokenID function of the model tokenizer.
+// A nil tokenID means that the tokenizer cannot resolve the tokens.
+func (o *Options) TokenLogitBias(tokenID func(token string) (int, bool)) (map[int]float64, error) {
+	if len(o.LogitBias) == 0 {
+		return nil, nil
+	}
+	if tokenID == nil {
+		return nil, fmt.Errorf("%w: the logit bias is not supported by the model tokenizer", ErrInvalidOptions)
+	}
+	result := make(map[int]float64, len(o.LogitBias))
+	for token, bias := range o.LogitBias {
+		id, ok := tokenID(token)
+		if !ok {
+			return nil, fmt.Errorf("%w: the logit bias token %#v is not in the vocabulary", ErrInvalidOptions, token)
+		}
+		result[id] += bias
+	}
+	return result, nil
+}
+
+// Constraints returns the ForceWords as constraints of the decoding search,
+// tokenizing each phrase with the tokenize function of the model tokenizer.
+func (o *Options) Constraints(tokenize func(phrase string) ([]int, error)) ([]generationutils.Constraint, error) {
+	if len(o.ForceWords) == 0 {
+		return nil, nil
+	}
+	result := make([]generationutils.Constraint, len(o.ForceWords))
+	for i, alternatives := range o.ForceWords {
+		if len(alternatives) == 0 {
+			return nil, fmt.Errorf("%w: the forced words must have at least one phrase", ErrInvalidOptions)
+		}
+		result[i] = make(generationutils.Constraint, len(alternatives))
+		for j, phrase := range alternatives {
+			ids, err := tokenize(phrase)
+			if err != nil {
+				return nil, err
+			}
+			if len(ids) == 0 {
+				return nil, fmt.Errorf("%w: the forced phrase %#v has no tokens", ErrInvalidOptions, phrase)
+			}
+			result[i][j] = ids
+		}
+	}
+	return result, nil
+}
+
+// ScoreProcessor returns the processor of the log-probabilities of the next
+// tokens: the LogitBias, resolved through the tokenID function as in
+// TokenLogitBias, followed by the Temperature and the TopK, TopP, MinP and
+// TypicalP filters. With multiple beams, the filters keep at least two tokens.
+func (o *Options) ScoreProcessor(tokenID func(token string) (int, bool), numBeams int) (generationutils.ScoreProcessor, error) {
+	logitBias, err := o.TokenLogitBias(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	minSize := 1
+	if numBeams > 1 {
+		minSize = 2
+	}
+	procs := make([]generationutils.ScoreProcessor, 0, 6)
+	if len(logitBias) > 0 {
+		procs = append(procs, generationutils.LogitBiasProcessor(logitBias))
+	}
+	if o.Temperature.Valid {
+		procs = append(procs, generationutils.TemperatureProcessor(o.Temperature.Value))
+	}
+	if o.TopK.Valid {
+		procs = append(procs, generationutils.TopKProcessor(o.TopK.Value, math.Inf(-1)))
+	}
+	if o.TopP.Valid {
+		procs = append(procs, generationutils.TopPProcessor(o.TopP.Value, math.Inf(-1), minSize))
+	}
+	if o.MinP.Valid {
+		procs = append(procs, generationutils.MinPProcessor(o.MinP.Value, math.Inf(-1), minSize))
+	}
+	if o.TypicalP.Valid {
+		procs = append(procs, generationutils.TypicalProcessor(o.TypicalP.Value, math.Inf(-1), minSize))
+	}
+	return generationutils.ProcessScores(procs...), nil
+}
+
+// DecodingStrategy returns the strategy for selecting the next tokens, which
+// samples them if Sample is set, and picks the most probable ones otherwise.
+func (o *Options) DecodingStrategy() generationutils.DecodingStrategyFunc {
+	if o.Sample.Valid && o.Sample.Value {
+		return generationutils.SelectNextMultinomial
+	}
+	return generationutils.SelectNextTopK
 }
 
 // Response contains the result of the text generation.
@@ -81,6 +364,9 @@ type Response struct {
 // produced a sequence that exceeds the maximum allowed length.
 var ErrInputSequenceTooLong = errors.New("input sequence too long")
 
+// ErrInvalidOptions means that the options for generating text are not valid.
+var ErrInvalidOptions = errors.New("invalid text generation options")
+
 // DefaultOptions returns the default options for generating text.
 func DefaultOptions() *Options {
 	return &Options{
@@ -0,0 +1,245 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package textgeneration
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/cybertron/pkg/generationutils"
+	"github.com/nlpodyssey/cybertron/pkg/utils/nullable"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func TestOptions_DecoderConfig(t *testing.T) {
+	model := generationutils.Config{
+		NumBeams:          4,
+		MinLength:         10,
+		MaxLength:         100,
+		LengthPenalty:     2,
+		NoRepeatNGramSize: 3,
+		EarlyStopping:     true,
+		BadWordsIDs:       [][]int{{1}},
+	}
+
+	t.Run("unset options", func(t *testing.T) {
+		got, err := (&Options{}).DecoderConfig(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, model) {
+			t.Errorf("got %+v, want %+v", got, model)
+		}
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		opts := &Options{
+			NumBeams:           nullable.Type[int]{Value: 2, Valid: true},
+			MinLength:          nullable.Type[int]{Value: 0, Valid: true},
+			MaxLength:          nullable.Type[int]{Value: 20, Valid: true},
+			LengthPenalty:      nullable.Type[float64]{Value: 0.5, Valid: true},
+			NoRepeatNGramSize:  nullable.Type[int]{Value: 0, Valid: true},
+			EarlyStopping:      nullable.Type[bool]{Value: false, Valid: true},
+			BadWordsIDs:        [][]int{{2, 3}},
+			NumReturnSequences: nullable.Type[int]{Value: 2, Valid: true},
+			RepetitionPenalty:  nullable.Type[float64]{Value: 1.2, Valid: true},
+			PresencePenalty:    nullable.Type[float64]{Value: 0.5, Valid: true},
+			FrequencyPenalty:   nullable.Type[float64]{Value: 0.1, Valid: true},
+		}
+		got, err := opts.DecoderConfig(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := generationutils.Config{
+			NumBeams:          2,
+			MaxLength:         100, // depends on the model
+			LengthPenalty:     0.5,
+			BadWordsIDs:       [][]int{{2, 3}},
+			RepetitionPenalty: 1.2,
+			PresencePenalty:   0.5,
+			FrequencyPenalty:  0.1,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	invalid := map[string]*Options{
+		"zero beams":                {NumBeams: nullable.Type[int]{Value: 0, Valid: true}},
+		"negative min length":       {MinLength: nullable.Type[int]{Value: -1, Valid: true}},
+		"zero max length":           {MaxLength: nullable.Type[int]{Value: 0, Valid: true}},
+		"zero max new tokens":       {MaxNewTokens: nullable.Type[int]{Value: 0, Valid: true}},
+		"zero return sequences":     {NumReturnSequences: nullable.Type[int]{Value: 0, Valid: true}},
+		"more sequences than beams": {NumReturnSequences: nullable.Type[int]{Value: 5, Valid: true}},
+		"zero repetition penalty":   {RepetitionPenalty: nullable.Type[float64]{Value: 0, Valid: true}},
+		"zero typical-p":            {TypicalP: nullable.Type[float64]{Value: 0, Valid: true}},
+		"typical-p above one":       {TypicalP: nullable.Type[float64]{Value: 1.5, Valid: true}},
+		"negative min-p":            {MinP: nullable.Type[float64]{Value: -0.1, Valid: true}},
+		"more sequences than overridden beams": {
+			NumBeams:           nullable.Type[int]{Value: 1, Valid: true},
+			NumReturnSequences: nullable.Type[int]{Value: 2, Valid: true},
+		},
+		"forced words without beams": {
+			NumBeams:   nullable.Type[int]{Value: 1, Valid: true},
+			ForceWords: [][]string{{"Cybertron"}},
+		},
+	}
+	for name, opts := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := opts.DecoderConfig(model); !errors.Is(err, ErrInvalidOptions) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestOptions_StreamingConfig(t *testing.T) {
+	// The beams of the model configuration are reduced to one, while the
+	// options requesting more beams are not valid.
+	model := generationutils.Config{NumBeams: 4, EarlyStopping: false}
+
+	got, err := (&Options{}).StreamingConfig(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := model.StreamingConfig(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	opts := &Options{NumBeams: nullable.Type[int]{Value: 1, Valid: true}}
+	if _, err = opts.StreamingConfig(model); err != nil {
+		t.Errorf("got error %v, want none", err)
+	}
+
+	invalid := map[string]*Options{
+		"beams":            {NumBeams: nullable.Type[int]{Value: 2, Valid: true}},
+		"return sequences": {NumReturnSequences: nullable.Type[int]{Value: 2, Valid: true}},
+		"forced words":     {ForceWords: [][]string{{"Cybertron"}}},
+	}
+	for name, opts := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := opts.StreamingConfig(model); !errors.Is(err, ErrInvalidOptions) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestOptions_TopSequences(t *testing.T) {
+	sequences := [][]int{{1}, {2}, {3}}
+	scores := []float64{-1, -2, -3}
+
+	gotSequences, gotScores := (&Options{}).TopSequences(sequences, scores)
+	if len(gotSequences) != 3 || len(gotScores) != 3 {
+		t.Errorf("got %d sequences and %d scores, want 3", len(gotSequences), len(gotScores))
+	}
+
+	opts := &Options{NumReturnSequences: nullable.Type[int]{Value: 2, Valid: true}}
+	gotSequences, gotScores = opts.TopSequences(sequences, scores)
+	if !reflect.DeepEqual(gotSequences, sequences[:2]) || !reflect.DeepEqual(gotScores, scores[:2]) {
+		t.Errorf("got %v %v, want the first 2", gotSequences, gotScores)
+	}
+}
+
+func TestOptions_TokenLogitBias(t *testing.T) {
+	vocab := map[string]int{"▁hello": 5, "▁world": 7}
+	tokenID := func(token string) (int, bool) {
+		id, ok := vocab[token]
+		return id, ok
+	}
+
+	got, err := (&Options{}).TokenLogitBias(tokenID)
+	if err != nil || got != nil {
+		t.Errorf("got %v %v, want no bias", got, err)
+	}
+
+	opts := &Options{LogitBias: map[string]float64{"▁hello": -2, "▁world": 1.5}}
+	got, err = opts.TokenLogitBias(tokenID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]float64{5: -2, 7: 1.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	opts = &Options{LogitBias: map[string]float64{"▁missing": 1}}
+	if _, err = opts.TokenLogitBias(tokenID); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+	}
+	if _, err = opts.TokenLogitBias(nil); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+	}
+}
+
+func TestOptions_ScoreProcessor(t *testing.T) {
+	tokenID := func(token string) (int, bool) {
+		id, ok := map[string]int{"▁hello": 3}[token]
+		return id, ok
+	}
+	scores := mat.NewDense[float64](mat.WithBacking([]float64{-1, -2, -3, -4}))
+	opts := &Options{
+		LogitBias: map[string]float64{"▁hello": 10},
+		MinP:      nullable.Type[float64]{Value: 0.5, Valid: true},
+	}
+
+	// With multiple beams, the min-p filter keeps at least two tokens.
+	tests := []struct {
+		numBeams int
+		want     []float64
+	}{
+		{1, []float64{math.Inf(-1), math.Inf(-1), math.Inf(-1), 6}},
+		{4, []float64{-1, math.Inf(-1), math.Inf(-1), 6}},
+	}
+	for _, tt := range tests {
+		proc, err := opts.ScoreProcessor(tokenID, tt.numBeams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := proc(scores.Clone()).Data().F64(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%d beams: got %v, want %v", tt.numBeams, got, tt.want)
+		}
+	}
+
+	if _, err := opts.ScoreProcessor(nil, 1); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+	}
+}
+
+func TestOptions_Constraints(t *testing.T) {
+	vocab := map[string][]int{"Cybertron": {4, 5}, "NLP": {6}, "natural language processing": {7, 8, 9}, "": nil}
+	tokenize := func(phrase string) ([]int, error) {
+		return vocab[phrase], nil
+	}
+
+	got, err := (&Options{}).Constraints(tokenize)
+	if err != nil || got != nil {
+		t.Errorf("got %v %v, want no constraints", got, err)
+	}
+
+	opts := &Options{ForceWords: [][]string{{"Cybertron"}, {"NLP", "natural language processing"}}}
+	got, err = opts.Constraints(tokenize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []generationutils.Constraint{{{4, 5}}, {{6}, {7, 8, 9}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	invalid := map[string][][]string{
+		"no alternatives": {{}},
+		"no tokens":       {{""}},
+	}
+	for name, forceWords := range invalid {
+		t.Run(name, func(t *testing.T) {
+			opts := &Options{ForceWords: forceWords}
+			if _, err := opts.Constraints(tokenize); !errors.Is(err, ErrInvalidOptions) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
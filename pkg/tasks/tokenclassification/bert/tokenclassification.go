@@ -17,7 +17,6 @@ import (
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
 	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
-	"github.com/nlpodyssey/spago/mat"
 	"github.com/nlpodyssey/spago/nn"
 	"github.com/rs/zerolog/log"
 )
@@ -84,21 +83,24 @@ func ID2Label(value map[string]string) []string {
 // Classify returns the classification of the given text.
 func (m *TokenClassification) Classify(_ context.Context, text string, parameters tokenclassification.Parameters) (tokenclassification.Response, error) {
 	tokenized := m.tokenize(text)
-	if l, k := len(tokenized), m.Model.Bert.Config.MaxPositionEmbeddings; l > k {
+	if l, k := len(tokenized)+2, m.Model.Bert.Config.MaxPositionEmbeddings; l > k {
 		return tokenclassification.Response{}, fmt.Errorf("%w: %d > %d", tokenclassification.ErrInputSequenceTooLong, l, k)
 	}
 
 	logits := m.Model.Classify(pad(tokenizers.GetStrings(tokenized)))
-	tokens := make([]tokenclassification.Token, 0, len(tokenized))
-	for i, token := range wordpiecetokenizer.GroupSubWords(tokenized) {
-		label, score := m.getBestClass(logits[i])
+	logits = wordpiecetokenizer.FirstSubWords(logits[1:len(logits)-1], tokenized)
 
+	tokens := make([]tokenclassification.Token, 0, len(tokenized))
+	words := wordpiecetokenizer.GroupSubWords(tokenized)
+	transitionScores := tokenclassification.TransitionScores(parameters.DecodingStrategy, m.Model.CRF, m.Labels)
+	labels, scores := tokenclassification.Decode(logits[:len(words)], m.Labels, transitionScores)
+	for i, token := range words {
 		tokens = append(tokens, tokenclassification.Token{
 			Text:  text[token.Offsets.Start:token.Offsets.End],
 			Start: token.Offsets.Start,
 			End:   token.Offsets.End,
-			Label: label,
-			Score: score,
+			Label: labels[i],
+			Score: scores[i],
 		})
 	}
 
@@ -112,14 +114,6 @@ func (m *TokenClassification) Classify(_ context.Context, text string, parameter
 	return response, nil
 }
 
-func (m *TokenClassification) getBestClass(logits mat.Tensor) (label string, score float64) {
-	probs := logits.Value().(mat.Matrix).Softmax()
-	argmax := probs.ArgMax()
-	score = probs.At(argmax).Item().F64()
-	label = m.Labels[argmax]
-	return
-}
-
 // tokenize returns the tokens of the given text (without padding tokens).
 func (m *TokenClassification) tokenize(text string) []tokenizers.StringOffsetsPair {
 	if m.doLowerCase {
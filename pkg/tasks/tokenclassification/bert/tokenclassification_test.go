@@ -0,0 +1,49 @@
+// Copyright 2022 NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func TestFirstSubWords(t *testing.T) {
+	vocab := vocabulary.New([]string{"[UNK]", "[CLS]", "[SEP]", "john", "mc", "##don", "##ald", "lives"})
+	tokenized := wordpiecetokenizer.New(vocab).Tokenize("john mcdonald lives")
+	if got, want := tokenizers.GetStrings(tokenized), []string{"john", "mc", "##don", "##ald", "lives"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got tokens %v, want %v", got, want)
+	}
+
+	labels := []string{"O", "B-PER", "I-PER"}
+	newLogits := func(values ...float64) mat.Tensor {
+		return mat.NewDense[float64](mat.WithBacking(values))
+	}
+	// The logits of the special tokens and of the sub-words following the
+	// first one favor labels that must not show up in the result.
+	logits := []mat.Tensor{
+		newLogits(0, 0, 5), // [CLS]
+		newLogits(0, 5, 0), // john
+		newLogits(0, 0, 5), // mc
+		newLogits(5, 0, 0), // ##don
+		newLogits(0, 5, 0), // ##ald
+		newLogits(5, 0, 0), // lives
+		newLogits(0, 5, 0), // [SEP]
+	}
+
+	words := wordpiecetokenizer.GroupSubWords(tokenized)
+	got, _ := tokenclassification.Decode(wordpiecetokenizer.FirstSubWords(logits[1:len(logits)-1], tokenized), labels, nil)
+	if want := []string{"B-PER", "I-PER", "O"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got labels %v, want %v", got, want)
+	}
+	if len(got) != len(words) {
+		t.Errorf("got %d labels, want one for each of the %d words", len(got), len(words))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokenclassification
+
+import (
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn/crf"
+)
+
+// forbiddenTransitionScore is the score of the transitions that are not allowed.
+// It is large enough to rule them out, while keeping the Viterbi scores finite.
+const forbiddenTransitionScore = -1e4
+
+// Decode returns the label and the score of each token, given the logits of the tokens.
+// If the transition scores are nil, each token gets its most probable label;
+// otherwise, the labels are decoded with the Viterbi algorithm.
+// The score is always the probability of the label assigned to the token.
+func Decode(logits []mat.Tensor, labels []string, transitionScores mat.Matrix) ([]string, []float64) {
+	if len(logits) == 0 {
+		return nil, nil
+	}
+
+	var best []int
+	if transitionScores != nil {
+		best = crf.Viterbi(transitionScores, logits)
+	}
+
+	resultLabels := make([]string, len(logits))
+	resultScores := make([]float64, len(logits))
+	for i, l := range logits {
+		probs := l.Value().(mat.Matrix).Softmax()
+		index := probs.ArgMax()
+		if best != nil {
+			index = best[i]
+		}
+		resultLabels[i] = labels[index]
+		resultScores[i] = probs.At(index).Item().F64()
+	}
+	return resultLabels, resultScores
+}
+
+// TransitionScores returns the transition scores to decode the labels
+// according to the given strategy, or nil to decode them with argmax.
+// The model is the CRF layer of the model, which can be nil.
+func TransitionScores(strategy DecodingStrategy, model *crf.Model, labels []string) mat.Matrix {
+	switch {
+	case strategy == DecodingStrategyArgmax:
+		return nil
+	case model != nil:
+		return model.TransitionScores.Value().(mat.Matrix)
+	case strategy == DecodingStrategyViterbi:
+		return IOBTransitionScores(labels)
+	default:
+		return nil
+	}
+}
+
+// IOBTransitionScores returns the transition scores of a linear-chain CRF that
+// forbid the label sequences that are invalid according to the IOB annotation schema,
+// that is, an "I-X" label not preceded by either "B-X" or "I-X".
+// The index 0 stands for both the start and the end state, as in crf.Model.
+func IOBTransitionScores(labels []string) mat.Matrix {
+	n := len(labels) + 1
+	scores := make([]float64, n*n)
+	for j, next := range labels {
+		if extractPrefix(next) != 'I' {
+			continue
+		}
+		scores[j+1] = forbiddenTransitionScore // from the start state
+		for i, prev := range labels {
+			if !anyOf2Bytes(extractPrefix(prev), 'B', 'I') || stripPrefix(prev) != stripPrefix(next) {
+				scores[(i+1)*n+j+1] = forbiddenTransitionScore
+			}
+		}
+	}
+	return mat.NewDense[float64](mat.WithShape(n, n), mat.WithBacking(scores))
+}
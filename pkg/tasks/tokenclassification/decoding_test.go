@@ -0,0 +1,114 @@
+// Copyright 2022 NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tokenclassification
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nlpodyssey/spago/mat"
+)
+
+func TestIOBTransitionScores(t *testing.T) {
+	labels := []string{"O", "B-PER", "I-PER", "B-LOC", "I-LOC"}
+	scores := IOBTransitionScores(labels)
+
+	if got := scores.Shape(); !reflect.DeepEqual(got, []int{6, 6}) {
+		t.Fatalf("unexpected shape %v", got)
+	}
+
+	allowed := func(from, to int) bool {
+		return scores.ScalarAt(from, to).F64() == 0
+	}
+
+	// from the start state
+	for j, want := range []bool{true, true, false, true, false} {
+		if got := allowed(0, j+1); got != want {
+			t.Errorf("start -> %s: got allowed %v, want %v", labels[j], got, want)
+		}
+	}
+	// to the end state
+	for i := range labels {
+		if !allowed(i+1, 0) {
+			t.Errorf("%s -> end: got forbidden, want allowed", labels[i])
+		}
+	}
+
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"O", "I-PER", false},
+		{"B-PER", "I-PER", true},
+		{"I-PER", "I-PER", true},
+		{"B-LOC", "I-PER", false},
+		{"I-LOC", "I-PER", false},
+		{"I-PER", "O", true},
+		{"O", "B-LOC", true},
+		{"I-PER", "B-PER", true},
+	}
+	index := func(label string) int {
+		for i, l := range labels {
+			if l == label {
+				return i + 1
+			}
+		}
+		t.Fatalf("unknown label %s", label)
+		return -1
+	}
+	for _, tt := range tests {
+		if got := allowed(index(tt.from), index(tt.to)); got != tt.want {
+			t.Errorf("%s -> %s: got allowed %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	labels := []string{"O", "B-PER", "I-PER"}
+	newLogits := func(values ...float64) mat.Tensor {
+		return mat.NewDense[float64](mat.WithBacking(values))
+	}
+	// "I-PER" is the most probable label of the first token, but it is
+	// not allowed after the start state.
+	logits := []mat.Tensor{
+		newLogits(0, 1, 2),
+		newLogits(0, 0, 3),
+		newLogits(3, 0, 0),
+	}
+
+	t.Run("argmax", func(t *testing.T) {
+		got, _ := Decode(logits, labels, nil)
+		want := []string{"I-PER", "I-PER", "O"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("viterbi", func(t *testing.T) {
+		got, scores := Decode(logits, labels, IOBTransitionScores(labels))
+		want := []string{"B-PER", "I-PER", "O"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		wantScore := logits[0].Value().(mat.Matrix).Softmax().ScalarAt(1).F64()
+		if scores[0] != wantScore {
+			t.Errorf("got score %v, want %v", scores[0], wantScore)
+		}
+	})
+}
+
+func TestTransitionScores(t *testing.T) {
+	labels := []string{"O", "B-PER", "I-PER"}
+
+	if got := TransitionScores("", nil, labels); got != nil {
+		t.Errorf("expected nil transition scores without CRF layer")
+	}
+	if got := TransitionScores(DecodingStrategyArgmax, nil, labels); got != nil {
+		t.Errorf("expected nil transition scores with argmax strategy")
+	}
+	if got := TransitionScores(DecodingStrategyViterbi, nil, labels); got == nil {
+		t.Errorf("expected IOB transition scores with viterbi strategy")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distilbert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/models/distilbert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+	bert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ tokenclassification.Interface = &TokenClassification{}
+
+// TokenClassification is a token classification model based on DistilBERT.
+type TokenClassification struct {
+	// Model is the model used for token classification.
+	Model *distilbert.ModelForTokenClassification
+	// Tokenizer is the tokenizer used to tokenize the text.
+	Tokenizer *wordpiecetokenizer.WordPieceTokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+	// doLowerCase is a flag indicating if the model should lowercase the input before tokenization.
+	doLowerCase bool
+}
+
+// LoadTokenClassification returns a TokenClassification loading the model, the embeddings and the tokenizer from a directory.
+func LoadTokenClassification(modelPath string) (*TokenClassification, error) {
+	vocab, err := vocabulary.NewFromFile(filepath.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for token classification: %w", err)
+	}
+	tokenizer := wordpiecetokenizer.New(vocab)
+
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for token classification: %w", err)
+	}
+
+	config, err := distilbert.ConfigFromFile(path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for token classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*distilbert.ModelForTokenClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load distilbert model: %w", err)
+	}
+
+	return &TokenClassification{
+		Model:       m,
+		Tokenizer:   tokenizer,
+		Labels:      bert_for_token_classification.ID2Label(config.ID2Label),
+		doLowerCase: tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TokenClassification) Classify(_ context.Context, text string, parameters tokenclassification.Parameters) (tokenclassification.Response, error) {
+	tokenized := m.tokenize(text)
+	if l, k := len(tokenized)+2, m.Model.DistilBert.Config.MaxPositionEmbeddings; l > k {
+		return tokenclassification.Response{}, fmt.Errorf("%w: %d > %d", tokenclassification.ErrInputSequenceTooLong, l, k)
+	}
+
+	logits := m.Model.Classify(pad(tokenizers.GetStrings(tokenized)))
+	logits = wordpiecetokenizer.FirstSubWords(logits[1:len(logits)-1], tokenized)
+
+	tokens := make([]tokenclassification.Token, 0, len(tokenized))
+	words := wordpiecetokenizer.GroupSubWords(tokenized)
+	transitionScores := tokenclassification.TransitionScores(parameters.DecodingStrategy, nil, m.Labels)
+	labels, scores := tokenclassification.Decode(logits[:len(words)], m.Labels, transitionScores)
+	for i, token := range words {
+		tokens = append(tokens, tokenclassification.Token{
+			Text:  text[token.Offsets.Start:token.Offsets.End],
+			Start: token.Offsets.Start,
+			End:   token.Offsets.End,
+			Label: labels[i],
+			Score: scores[i],
+		})
+	}
+
+	if parameters.AggregationStrategy == tokenclassification.AggregationStrategySimple {
+		tokens = tokenclassification.FilterNotEntities(tokenclassification.Aggregate(tokens))
+	}
+
+	response := tokenclassification.Response{
+		Tokens: tokens,
+	}
+	return response, nil
+}
+
+// tokenize returns the tokens of the given text (without padding tokens).
+func (m *TokenClassification) tokenize(text string) []tokenizers.StringOffsetsPair {
+	if m.doLowerCase {
+		text = strings.ToLower(text)
+	}
+	return m.Tokenizer.Tokenize(text)
+}
+
+func pad(tokens []string) []string {
+	return append(append([]string{wordpiecetokenizer.DefaultClassToken}, tokens...), wordpiecetokenizer.DefaultSequenceSeparator)
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package roberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+	bert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ tokenclassification.Interface = &TokenClassification{}
+
+// TokenClassification is a token classification model based on RoBERTa.
+type TokenClassification struct {
+	// Model is the model used for token classification.
+	Model *bert.ModelForTokenClassification
+	// Tokenizer is the byte-level BPE tokenizer used to tokenize the text.
+	Tokenizer *bpetokenizer.BPETokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+}
+
+// LoadTokenClassification returns a TokenClassification loading the model, the embeddings and the tokenizer from a directory.
+func LoadTokenClassification(modelPath string) (*TokenClassification, error) {
+	tokenizer, err := bpetokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for token classification: %w", err)
+	}
+
+	config, err := bert.ConfigFromFile[bert.Config](path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for token classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForTokenClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roberta model: %w", err)
+	}
+
+	return &TokenClassification{
+		Model:     m,
+		Tokenizer: tokenizer,
+		Labels:    bert_for_token_classification.ID2Label(config.ID2Label),
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TokenClassification) Classify(_ context.Context, text string, parameters tokenclassification.Parameters) (tokenclassification.Response, error) {
+	tokenized, err := m.Tokenizer.Tokenize(text)
+	if err != nil {
+		return tokenclassification.Response{}, err
+	}
+	if l, k := len(tokenized)+2, m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return tokenclassification.Response{}, fmt.Errorf("%w: %d > %d", tokenclassification.ErrInputSequenceTooLong, l, k)
+	}
+
+	logits := m.Model.Classify(pad(tokenizers.GetStrings(tokenized)))
+	runes := []rune(text)
+	words, firstTokens := groupSubWords(tokenized)
+	tokens := make([]tokenclassification.Token, 0, len(words))
+	wordLogits := make([]mat.Tensor, len(words))
+	for i := range words {
+		wordLogits[i] = logits[firstTokens[i]+1] // +1 for the class token
+	}
+	transitionScores := tokenclassification.TransitionScores(parameters.DecodingStrategy, m.Model.CRF, m.Labels)
+	labels, scores := tokenclassification.Decode(wordLogits, m.Labels, transitionScores)
+	for i, word := range words {
+		tokens = append(tokens, tokenclassification.Token{
+			Text:  string(runes[word.Offsets.Start:word.Offsets.End]),
+			Start: word.Offsets.Start,
+			End:   word.Offsets.End,
+			Label: labels[i],
+			Score: scores[i],
+		})
+	}
+
+	if parameters.AggregationStrategy == tokenclassification.AggregationStrategySimple {
+		tokens = tokenclassification.FilterNotEntities(tokenclassification.Aggregate(tokens))
+	}
+
+	response := tokenclassification.Response{
+		Tokens: tokens,
+	}
+	return response, nil
+}
+
+// groupSubWords returns the words formed by the given byte-level BPE tokens,
+// along with the index of the first token of each word.
+func groupSubWords(tokens []tokenizers.StringOffsetsPair) ([]tokenizers.StringOffsetsPair, []int) {
+	words := make([]tokenizers.StringOffsetsPair, 0, len(tokens))
+	firstTokens := make([]int, 0, len(tokens))
+	for i, token := range tokens {
+		if len(words) > 0 && !strings.HasPrefix(token.String, bpetokenizer.DefaultSpacePrefix) {
+			last := &words[len(words)-1]
+			last.String += token.String
+			last.Offsets.End = token.Offsets.End
+			continue
+		}
+		words = append(words, tokenizers.StringOffsetsPair{
+			String:  strings.TrimPrefix(token.String, bpetokenizer.DefaultSpacePrefix),
+			Offsets: token.Offsets,
+		})
+		firstTokens = append(firstTokens, i)
+	}
+	return words, firstTokens
+}
+
+func pad(tokens []string) []string {
+	return append(append([]string{bpetokenizer.DefaultClassToken}, tokens...), bpetokenizer.DefaultSequenceSeparator)
+}
@@ -37,12 +37,27 @@ const (
 	AggregationStrategySimple AggregationStrategy = "simple"
 )
 
+type DecodingStrategy string
+
+const (
+	// DecodingStrategyArgmax - Every token gets its most probable label, independently of the others.
+	DecodingStrategyArgmax DecodingStrategy = "argmax"
+
+	// DecodingStrategyViterbi - The most probable sequence of labels is decoded with a linear-chain CRF.
+	// The transition scores are loaded from the model when available, otherwise the
+	// transitions only forbid the sequences that are invalid according to the IOB annotation schema.
+	DecodingStrategyViterbi DecodingStrategy = "viterbi"
+)
+
 // ErrInputSequenceTooLong means that pre-processing the input text
 // produced a sequence that exceeds the maximum allowed length.
 var ErrInputSequenceTooLong = errors.New("input sequence too long")
 
 type Parameters struct {
 	AggregationStrategy AggregationStrategy
+	// DecodingStrategy is the strategy used to assign the labels to the tokens.
+	// If empty, the Viterbi decoding is used only for models with a CRF layer.
+	DecodingStrategy DecodingStrategy
 }
 
 // Interface defines the main functions for token classification task.
@@ -0,0 +1,144 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xlmroberta
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification"
+	bert_for_token_classification "github.com/nlpodyssey/cybertron/pkg/tasks/tokenclassification/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+const (
+	// defaultClassToken is the XLM-RoBERTa class token, at the beginning of the sequence.
+	defaultClassToken = "<s>"
+	// defaultSequenceSeparator is the XLM-RoBERTa separator token, at the end of the sequence.
+	defaultSequenceSeparator = "</s>"
+	// defaultSpacePrefix is the sentence-piece prefix of the tokens starting a new word.
+	defaultSpacePrefix = "▁"
+)
+
+var _ tokenclassification.Interface = &TokenClassification{}
+
+// TokenClassification is a token classification model based on XLM-RoBERTa.
+type TokenClassification struct {
+	// Model is the model used for token classification.
+	Model *bert.ModelForTokenClassification
+	// Tokenizer is the sentence-piece tokenizer used to tokenize the text.
+	Tokenizer *sentencepiece.Tokenizer
+	// Labels is the list of labels used for classification.
+	Labels []string
+}
+
+// LoadTokenClassification returns a TokenClassification loading the model, the embeddings and the tokenizer from a directory.
+func LoadTokenClassification(modelPath string) (*TokenClassification, error) {
+	tokenizer, err := sentencepiece.NewFairseqFromFile(path.Join(modelPath, "sentencepiece.bpe.model"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for token classification: %w", err)
+	}
+
+	config, err := bert.ConfigFromFile[bert.Config](path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for token classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForTokenClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load xlm-roberta model: %w", err)
+	}
+
+	return &TokenClassification{
+		Model:     m,
+		Tokenizer: tokenizer,
+		Labels:    bert_for_token_classification.ID2Label(config.ID2Label),
+	}, nil
+}
+
+// Classify returns the classification of the given text.
+func (m *TokenClassification) Classify(_ context.Context, text string, parameters tokenclassification.Parameters) (tokenclassification.Response, error) {
+	tokenized := m.Tokenizer.TokenizeWithOffsets(text)
+	if l, k := len(tokenized)+2, m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return tokenclassification.Response{}, fmt.Errorf("%w: %d > %d", tokenclassification.ErrInputSequenceTooLong, l, k)
+	}
+
+	logits := m.Model.Classify(m.pad(tokenizers.GetStrings(tokenized)))
+	runes := []rune(text)
+	words, firstTokens := groupSubWords(tokenized)
+	tokens := make([]tokenclassification.Token, 0, len(words))
+	wordLogits := make([]mat.Tensor, len(words))
+	for i := range words {
+		wordLogits[i] = logits[firstTokens[i]+1] // +1 for the class token
+	}
+	transitionScores := tokenclassification.TransitionScores(parameters.DecodingStrategy, m.Model.CRF, m.Labels)
+	labels, scores := tokenclassification.Decode(wordLogits, m.Labels, transitionScores)
+	for i, word := range words {
+		tokens = append(tokens, tokenclassification.Token{
+			Text:  string(runes[word.Offsets.Start:word.Offsets.End]),
+			Start: word.Offsets.Start,
+			End:   word.Offsets.End,
+			Label: labels[i],
+			Score: scores[i],
+		})
+	}
+
+	if parameters.AggregationStrategy == tokenclassification.AggregationStrategySimple {
+		tokens = tokenclassification.FilterNotEntities(tokenclassification.Aggregate(tokens))
+	}
+
+	response := tokenclassification.Response{
+		Tokens: tokens,
+	}
+	return response, nil
+}
+
+// groupSubWords returns the words formed by the given sentence-piece tokens,
+// along with the index of the first token of each word.
+func groupSubWords(tokens []tokenizers.StringOffsetsPair) ([]tokenizers.StringOffsetsPair, []int) {
+	words := make([]tokenizers.StringOffsetsPair, 0, len(tokens))
+	firstTokens := make([]int, 0, len(tokens))
+	for i, token := range tokens {
+		if len(words) > 0 && !strings.HasPrefix(token.String, defaultSpacePrefix) {
+			last := &words[len(words)-1]
+			if last.String == "" {
+				// the word starts with a standalone separator, representing the preceding whitespace
+				last.Offsets.Start = token.Offsets.Start
+			}
+			last.String += token.String
+			last.Offsets.End = token.Offsets.End
+			continue
+		}
+		words, firstTokens = dropWhitespace(words, firstTokens)
+		words = append(words, tokenizers.StringOffsetsPair{
+			String:  strings.TrimPrefix(token.String, defaultSpacePrefix),
+			Offsets: token.Offsets,
+		})
+		firstTokens = append(firstTokens, i)
+	}
+	return dropWhitespace(words, firstTokens)
+}
+
+// dropWhitespace removes the last word if it consists only of a standalone
+// separator, which represents a whitespace.
+func dropWhitespace(words []tokenizers.StringOffsetsPair, firstTokens []int) ([]tokenizers.StringOffsetsPair, []int) {
+	if n := len(words); n > 0 && words[n-1].String == "" {
+		return words[:n-1], firstTokens[:n-1]
+	}
+	return words, firstTokens
+}
+
+// pad returns the tokens surrounded by the class and separator tokens,
+// replacing the pieces missing from the vocabulary with the unknown token.
+func (m *TokenClassification) pad(tokens []string) []string {
+	tokens = m.Tokenizer.IDsToTokens(m.Tokenizer.TokensToIDs(tokens))
+	return append(append([]string{defaultClassToken}, tokens...), defaultSequenceSeparator)
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/bert"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotclassifier"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"golang.org/x/sync/errgroup"
+)
+
+var _ zeroshotclassifier.Interface = &ZeroShotClassifier{}
+
+// ZeroShotClassifier contains the ModelForSequenceClassification and the Tokenizer
+// used for zero-shot classification tasks with BERT-family (e.g. BERT, RoBERTa)
+// Natural Language Inference models.
+type ZeroShotClassifier struct {
+	// Model is the model used for zero-shot classification.
+	Model *bert.ModelForSequenceClassification
+	// Tokenizer is the tokenizer.
+	Tokenizer                     Tokenizer
+	entailmentID, contradictionID int
+}
+
+// LoadZeroShotClassifier loads a ZeroShotClassifier from a directory.
+func LoadZeroShotClassifier(modelPath string) (*ZeroShotClassifier, error) {
+	config, err := bert.ConfigFromFile[bert.Config](path.Join(modelPath, "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for zero-shot: %w", err)
+	}
+
+	tok, err := loadTokenizer(modelPath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := nn.LoadFromFile[*bert.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bert model: %w", err)
+	}
+
+	entailmentID, err := m.Bert.Config.EntailmentID()
+	if err != nil {
+		return nil, err
+	}
+	contradictionID, err := m.Bert.Config.ContradictionID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZeroShotClassifier{
+		Model:           m,
+		Tokenizer:       tok,
+		entailmentID:    entailmentID,
+		contradictionID: contradictionID,
+	}, nil
+}
+
+// loadTokenizer returns the byte-level BPE tokenizer for RoBERTa models,
+// and the WordPiece tokenizer otherwise.
+func loadTokenizer(modelPath string, config bert.Config) (Tokenizer, error) {
+	if config.ModelType == "roberta" {
+		tok, err := bpetokenizer.NewFromModelFolder(modelPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bpe tokenizer for zero-shot: %w", err)
+		}
+		return &BPETokenizer{BPETokenizer: tok}, nil
+	}
+
+	vocab, err := vocabulary.NewFromFile(path.Join(modelPath, "vocab.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary for zero-shot: %w", err)
+	}
+	tokenizerConfig, err := bert.ConfigFromFile[bert.TokenizerConfig](path.Join(modelPath, "tokenizer_config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config for zero-shot: %w", err)
+	}
+	return &WordPieceTokenizer{
+		WordPieceTokenizer: wordpiecetokenizer.New(vocab),
+		DoLowerCase:        tokenizerConfig.DoLowerCase,
+	}, nil
+}
+
+// Classify classifies the input.
+func (m *ZeroShotClassifier) Classify(_ context.Context, text string, parameters zeroshotclassifier.Parameters) (zeroshotclassifier.Response, error) {
+	premise, err := m.Tokenizer.TokenizePremise(text)
+	if err != nil {
+		return zeroshotclassifier.Response{}, err
+	}
+	if l, k := len(premise), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+		return zeroshotclassifier.Response{}, fmt.Errorf("%w: %d > %d", zeroshotclassifier.ErrInputSequenceTooLong, l, k)
+	}
+
+	if parameters.HypothesisTemplate == "" {
+		parameters.HypothesisTemplate = zeroshotclassifier.DefaultHypothesisTemplate
+	}
+
+	multiClass := parameters.MultiLabel || len(parameters.CandidateLabels) == 1
+	scoreFn := m.score(premise, multiClass)
+
+	ch := make(chan struct{}, runtime.NumCPU())
+	eg, _ := errgroup.WithContext(context.Background())
+
+	var scores mat.Matrix = mat.NewDense[float64](mat.WithShape(len(parameters.CandidateLabels)))
+
+	for i := range parameters.CandidateLabels {
+		ch <- struct{}{}
+		i := i
+		eg.Go(func() error {
+			defer func() { <-ch }()
+			hypothesis, err := m.Tokenizer.TokenizeHypothesis(strings.Replace(parameters.HypothesisTemplate, "{}", parameters.CandidateLabels[i], -1))
+			if err != nil {
+				return err
+			}
+			if l, k := len(premise)+len(hypothesis), m.Model.Bert.Config.MaxSequenceLength(); l > k {
+				return fmt.Errorf("%w: %d > %d", zeroshotclassifier.ErrInputSequenceTooLong, l, k)
+			}
+			scores.SetScalar(float.Interface(scoreFn(hypothesis)), i)
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return zeroshotclassifier.Response{}, err
+	}
+	close(ch)
+
+	if !multiClass {
+		scores = scores.Softmax() // softmax the "entailment" over all candidate labels
+	}
+
+	result := sliceutils.NewIndexedSlice[float64](scores.Data().F64())
+	sort.Stable(sort.Reverse(result))
+
+	labels := make([]string, len(parameters.CandidateLabels))
+	for i, ii := range result.Indices {
+		labels[i] = parameters.CandidateLabels[ii]
+	}
+
+	response := zeroshotclassifier.Response{
+		Labels: labels,
+		Scores: result.Slice,
+	}
+	return response, nil
+}
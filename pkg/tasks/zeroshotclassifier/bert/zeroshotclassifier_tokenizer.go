@@ -0,0 +1,76 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bert
+
+import (
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/wordpiecetokenizer"
+)
+
+// Tokenizer splits the premise and the hypotheses into tokens, including
+// the special tokens, so that their concatenation is the sequence pair
+// expected by the model.
+type Tokenizer interface {
+	// TokenizePremise returns the tokens of the premise, i.e. the first sequence of the pair.
+	TokenizePremise(text string) ([]string, error)
+	// TokenizeHypothesis returns the tokens of a hypothesis, i.e. the second sequence of the pair.
+	TokenizeHypothesis(text string) ([]string, error)
+}
+
+// WordPieceTokenizer tokenizes the sequence pairs of BERT models,
+// as "[CLS] premise [SEP] hypothesis [SEP]".
+type WordPieceTokenizer struct {
+	*wordpiecetokenizer.WordPieceTokenizer
+	// DoLowerCase reports whether the text is lowercased before tokenization.
+	DoLowerCase bool
+}
+
+// TokenizePremise returns the tokens of the premise.
+func (t *WordPieceTokenizer) TokenizePremise(text string) ([]string, error) {
+	return append([]string{wordpiecetokenizer.DefaultClassToken}, t.tokenize(text)...), nil
+}
+
+// TokenizeHypothesis returns the tokens of a hypothesis.
+func (t *WordPieceTokenizer) TokenizeHypothesis(text string) ([]string, error) {
+	return t.tokenize(text), nil
+}
+
+// tokenize returns the tokens of the text, followed by the separator token.
+func (t *WordPieceTokenizer) tokenize(text string) []string {
+	if t.DoLowerCase {
+		text = strings.ToLower(text)
+	}
+	return append(tokenizers.GetStrings(t.Tokenize(text)), wordpiecetokenizer.DefaultSequenceSeparator)
+}
+
+// BPETokenizer tokenizes the sequence pairs of RoBERTa models,
+// as "<s> premise </s></s> hypothesis </s>".
+type BPETokenizer struct {
+	*bpetokenizer.BPETokenizer
+}
+
+// TokenizePremise returns the tokens of the premise.
+func (t *BPETokenizer) TokenizePremise(text string) ([]string, error) {
+	return t.tokenize(text, bpetokenizer.DefaultClassToken)
+}
+
+// TokenizeHypothesis returns the tokens of a hypothesis.
+func (t *BPETokenizer) TokenizeHypothesis(text string) ([]string, error) {
+	return t.tokenize(text, bpetokenizer.DefaultSequenceSeparator)
+}
+
+// tokenize returns the tokens of the text, preceded by the given start
+// token, and followed by the separator token.
+func (t *BPETokenizer) tokenize(text, startToken string) ([]string, error) {
+	tokens, err := t.Tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	result := append([]string{startToken}, tokenizers.GetStrings(tokens)...)
+	return append(result, bpetokenizer.DefaultSequenceSeparator), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/models/debertav2"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotclassifier"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/mat/float"
+	"github.com/nlpodyssey/spago/nn"
+	"golang.org/x/sync/errgroup"
+)
+
+var _ zeroshotclassifier.Interface = &ZeroShotClassifier{}
+
+const (
+	// defaultClassToken is the DeBERTa-v2 class token, at the beginning of the sequence.
+	defaultClassToken = "[CLS]"
+	// defaultSequenceSeparator is the DeBERTa-v2 separator token, at the end of each sequence.
+	defaultSequenceSeparator = "[SEP]"
+)
+
+// ZeroShotClassifier contains the ModelForSequenceClassification and the Tokenizer
+// used for zero-shot classification tasks.
+type ZeroShotClassifier struct {
+	// Model is the model used for zero-shot classification.
+	Model *debertav2.ModelForSequenceClassification
+	// Tokenizer is the tokenizer.
+	Tokenizer                     *sentencepiece.Tokenizer
+	entailmentID, contradictionID int
+}
+
+// LoadZeroShotClassifier loads a ZeroShotClassifier from a directory.
+func LoadZeroShotClassifier(modelPath string) (*ZeroShotClassifier, error) {
+	tok, err := sentencepiece.NewFromFile(path.Join(modelPath, "spm.model"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sentencepiece tokenizer for zero-shot: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*debertav2.ModelForSequenceClassification](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deberta-v2 model: %w", err)
+	}
+
+	entailmentID, err := m.DeBERTa.Config.EntailmentID()
+	if err != nil {
+		return nil, err
+	}
+	contradictionID, err := m.DeBERTa.Config.ContradictionID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZeroShotClassifier{
+		Model:           m,
+		Tokenizer:       tok,
+		entailmentID:    entailmentID,
+		contradictionID: contradictionID,
+	}, nil
+}
+
+// Classify classifies the input.
+func (m *ZeroShotClassifier) Classify(_ context.Context, text string, parameters zeroshotclassifier.Parameters) (zeroshotclassifier.Response, error) {
+	premise := m.tokenize(text, defaultClassToken)
+	if l, k := len(premise), m.Model.DeBERTa.Config.MaxSequenceLength(); l > k {
+		return zeroshotclassifier.Response{}, fmt.Errorf("%w: %d > %d", zeroshotclassifier.ErrInputSequenceTooLong, l, k)
+	}
+
+	if parameters.HypothesisTemplate == "" {
+		parameters.HypothesisTemplate = zeroshotclassifier.DefaultHypothesisTemplate
+	}
+
+	multiClass := parameters.MultiLabel || len(parameters.CandidateLabels) == 1
+	scoreFn := m.score(premise, multiClass)
+
+	ch := make(chan struct{}, runtime.NumCPU())
+	eg, _ := errgroup.WithContext(context.Background())
+
+	var scores mat.Matrix = mat.NewDense[float64](mat.WithShape(len(parameters.CandidateLabels)))
+
+	for i := range parameters.CandidateLabels {
+		ch <- struct{}{}
+		i := i
+		eg.Go(func() error {
+			hypothesis := m.tokenize(strings.Replace(parameters.HypothesisTemplate, "{}", parameters.CandidateLabels[i], -1))
+			var err error
+			if l, k := len(premise)+len(hypothesis), m.Model.DeBERTa.Config.MaxSequenceLength(); l > k {
+				err = fmt.Errorf("%w: %d > %d", zeroshotclassifier.ErrInputSequenceTooLong, l, k)
+			} else {
+				scores.SetScalar(float.Interface(scoreFn(hypothesis)), i)
+			}
+			<-ch
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return zeroshotclassifier.Response{}, err
+	}
+	for i := 0; i < len(ch); i++ {
+		ch <- struct{}{}
+	}
+	close(ch)
+
+	if !multiClass {
+		scores = scores.Softmax() // softmax the "entailment" over all candidate labels
+	}
+
+	result := sliceutils.NewIndexedSlice[float64](scores.Data().F64())
+	sort.Stable(sort.Reverse(result))
+
+	labels := make([]string, len(parameters.CandidateLabels))
+	for i, ii := range result.Indices {
+		labels[i] = parameters.CandidateLabels[ii]
+	}
+
+	response := zeroshotclassifier.Response{
+		Labels: labels,
+		Scores: result.Slice,
+	}
+	return response, nil
+}
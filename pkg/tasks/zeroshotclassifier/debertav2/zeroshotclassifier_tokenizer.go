@@ -0,0 +1,12 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package debertav2
+
+// tokenize returns the token IDs of the text, optionally preceded by the
+// given prefix tokens, and followed by the separator token.
+func (m *ZeroShotClassifier) tokenize(text string, prefix ...string) []int {
+	tokens := append(prefix, m.Tokenizer.Tokenize(text)...)
+	return m.Tokenizer.TokensToIDs(append(tokens, defaultSequenceSeparator))
+}
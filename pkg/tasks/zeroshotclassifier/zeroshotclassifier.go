@@ -13,6 +13,16 @@ const (
 	// DefaultModel is a model for Natural Language Inference (NLI) that can be used for zero-shot classification.
 	// Model card: https://huggingface.co/valhalla/distilbart-mnli-12-3
 	DefaultModel = "valhalla/distilbart-mnli-12-3"
+
+	// DefaultModelDeBERTaV3 is a DeBERTa-v3 model for Natural Language Inference (NLI), more accurate
+	// than DefaultModel for zero-shot classification.
+	// Model card: https://huggingface.co/MoritzLaurer/DeBERTa-v3-base-mnli-fever-anli
+	DefaultModelDeBERTaV3 = "MoritzLaurer/DeBERTa-v3-base-mnli-fever-anli"
+
+	// DefaultModelDistilRoBERTa is a smaller and faster RoBERTa model for Natural Language Inference (NLI)
+	// that can be used for zero-shot classification.
+	// Model card: https://huggingface.co/cross-encoder/nli-distilroberta-base
+	DefaultModelDistilRoBERTa = "cross-encoder/nli-distilroberta-base"
 )
 
 const (
@@ -0,0 +1,106 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clip
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nlpodyssey/cybertron/pkg/imageprocessing"
+	"github.com/nlpodyssey/cybertron/pkg/models/clip"
+	"github.com/nlpodyssey/cybertron/pkg/tasks/zeroshotimageclassification"
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/cliptokenizer"
+	"github.com/nlpodyssey/cybertron/pkg/utils/sliceutils"
+	"github.com/nlpodyssey/spago/mat"
+	"github.com/nlpodyssey/spago/nn"
+)
+
+var _ zeroshotimageclassification.Interface = &ZeroShotImageClassifier{}
+
+// ZeroShotImageClassifier contains the CLIP model, the image processor and the tokenizer
+// used for zero-shot image classification tasks.
+type ZeroShotImageClassifier struct {
+	// Model is the CLIP model.
+	Model *clip.Model
+	// Processor converts the images into the model input.
+	Processor *imageprocessing.Processor
+	// Tokenizer is the tokenizer of the candidate labels.
+	Tokenizer *cliptokenizer.CLIPTokenizer
+}
+
+// LoadZeroShotImageClassifier loads a ZeroShotImageClassifier from a directory.
+func LoadZeroShotImageClassifier(modelPath string) (*ZeroShotImageClassifier, error) {
+	processor, err := imageprocessing.NewFromModelFolder(modelPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image processor for zero-shot image classification: %w", err)
+	}
+
+	tokenizer, err := cliptokenizer.NewFromModelFolder(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer for zero-shot image classification: %w", err)
+	}
+
+	m, err := nn.LoadFromFile[*clip.Model](path.Join(modelPath, "spago_model.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load clip model: %w", err)
+	}
+
+	return &ZeroShotImageClassifier{
+		Model:     m,
+		Processor: processor,
+		Tokenizer: tokenizer,
+	}, nil
+}
+
+// Classify classifies the image, comparing it with the hypothesis of each candidate label.
+func (m *ZeroShotImageClassifier) Classify(_ context.Context, img image.Image, parameters zeroshotimageclassification.Parameters) (zeroshotimageclassification.Response, error) {
+	if parameters.HypothesisTemplate == "" {
+		parameters.HypothesisTemplate = zeroshotimageclassification.DefaultHypothesisTemplate
+	}
+
+	pixels, err := m.Processor.Process(img)
+	if err != nil {
+		return zeroshotimageclassification.Response{}, err
+	}
+	encodedImage, err := m.Model.EncodeImage(pixels)
+	if err != nil {
+		return zeroshotimageclassification.Response{}, err
+	}
+	imageVector := encodedImage.Value().(mat.Matrix).Normalize2()
+
+	scale := m.Model.Scale()
+	logits := make([]float64, len(parameters.CandidateLabels))
+	for i, label := range parameters.CandidateLabels {
+		hypothesis := strings.Replace(parameters.HypothesisTemplate, "{}", label, -1)
+		tokenized, err := m.Tokenizer.Encode(hypothesis)
+		if err != nil {
+			return zeroshotimageclassification.Response{}, err
+		}
+		if l, k := len(tokenized), m.Model.Config.TextConfig.MaxPositionEmbeddings; l > k {
+			return zeroshotimageclassification.Response{}, fmt.Errorf("%w: %d > %d", zeroshotimageclassification.ErrInputSequenceTooLong, l, k)
+		}
+		textVector := m.Model.EncodeText(tokenized).Value().(mat.Matrix).Normalize2()
+		logits[i] = scale * imageVector.DotUnitary(textVector).Item().F64()
+	}
+
+	// the probabilities are the softmax of the scaled cosine similarities over all candidate labels
+	scores := mat.NewDense[float64](mat.WithBacking(logits)).Softmax()
+	result := sliceutils.NewIndexedSlice[float64](scores.Data().F64())
+	sort.Stable(sort.Reverse(result))
+
+	labels := make([]string, len(parameters.CandidateLabels))
+	for i, ii := range result.Indices {
+		labels[i] = parameters.CandidateLabels[ii]
+	}
+
+	return zeroshotimageclassification.Response{
+		Labels: labels,
+		Scores: result.Slice,
+	}, nil
+}
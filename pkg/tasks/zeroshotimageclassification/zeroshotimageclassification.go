@@ -0,0 +1,51 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zeroshotimageclassification
+
+import (
+	"context"
+	"errors"
+	"image"
+)
+
+const (
+	// DefaultModel is a CLIP model that can be used for zero-shot image classification,
+	// comparing the image with a description of each candidate label.
+	// Model card: https://huggingface.co/openai/clip-vit-base-patch32
+	DefaultModel = "openai/clip-vit-base-patch32"
+)
+
+const (
+	// DefaultHypothesisTemplate is the string template that is interpolated with each class to predict.
+	DefaultHypothesisTemplate = "This is a photo of {}."
+)
+
+// ErrInputSequenceTooLong means that pre-processing a candidate label
+// produced a sequence that exceeds the maximum allowed length.
+var ErrInputSequenceTooLong = errors.New("input sequence too long")
+
+// Interface defines the main functions for zero-shot image classification task.
+type Interface interface {
+	// Classify returns the classification of the given image.
+	Classify(ctx context.Context, img image.Image, parameters Parameters) (Response, error)
+}
+
+// Parameters contains the parameters for zero-shot image classification.
+type Parameters struct {
+	// A list of strings that are potential classes for inputs. (required)
+	CandidateLabels []string
+	// HypothesisTemplate is the string template that is interpolated with each class to predict.
+	// For example, “a photo of a {}”. (optional)
+	HypothesisTemplate string
+}
+
+// Response contains the response from zero-shot image classification.
+type Response struct {
+	// The list of labels sent in the request, sorted in descending order
+	// by probability that the input corresponds to the label.
+	Labels []string
+	// a list of floats that correspond the probability of label, in the same order as labels.
+	Scores []float64
+}
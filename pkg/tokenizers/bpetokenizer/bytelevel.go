@@ -0,0 +1,36 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bpetokenizer
+
+// runeToByte maps the printable characters of the byte-level vocabulary to the
+// original bytes, as in the GPT-2 tokenizer.
+var runeToByte = make(map[rune]byte, 0x100)
+
+func init() {
+	n := 0
+	for i := 0; i < 0x100; i++ {
+		if (i >= '!' && i <= '~') || (i >= 0xA1 && i <= 0xAC) || (i >= 0xAE && i <= 0xFF) {
+			runeToByte[rune(i)] = byte(i)
+		} else {
+			runeToByte[rune(0x100+n)] = byte(i)
+			n++
+		}
+	}
+}
+
+// AppendByteLevel appends to dst the original bytes of a token of the
+// byte-level vocabulary (e.g. "Ġhello" is " hello" and "Ċ" is "\n"),
+// skipping the characters which are not part of the byte-level alphabet.
+//
+// The bytes of a multibyte character may be split across several tokens,
+// so they are valid UTF-8 only once all the tokens are appended.
+func AppendByteLevel(dst []byte, token string) []byte {
+	for _, r := range token {
+		if b, ok := runeToByte[r]; ok {
+			dst = append(dst, b)
+		}
+	}
+	return dst
+}
@@ -20,6 +20,17 @@ import (
 	"github.com/nlpodyssey/gotokenizers/vocabulary"
 )
 
+const (
+	// DefaultClassToken is the default class token value for RoBERTa-compatible BPE models.
+	DefaultClassToken = "<s>"
+	// DefaultSequenceSeparator is the default sequence separator value for RoBERTa-compatible BPE models.
+	DefaultSequenceSeparator = "</s>"
+	// DefaultMaskToken is the default mask token value for RoBERTa-compatible BPE models.
+	DefaultMaskToken = "<mask>"
+	// DefaultSpacePrefix is the symbol used by the byte-level pre-tokenizer to represent a leading space.
+	DefaultSpacePrefix = "Ġ"
+)
+
 // var _ tokenizers.Tokenizer = &BPETokenizer{} // TODO: update Tokenizer interface to return errors
 
 // BPETokenizer is a higher-level tokenizer, which includes byte-level pre-tokenization.
@@ -180,6 +191,17 @@ func (t *BPETokenizer) Encode(text string) (*encodings.Encoding, error) {
 	return encoding, nil
 }
 
+// TokenID returns the ID of a token of the vocabulary (e.g. "Ġhello"),
+// including the extra special tokens, and whether it exists.
+func (t *BPETokenizer) TokenID(token string) (int, bool) {
+	for id, s := range t.extraSpecialTokenIDs {
+		if s == token {
+			return id, true
+		}
+	}
+	return t.vocab.GetID(token)
+}
+
 // Detokenize flatten and merges a list of ids into a single string.
 func (t *BPETokenizer) Detokenize(ids []int) string {
 	var sb strings.Builder
@@ -194,5 +216,23 @@ func (t *BPETokenizer) Detokenize(ids []int) string {
 		}
 	}
 	ret := sb.String()
-	return strings.Replace(ret, "Ġ", " ", -1) // TODO
+	return strings.Replace(ret, DefaultSpacePrefix, " ", -1) // TODO
+}
+
+// Decode returns the text of a list of ids, decoding the byte-level tokens
+// back to the original bytes, while the extra special tokens are kept as they are.
+// Invalid UTF-8 sequences (e.g. a multibyte character truncated by the generation)
+// are replaced with the Unicode replacement character.
+func (t *BPETokenizer) Decode(ids []int) string {
+	var text []byte
+	for _, id := range ids {
+		if s, ok := t.extraSpecialTokenIDs[id]; ok {
+			text = append(text, s...)
+			continue
+		}
+		if s, ok := t.vocab.GetString(id); ok {
+			text = AppendByteLevel(text, s)
+		}
+	}
+	return strings.ToValidUTF8(string(text), "�")
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
+	"github.com/nlpodyssey/gotokenizers/vocabulary"
 )
 
 func TestNewFromModelFolder(t *testing.T) {
@@ -36,3 +37,47 @@ func TestNewFromModelFolder(t *testing.T) {
 		t.Errorf("expected:\n  %#v\nactual:\n  %#v\n", expected, actual)
 	}
 }
+
+func TestBPETokenizer_TokenID(t *testing.T) {
+	tokenizer, err := NewFromModelFolder("testdata/dummy-roberta-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenizer.SetExtraSpecialTokens(map[int]string{16: "<extra>"})
+
+	for token, expected := range map[string]int{"unrelated": 15, "re": 8, "<extra>": 16} {
+		if id, ok := tokenizer.TokenID(token); !ok || id != expected {
+			t.Errorf("token %#v: expected ID %d, actual %d (%t)", token, expected, id, ok)
+		}
+	}
+	if _, ok := tokenizer.TokenID("missing"); ok {
+		t.Error("expected missing token not to be found")
+	}
+}
+
+func TestBPETokenizer_Decode(t *testing.T) {
+	vocab := vocabulary.NewVocabulary()
+	for _, term := range []string{"Hello", "Ċ", "Ġcaf", "Ã", "©"} {
+		vocab.AddTerm(term)
+	}
+	tokenizer := New(nil, nil, vocab)
+	tokenizer.SetExtraSpecialTokens(map[int]string{5: "<extra>"})
+
+	tests := []struct {
+		name string
+		ids  []int
+		want string
+	}{
+		{"newline", []int{0, 1}, "Hello\n"},
+		{"multibyte character", []int{2, 3, 4}, " café"},
+		{"truncated multibyte character", []int{2, 3}, " caf�"},
+		{"extra special token", []int{5, 0}, "<extra>Hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenizer.Decode(tt.ids); got != tt.want {
+				t.Errorf("expected %q, actual %q", tt.want, got)
+			}
+		})
+	}
+}
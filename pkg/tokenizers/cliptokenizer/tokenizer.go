@@ -0,0 +1,163 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cliptokenizer
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nlpodyssey/gotokenizers/models/bpemodel"
+	"github.com/nlpodyssey/gotokenizers/vocabulary"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultStartOfTextToken is the token prepended to the text.
+	DefaultStartOfTextToken = "<|startoftext|>"
+	// DefaultEndOfTextToken is the token appended to the text.
+	DefaultEndOfTextToken = "<|endoftext|>"
+	// DefaultEndOfWordSuffix is the suffix marking the last sub-word of each word.
+	DefaultEndOfWordSuffix = "</w>"
+)
+
+// splittingRegexp splits the text into words, before the BPE tokenization.
+var splittingRegexp = regexp.MustCompile(`<\|startoftext\|>|<\|endoftext\|>|'s|'t|'re|'ve|'m|'ll|'d|\p{L}+|\p{N}|[^\s\p{L}\p{N}]+`)
+
+// whitespaces matches the sequences of whitespace characters.
+var whitespaces = regexp.MustCompile(`\s+`)
+
+// CLIPTokenizer is the byte-level BPE tokenizer of CLIP models, which,
+// differently from the GPT-2 tokenizer, lowercases the text and marks
+// the end of the words instead of the leading spaces.
+type CLIPTokenizer struct {
+	model         *bpemodel.BPEModel
+	vocab         *vocabulary.Vocabulary
+	startOfTextID int
+	endOfTextID   int
+}
+
+// NewFromModelFolder returns a new CLIPTokenizer built from a pre-trained
+// CLIP model, given the path to the folder containing the vocabulary
+// ("vocab.json") and the merges ("merges.txt").
+func NewFromModelFolder(path string) (*CLIPTokenizer, error) {
+	vocabularyFilename := filepath.Join(path, "vocab.json")
+	vocab, err := vocabulary.FromJSONFile(vocabularyFilename)
+	if err != nil {
+		return nil, fmt.Errorf("loading vocabulary from file %s: %w", vocabularyFilename, err)
+	}
+
+	mergesFilename := filepath.Join(path, "merges.txt")
+	merges, err := bpemodel.MergeMapFromFile(mergesFilename, vocab, 0)
+	if err != nil {
+		return nil, fmt.Errorf("loading merges from file %s: %w", mergesFilename, err)
+	}
+
+	startOfTextID, ok := vocab.GetID(DefaultStartOfTextToken)
+	if !ok {
+		return nil, fmt.Errorf("missing token %s from vocabulary", DefaultStartOfTextToken)
+	}
+	endOfTextID, ok := vocab.GetID(DefaultEndOfTextToken)
+	if !ok {
+		return nil, fmt.Errorf("missing token %s from vocabulary", DefaultEndOfTextToken)
+	}
+
+	model := bpemodel.New(
+		vocab,
+		merges,
+		0,   // cache capacity
+		0.0, // dropout
+		DefaultEndOfTextToken,
+		"", // continuing subword prefix
+		DefaultEndOfWordSuffix,
+		false, // unknown fusion
+	)
+
+	return &CLIPTokenizer{
+		model:         model,
+		vocab:         vocab,
+		startOfTextID: startOfTextID,
+		endOfTextID:   endOfTextID,
+	}, nil
+}
+
+// StartOfTextID returns the ID of the start of text token.
+func (t *CLIPTokenizer) StartOfTextID() int {
+	return t.startOfTextID
+}
+
+// EndOfTextID returns the ID of the end of text token.
+func (t *CLIPTokenizer) EndOfTextID() int {
+	return t.endOfTextID
+}
+
+// Tokenize returns the tokens of the text, without the special tokens.
+func (t *CLIPTokenizer) Tokenize(text string) ([]string, error) {
+	ids, err := t.tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]string, len(ids))
+	for i, id := range ids {
+		tokens[i], _ = t.vocab.GetString(id)
+	}
+	return tokens, nil
+}
+
+// Encode returns the IDs of the tokens of the text, preceded by the start
+// of text token and followed by the end of text token.
+func (t *CLIPTokenizer) Encode(text string) ([]int, error) {
+	ids, err := t.tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int, 0, len(ids)+2)
+	result = append(result, t.startOfTextID)
+	result = append(result, ids...)
+	return append(result, t.endOfTextID), nil
+}
+
+// tokenize returns the IDs of the tokens of the text.
+func (t *CLIPTokenizer) tokenize(text string) ([]int, error) {
+	text = norm.NFC.String(text)
+	text = strings.ToLower(strings.TrimSpace(whitespaces.ReplaceAllString(text, " ")))
+
+	ids := make([]int, 0, len(text))
+	for _, word := range splittingRegexp.FindAllString(text, -1) {
+		tokens, err := t.model.Tokenize(byteLevelEncode(word))
+		if err != nil {
+			return nil, fmt.Errorf("CLIPTokenizer Tokenize for %s: %w", text, err)
+		}
+		for _, token := range tokens {
+			ids = append(ids, token.ID)
+		}
+	}
+	return ids, nil
+}
+
+// byteLevelEncode maps each byte of the word to a printable character.
+func byteLevelEncode(word string) string {
+	var sb strings.Builder
+	for _, b := range []byte(word) {
+		sb.WriteRune(byteToRune[b])
+	}
+	return sb.String()
+}
+
+// byteToRune maps the bytes to printable characters, as in the GPT-2 and CLIP tokenizers.
+var byteToRune [0x100]rune
+
+func init() {
+	n := 0
+	for i := range byteToRune {
+		if (i >= '!' && i <= '~') || (i >= 0xA1 && i <= 0xAC) || (i >= 0xAE && i <= 0xFF) {
+			byteToRune[i] = rune(i)
+		} else {
+			byteToRune[i] = rune(0x100 + n)
+			n++
+		}
+	}
+}
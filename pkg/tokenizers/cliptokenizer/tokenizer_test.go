@@ -0,0 +1,39 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cliptokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewFromModelFolder(t *testing.T) {
+	tokenizer, err := NewFromModelFolder("testdata/dummy-clip-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := tokenizer.Tokenize(" A \t CAT!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedTokens := []string{"a</w>", "cat</w>", "!</w>"}
+	if !reflect.DeepEqual(tokens, expectedTokens) {
+		t.Errorf("expected:\n  %#v\nactual:\n  %#v\n", expectedTokens, tokens)
+	}
+
+	ids, err := tokenizer.Encode("a cat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedIDs := []int{10, 5, 9, 11}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		t.Errorf("expected:\n  %#v\nactual:\n  %#v\n", expectedIDs, ids)
+	}
+
+	if id := tokenizer.EndOfTextID(); id != 11 {
+		t.Errorf("expected end of text ID 11, actual %d", id)
+	}
+}
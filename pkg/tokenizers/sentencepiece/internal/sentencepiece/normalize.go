@@ -4,30 +4,6 @@
 
 package sentencepiece
 
-import (
-	"strings"
-	"unicode"
-
-	"golang.org/x/text/unicode/norm"
-)
-
-func sanitize(s string) string {
-	return norm.NFKC.String(s)
-}
-
-func normalize(s string) string {
-	replacer := func(r rune) rune {
-		if isControl(r) || r == 0 {
-			return -1
-		}
-		if unicode.IsSpace(r) {
-			return ' '
-		}
-		return r
-	}
-	return sanitize(strings.Map(replacer, s))
-}
-
 var controlChars = []rune{
 	0x007F, 0x00AD, 0x0600, 0x0601, 0x0602, 0x0603, 0x0604, 0x0605, 0x061C, 0x06DD, 0x070F,
 	0x08E2, 0x180E, 0x200B, 0x200C, 0x200D, 0x200E, 0x200F, 0x202A, 0x202B, 0x202C, 0x202D,
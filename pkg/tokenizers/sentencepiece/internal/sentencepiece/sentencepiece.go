@@ -11,6 +11,8 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 const minScore float32 = -math.MaxFloat32
@@ -43,12 +45,22 @@ func newTrieNode(text string, level int) *trieNode {
 	}
 }
 
+type bpePiece struct {
+	score float32
+	index int32
+}
+
 // Sentencepiece holds the model
 type Sentencepiece struct {
 	root         *trieNode
 	lowercase    bool
+	stripAccents bool
 	unknown      int32
 	controlWords map[string]int32
+	// bpePieces is set only for BPE models.
+	bpePieces    map[string]bpePiece
+	byteFallback bool
+	bytePieces   map[byte]int32
 }
 
 // NewEmptySentencepiece creates an empty sentencepiece model
@@ -58,6 +70,7 @@ func NewEmptySentencepiece(lowercase bool) Sentencepiece {
 		lowercase:    lowercase,
 		unknown:      0,
 		controlWords: make(map[string]int32),
+		bytePieces:   make(map[byte]int32),
 	}
 }
 
@@ -76,6 +89,11 @@ func (s *Sentencepiece) SetControlWord(word string, index int32) {
 	s.controlWords[word] = index
 }
 
+// SetStripAccents sets whether the accents are removed from the text before tokenization
+func (s *Sentencepiece) SetStripAccents(value bool) {
+	s.stripAccents = value
+}
+
 // GetControlWord gets the index for the given control word
 func (s *Sentencepiece) GetControlWord(word string) (int32, bool) {
 	v, ok := s.controlWords[word]
@@ -84,19 +102,83 @@ func (s *Sentencepiece) GetControlWord(word string) (int32, bool) {
 
 // Tokenize tokenizes text into pieces
 func (s *Sentencepiece) Tokenize(text string) []Token {
-	text = normalize(text)
-	if s.lowercase {
-		text = strings.ToLower(text)
+	offsetTokens := s.TokenizeWithOffsets(text)
+	tokens := make([]Token, len(offsetTokens))
+	for i, token := range offsetTokens {
+		tokens[i] = token.Token
 	}
-	runes := torunes(text)
-	replaceWhiteSpace(runes)
+	return tokens
+}
+
+// TokenizeWithOffsets tokenizes text into pieces, along with their positions
+// in the original text.
+func (s *Sentencepiece) TokenizeWithOffsets(text string) []OffsetToken {
+	if s.bpePieces != nil {
+		return s.tokenizeBPE(text)
+	}
+	runes, spans := s.normalizeWithSpans(text)
 	slices := s.decodeForwardToken(runes)
 	slices = s.decodeBackwards(slices)
 	offsets := s.sliceToTokens(slices)
-	tokens := makeTokens(offsets, runes)
+	tokens := makeTokens(offsets, runes, spans)
 	return tokens
 }
 
+// normalizeWithSpans removes the control characters, applies the NFKC
+// normalization, lowercases the text and removes the accents if required, adds the dummy prefix
+// and replaces the whitespaces with the separator. It returns the resulting
+// runes along with the span of the original runes from which each of them comes.
+func (s *Sentencepiece) normalizeWithSpans(text string) ([]rune, []span) {
+	mapped := make([]byte, 0, len(text))
+	origins := make([]int, 0, len(text)) // original rune index of each mapped byte
+	index := 0
+	for _, r := range text {
+		if isControl(r) || r == 0 {
+			index++
+			continue
+		}
+		if unicode.IsSpace(r) {
+			r = ' '
+		}
+		n := len(mapped)
+		mapped = utf8.AppendRune(mapped, r)
+		for ; n < len(mapped); n++ {
+			origins = append(origins, index)
+		}
+		index++
+	}
+
+	runes := make([]rune, 0, len(mapped)+1)
+	spans := make([]span, 0, len(mapped)+1)
+	var it norm.Iter
+	it.Init(norm.NFKC, mapped)
+	for !it.Done() {
+		start := it.Pos()
+		segment := it.Next()
+		sp := span{start: origins[start], end: origins[it.Pos()-1] + 1}
+		if s.stripAccents {
+			segment = norm.NFKD.Bytes(segment)
+		}
+		for _, r := range string(segment) {
+			if s.stripAccents && unicode.Is(unicode.Mn, r) {
+				continue
+			}
+			if s.lowercase {
+				r = unicode.ToLower(r)
+			}
+			runes = append(runes, r)
+			spans = append(spans, sp)
+		}
+	}
+
+	if len(runes) == 0 || runes[0] != sep {
+		runes = append([]rune{sep}, runes...)
+		spans = append([]span{{}}, spans...)
+	}
+	replaceWhiteSpace(runes)
+	return runes, spans
+}
+
 // TokenizeToIDs tokenizes text into ids from the vocab
 func (s *Sentencepiece) TokenizeToIDs(text string) []int32 {
 	tokens := s.Tokenize(text)
@@ -107,6 +189,73 @@ func (s *Sentencepiece) TokenizeToIDs(text string) []int32 {
 	return ids
 }
 
+// tokenizeBPE tokenizes text by repeatedly merging the pair of adjacent
+// pieces with the highest score, starting from single characters.
+// The text is not normalized, except for the whitespaces.
+// If byte fallback is enabled, unknown pieces are split into byte pieces.
+func (s *Sentencepiece) tokenizeBPE(text string) []OffsetToken {
+	if s.lowercase {
+		text = strings.ToLower(text)
+	}
+	runes := torunes(text)
+	shift := len(runes) - utf8.RuneCountInString(text) // 1 if the dummy prefix was added
+	for i, r := range runes {
+		if r == ' ' {
+			runes[i] = sep
+		}
+	}
+
+	type symbol struct {
+		text       string
+		start, end int // rune offsets in runes
+	}
+	symbols := make([]symbol, len(runes))
+	for i, r := range runes {
+		symbols[i] = symbol{text: string(r), start: i, end: i + 1}
+	}
+	for {
+		best, bestScore := -1, minScore
+		for i := 0; i < len(symbols)-1; i++ {
+			if p, ok := s.bpePieces[symbols[i].text+symbols[i+1].text]; ok && p.score > bestScore {
+				best, bestScore = i, p.score
+			}
+		}
+		if best < 0 {
+			break
+		}
+		symbols[best].text += symbols[best+1].text
+		symbols[best].end = symbols[best+1].end
+		symbols = append(symbols[:best+1], symbols[best+2:]...)
+	}
+
+	tokens := make([]OffsetToken, 0, len(symbols))
+	for _, sym := range symbols {
+		start := sym.start
+		for start < sym.end-1 && runes[start] == sep {
+			start++
+		}
+		newToken := func(id int32, text string) OffsetToken {
+			return OffsetToken{
+				Token: Token{ID: id, Text: text},
+				Start: max(start-shift, 0),
+				End:   max(sym.end-shift, 0),
+			}
+		}
+		if p, ok := s.bpePieces[sym.text]; ok {
+			tokens = append(tokens, newToken(p.index, sym.text))
+			continue
+		}
+		if !s.byteFallback {
+			tokens = append(tokens, newToken(s.unknown, sym.text))
+			continue
+		}
+		for _, b := range []byte(sym.text) {
+			tokens = append(tokens, newToken(s.bytePieces[b], fmt.Sprintf("<0x%02X>", b)))
+		}
+	}
+	return tokens
+}
+
 func (s *Sentencepiece) insert(word string, score float32, index int32) {
 	_, size := utf8.DecodeLastRuneInString(word)
 	charCount := len(word)
@@ -235,10 +384,20 @@ func torunes(text string) []rune {
 	return runes
 }
 
-func makeTokens(offsets []tokenOffset, runes []rune) []Token {
-	tokens := make([]Token, len(offsets))
+func makeTokens(offsets []tokenOffset, runes []rune, spans []span) []OffsetToken {
+	tokens := make([]OffsetToken, len(offsets))
 	for i, offset := range offsets {
-		tokens[i] = Token{ID: offset.id, Text: string(runes[offset.start:offset.end])}
+		// A leading separator represents the preceding whitespace, so it is
+		// excluded from the span, unless it is the whole token.
+		first := offset.start
+		for first < offset.end-1 && runes[first] == sep {
+			first++
+		}
+		tokens[i] = OffsetToken{
+			Token: Token{ID: offset.id, Text: string(runes[offset.start:offset.end])},
+			Start: spans[first].start,
+			End:   spans[offset.end-1].end,
+		}
 	}
 	return tokens
 }
@@ -14,33 +14,57 @@ import (
 
 // NewSentencepieceFromFile creates sentencepiece from file.
 func NewSentencepieceFromFile(filename string, lowercase bool) (Sentencepiece, error) {
-	s := NewEmptySentencepiece(lowercase)
+	model, err := readModelProto(filename)
+	if err != nil {
+		return NewEmptySentencepiece(lowercase), err
+	}
+	return newSentencepieceFromModel(model, lowercase), nil
+}
+
+// readModelProto reads a sentencepiece model from file.
+func readModelProto(filename string) (*ModelProto, error) {
 	bytes, err := os.ReadFile(filename)
 	if err != nil {
-		return s, fmt.Errorf("unable to read file : %s, err %v", filename, err)
+		return nil, fmt.Errorf("unable to read file : %s, err %v", filename, err)
 	}
 	var model ModelProto
 	err = proto.Unmarshal(bytes, &model)
 	if err != nil {
-		return s, fmt.Errorf("unable to read model file : %s, err %v", filename, err)
+		return nil, fmt.Errorf("unable to read model file : %s, err %v", filename, err)
+	}
+	return &model, nil
+}
+
+// newSentencepieceFromModel creates sentencepiece from a model.
+// BPE models are encoded by merging pieces, instead of using the unigram scores.
+func newSentencepieceFromModel(model *ModelProto, lowercase bool) Sentencepiece {
+	s := NewEmptySentencepiece(lowercase)
+	if model.GetTrainerSpec().GetModelType() == TrainerSpec_BPE {
+		s.bpePieces = make(map[string]bpePiece)
+		s.byteFallback = model.GetTrainerSpec().GetByteFallback()
 	}
 
-	count := 0
 	for i, piece := range model.GetPieces() {
 		typ := piece.GetType()
 		word := piece.GetPiece()
 		switch typ {
 		case ModelProto_SentencePiece_NORMAL, ModelProto_SentencePiece_USER_DEFINED:
 			s.insert(word, piece.GetScore(), int32(i))
+			if s.bpePieces != nil {
+				s.bpePieces[word] = bpePiece{score: piece.GetScore(), index: int32(i)}
+			}
 		case ModelProto_SentencePiece_UNKNOWN:
 			s.SetUnknownIndex(int32(i))
 		case ModelProto_SentencePiece_CONTROL:
 			s.SetControlWord(word, int32(i))
+		case ModelProto_SentencePiece_BYTE:
+			var b byte
+			if _, err := fmt.Sscanf(word, "<0x%02X>", &b); err == nil {
+				s.bytePieces[b] = int32(i)
+			}
 		}
-		count++
 	}
-
-	return s, nil
+	return s
 }
 
 func NewSentencepieceAndVocabFromFile(filename string, lowercase bool) (Sentencepiece, *vocabulary.Vocabulary, error) {
@@ -93,3 +117,40 @@ func NewSentencepieceAndVocabFromFile(filename string, lowercase bool) (Sentence
 
 	return s, vocab, nil
 }
+
+// NewSentencepieceAndPlainVocabFromFile creates sentencepiece from file, along with
+// a vocabulary whose IDs correspond to the positions of the pieces in the model.
+func NewSentencepieceAndPlainVocabFromFile(filename string, lowercase bool) (Sentencepiece, *vocabulary.Vocabulary, error) {
+	model, err := readModelProto(filename)
+	if err != nil {
+		return NewEmptySentencepiece(lowercase), nil, err
+	}
+
+	vocab := vocabulary.NewVocabulary()
+	for _, piece := range model.GetPieces() {
+		vocab.AddTerm(piece.GetPiece())
+	}
+	return newSentencepieceFromModel(model, lowercase), vocab, nil
+}
+
+// NewSentencepieceAndFairseqVocabFromFile creates sentencepiece from file, along with
+// a vocabulary following the fairseq dictionary layout, used by XLM-RoBERTa models:
+// the special tokens "<s>", "<pad>", "</s>" and "<unk>" come first, followed by the
+// pieces of the model (each ID is the position of the piece plus one) and by "<mask>".
+// The first three pieces of the model are expected to be "<unk>", "<s>" and "</s>".
+func NewSentencepieceAndFairseqVocabFromFile(filename string, lowercase bool) (Sentencepiece, *vocabulary.Vocabulary, error) {
+	model, err := readModelProto(filename)
+	if err != nil {
+		return NewEmptySentencepiece(lowercase), nil, err
+	}
+
+	vocab := vocabulary.NewVocabulary()
+	for _, term := range []string{"<s>", "<pad>", "</s>", "<unk>"} {
+		vocab.AddTerm(term)
+	}
+	for _, piece := range model.GetPieces()[min(3, len(model.GetPieces())):] {
+		vocab.AddTerm(piece.GetPiece())
+	}
+	vocab.AddTerm("<mask>")
+	return newSentencepieceFromModel(model, lowercase), vocab, nil
+}
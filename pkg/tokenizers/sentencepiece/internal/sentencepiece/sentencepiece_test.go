@@ -7,6 +7,8 @@ package sentencepiece
 import (
 	"reflect"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
 )
 
 func TestTokenization(t *testing.T) {
@@ -343,6 +345,47 @@ func TestControlWords2(t *testing.T) {
 	}
 }
 
+func TestBPETokenization(t *testing.T) {
+	piece := func(text string, score float32, typ ModelProto_SentencePiece_Type) *ModelProto_SentencePiece {
+		return &ModelProto_SentencePiece{Piece: proto.String(text), Score: proto.Float32(score), Type: typ.Enum()}
+	}
+	model := &ModelProto{
+		TrainerSpec: &TrainerSpec{
+			ModelType:    TrainerSpec_BPE.Enum(),
+			ByteFallback: proto.Bool(true),
+		},
+		Pieces: []*ModelProto_SentencePiece{
+			piece("<unk>", 0, ModelProto_SentencePiece_UNKNOWN),
+			piece("<s>", 0, ModelProto_SentencePiece_CONTROL),
+			piece("<0x0A>", 0, ModelProto_SentencePiece_BYTE),
+			piece("<0x21>", 0, ModelProto_SentencePiece_BYTE),
+			piece("▁h", -1, ModelProto_SentencePiece_NORMAL),
+			piece("ll", -2, ModelProto_SentencePiece_NORMAL),
+			piece("▁he", -3, ModelProto_SentencePiece_NORMAL),
+			piece("▁hell", -4, ModelProto_SentencePiece_NORMAL),
+			piece("lo", -5, ModelProto_SentencePiece_NORMAL),
+			piece("▁hello", -6, ModelProto_SentencePiece_NORMAL),
+			piece("▁", -7, ModelProto_SentencePiece_NORMAL),
+			piece("h", -8, ModelProto_SentencePiece_NORMAL),
+			piece("e", -9, ModelProto_SentencePiece_NORMAL),
+			piece("l", -10, ModelProto_SentencePiece_NORMAL),
+			piece("o", -11, ModelProto_SentencePiece_NORMAL),
+		},
+	}
+	sp := newSentencepieceFromModel(model, false)
+
+	tokens := sp.Tokenize("hello hell!\n")
+	expected := []Token{
+		{ID: 9, Text: "▁hello"},
+		{ID: 7, Text: "▁hell"},
+		{ID: 3, Text: "<0x21>"},
+		{ID: 2, Text: "<0x0A>"},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Tokenize: got %v, want %v", tokens, expected)
+	}
+}
+
 func BenchmarkSentencePiece(b *testing.B) {
 	sp, err := NewSentencepieceFromFile("test_data/xlnet-base-cased-spiece.model", false)
 	if err != nil {
@@ -371,3 +414,59 @@ func firstNChars(s string, n int) string {
 	}
 	return s[:n]
 }
+
+func TestTokenizationWithOffsets(t *testing.T) {
+	sp, err := NewSentencepieceFromFile("test_data/xlnet-base-cased-spiece.model", false)
+	if err != nil {
+		t.Errorf("Unable to create sentencepiece")
+		return
+	}
+
+	text := "Hello  ﬁne​world"
+	tokens := sp.TokenizeWithOffsets(text)
+	runes := []rune(text)
+	got := make([]string, len(tokens))
+	for i, token := range tokens {
+		got[i] = string(runes[token.Start:token.End])
+	}
+	expected := []string{"", "Hello", " ", "ﬁne", "world"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("TokenizeWithOffsets: got %v", tokens)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("TokenizeWithOffsets: token %d %#v: got %#v, want %#v", i, tokens[i].Text, got[i], expected[i])
+		}
+	}
+}
+
+func TestTokenizationWithStripAccents(t *testing.T) {
+	sp, err := NewSentencepieceFromFile("test_data/xlnet-base-cased-spiece.model", true)
+	if err != nil {
+		t.Errorf("Unable to create sentencepiece")
+		return
+	}
+	sp.SetStripAccents(true)
+
+	text := "Café naïve"
+	tokens := sp.TokenizeWithOffsets(text)
+	runes := []rune(text)
+	expected := []struct {
+		text string
+		span string
+	}{
+		{"▁cafe", "Café"},
+		{"▁naive", "naïve"},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("TokenizeWithOffsets: got %v", tokens)
+	}
+	for i, want := range expected {
+		if tokens[i].Text != want.text {
+			t.Errorf("TokenizeWithOffsets: token %d: got %#v, want %#v", i, tokens[i].Text, want.text)
+		}
+		if got := string(runes[tokens[i].Start:tokens[i].End]); got != want.span {
+			t.Errorf("TokenizeWithOffsets: token %d %#v: got %#v, want %#v", i, tokens[i].Text, got, want.span)
+		}
+	}
+}
@@ -10,6 +10,24 @@ type Token struct {
 	Text string
 }
 
+// OffsetToken is a Token along with its position in the original text.
+type OffsetToken struct {
+	Token
+	// Start is the offset (in runes) of the first character of the token
+	// in the original text, excluding the whitespace represented by a
+	// leading separator.
+	Start int
+	// End is the offset (in runes) following the last character of the token.
+	End int
+}
+
+// span is the range of runes of the original text from which a normalized
+// rune comes.
+type span struct {
+	start int
+	end   int
+}
+
 type tokenOffset struct {
 	id    int32
 	start int
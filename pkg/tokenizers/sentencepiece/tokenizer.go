@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers"
 	"github.com/nlpodyssey/cybertron/pkg/tokenizers/sentencepiece/internal/sentencepiece"
 	"github.com/nlpodyssey/gotokenizers/vocabulary"
 )
@@ -22,6 +24,9 @@ const defaultSeparator = "▁"
 type Tokenizer struct {
 	sp    *sentencepiece.Sentencepiece
 	vocab *vocabulary.Vocabulary
+	// unknownToken is the token used for the pieces missing from the vocabulary.
+	// If empty, defaultUnknownToken is used.
+	unknownToken string
 }
 
 // NewFromModelFolder returns a new Tokenizer.
@@ -57,6 +62,62 @@ func NewFromModelFolder(path string, lowercase bool) (*Tokenizer, error) {
 	}, nil
 }
 
+// NewFromFile returns a new Tokenizer loading the sentence-piece model from file.
+// Unlike NewFromModelFolder, the token IDs correspond exactly to the positions
+// of the pieces in the model (e.g. T5's "spiece.model").
+func NewFromFile(filename string, lowercase bool) (*Tokenizer, error) {
+	sp, vocab, err := sentencepiece.NewSentencepieceAndPlainVocabFromFile(filename, lowercase)
+	if err != nil {
+		return nil, fmt.Errorf("loading sentence-piece from file %s: %w", filename, err)
+	}
+	unknownToken, _ := vocab.GetString(int(sp.GetUnknownIndex()))
+	return &Tokenizer{
+		sp:           &sp,
+		vocab:        vocab,
+		unknownToken: unknownToken,
+	}, nil
+}
+
+// NewFairseqFromFile returns a new Tokenizer loading the sentence-piece model from file.
+// The token IDs follow the fairseq dictionary used by XLM-RoBERTa models
+// (e.g. "sentencepiece.bpe.model"), where the special tokens "<s>", "<pad>",
+// "</s>" and "<unk>" come first, and "<mask>" is the last token.
+func NewFairseqFromFile(filename string, lowercase bool) (*Tokenizer, error) {
+	sp, vocab, err := sentencepiece.NewSentencepieceAndFairseqVocabFromFile(filename, lowercase)
+	if err != nil {
+		return nil, fmt.Errorf("loading sentence-piece from file %s: %w", filename, err)
+	}
+	return &Tokenizer{
+		sp:    &sp,
+		vocab: vocab,
+	}, nil
+}
+
+// NewFromFileWithVocabulary returns a new Tokenizer loading the sentence-piece
+// model from file, and the token IDs from a separate JSON vocabulary
+// (e.g. M2M100's "sentencepiece.bpe.model" and "vocab.json").
+func NewFromFileWithVocabulary(filename, vocabFilename string, lowercase bool) (*Tokenizer, error) {
+	vocab, err := vocabulary.FromJSONFile(vocabFilename)
+	if err != nil {
+		return nil, fmt.Errorf("loading vocabulary from file %s: %w", vocabFilename, err)
+	}
+	sp, err := sentencepiece.NewSentencepieceFromFile(filename, lowercase)
+	if err != nil {
+		return nil, fmt.Errorf("loading sentence-piece from file %s: %w", filename, err)
+	}
+	return &Tokenizer{
+		sp:    &sp,
+		vocab: vocab,
+	}, nil
+}
+
+// WithStripAccents sets whether the accents are removed from the text before
+// tokenization (e.g. ALBERT), and returns the Tokenizer itself.
+func (t *Tokenizer) WithStripAccents(value bool) *Tokenizer {
+	t.sp.SetStripAccents(value)
+	return t
+}
+
 // Tokenize performs sentence-piece tokenization.
 func (t *Tokenizer) Tokenize(text string) []string {
 	tokens := t.sp.Tokenize(text)
@@ -68,15 +129,37 @@ func (t *Tokenizer) Tokenize(text string) []string {
 	return result
 }
 
+// TokenizeWithOffsets performs sentence-piece tokenization, returning the
+// tokens along with their offsets (in runes) in the original text.
+func (t *Tokenizer) TokenizeWithOffsets(text string) []tokenizers.StringOffsetsPair {
+	tokens := t.sp.TokenizeWithOffsets(text)
+
+	result := make([]tokenizers.StringOffsetsPair, len(tokens))
+	for i, token := range tokens {
+		result[i] = tokenizers.StringOffsetsPair{
+			String: token.Text,
+			Offsets: tokenizers.OffsetsType{
+				Start: token.Start,
+				End:   token.End,
+			},
+		}
+	}
+	return result
+}
+
 // TokensToIDs returns a list of token IDs from a list of string tokens.
 // It panics if a token is not found in the vocabulary and no unknown token is found.
 func (t *Tokenizer) TokensToIDs(tokens []string) []int {
+	unknownToken := t.unknownToken
+	if unknownToken == "" {
+		unknownToken = defaultUnknownToken
+	}
 	ids := make([]int, len(tokens))
 	for i, token := range tokens {
 		var ok bool
 		ids[i], ok = t.vocab.GetID(token)
 		if !ok {
-			ids[i], ok = t.vocab.GetID(defaultUnknownToken)
+			ids[i], ok = t.vocab.GetID(unknownToken)
 			if !ok {
 				panic(fmt.Errorf("unknown token ID not found for token %#v", token))
 			}
@@ -85,6 +168,11 @@ func (t *Tokenizer) TokensToIDs(tokens []string) []int {
 	return ids
 }
 
+// TokenID returns the ID of a token of the vocabulary, and whether it exists.
+func (t *Tokenizer) TokenID(token string) (int, bool) {
+	return t.vocab.GetID(token)
+}
+
 // IDsToTokens returns a list of string terms from a list of token IDs.
 // It panics if a token is not found in the vocabulary.
 func (t *Tokenizer) IDsToTokens(ids []int) []string {
@@ -99,11 +187,20 @@ func (t *Tokenizer) IDsToTokens(ids []int) []string {
 	return tokens
 }
 
+// VocabSize returns the number of terms in the vocabulary.
+func (t *Tokenizer) VocabSize() int {
+	return t.vocab.Size()
+}
+
 // Detokenize flatten and merges a list of tokens into a single string.
 func (t *Tokenizer) Detokenize(tokens []string) string {
 	var sb strings.Builder
 
 	for i, token := range tokens {
+		if b, ok := byteValue(token); ok {
+			sb.WriteByte(b)
+			continue
+		}
 		if strings.HasPrefix(token, defaultSeparator) {
 			if i > 0 {
 				sb.WriteByte(' ')
@@ -115,3 +212,15 @@ func (t *Tokenizer) Detokenize(tokens []string) string {
 
 	return sb.String()
 }
+
+// byteValue returns the value of a byte-fallback token (e.g. "<0x0A>").
+func byteValue(token string) (byte, bool) {
+	if len(token) != 6 || !strings.HasPrefix(token, "<0x") || token[5] != '>' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(token[3:5], 16, 8)
+	if err != nil {
+		return 0, false
+	}
+	return byte(v), true
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whispertokenizer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nlpodyssey/cybertron/pkg/tokenizers/bpetokenizer"
+	"github.com/nlpodyssey/gotokenizers/vocabulary"
+)
+
+// DefaultEndOfTextToken is the token marking the end of the transcription.
+// All the tokens from its ID onward are special tokens (including timestamps).
+const DefaultEndOfTextToken = "<|endoftext|>"
+
+// WhisperTokenizer is the byte-level BPE tokenizer of Whisper models.
+// Only the decoding of the generated tokens is supported, since the
+// prompts of the model are made of special tokens only.
+type WhisperTokenizer struct {
+	vocab       *vocabulary.Vocabulary
+	addedTokens map[string]int
+	endOfTextID int
+}
+
+// NewFromModelFolder returns a new WhisperTokenizer built from a pre-trained
+// Whisper model, given the path to the folder containing the vocabulary
+// ("vocab.json") and, optionally, the special tokens ("added_tokens.json").
+func NewFromModelFolder(path string) (*WhisperTokenizer, error) {
+	vocabularyFilename := filepath.Join(path, "vocab.json")
+	vocab, err := vocabulary.FromJSONFile(vocabularyFilename)
+	if err != nil {
+		return nil, fmt.Errorf("loading vocabulary from file %s: %w", vocabularyFilename, err)
+	}
+
+	addedTokens := make(map[string]int)
+	addedTokensFilename := filepath.Join(path, "added_tokens.json")
+	data, err := os.ReadFile(addedTokensFilename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &addedTokens); err != nil {
+			return nil, fmt.Errorf("loading added tokens from file %s: %w", addedTokensFilename, err)
+		}
+	}
+
+	t := &WhisperTokenizer{
+		vocab:       vocab,
+		addedTokens: addedTokens,
+	}
+	var ok bool
+	if t.endOfTextID, ok = t.TokenID(DefaultEndOfTextToken); !ok {
+		return nil, fmt.Errorf("missing token %s from vocabulary", DefaultEndOfTextToken)
+	}
+	return t, nil
+}
+
+// TokenID returns the ID of the given token, and whether it was found.
+func (t *WhisperTokenizer) TokenID(token string) (int, bool) {
+	if id, ok := t.addedTokens[token]; ok {
+		return id, true
+	}
+	return t.vocab.GetID(token)
+}
+
+// EndOfTextID returns the ID of the end-of-text token.
+func (t *WhisperTokenizer) EndOfTextID() int {
+	return t.endOfTextID
+}
+
+// IsSpecial reports whether the given ID is a special token, such as
+// a language, a task or a timestamp token.
+func (t *WhisperTokenizer) IsSpecial(id int) bool {
+	return id >= t.endOfTextID
+}
+
+// Languages returns the codes of the languages supported by the model
+// (e.g. "en"), sorted by the ID of their token "<|code|>".
+func (t *WhisperTokenizer) Languages() []string {
+	languages := make([]string, 0)
+	for token := range t.addedTokens {
+		if code, ok := languageCode(token); ok {
+			languages = append(languages, code)
+		}
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return t.addedTokens["<|"+languages[i]+"|>"] < t.addedTokens["<|"+languages[j]+"|>"]
+	})
+	return languages
+}
+
+// languageCode returns the language code of a token in the form "<|code|>", if it is.
+func languageCode(token string) (string, bool) {
+	if !strings.HasPrefix(token, "<|") || !strings.HasSuffix(token, "|>") {
+		return "", false
+	}
+	code := token[2 : len(token)-2]
+	if len(code) < 2 || len(code) > 3 {
+		return "", false
+	}
+	for _, r := range code {
+		if !unicode.IsLower(r) {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+// Decode returns the text corresponding to the given IDs, skipping the special tokens.
+// Invalid UTF-8 sequences (e.g. a multibyte character truncated by the generation)
+// are replaced with the Unicode replacement character.
+func (t *WhisperTokenizer) Decode(ids []int) string {
+	var text []byte
+	for _, id := range ids {
+		if t.IsSpecial(id) {
+			continue
+		}
+		if token, ok := t.vocab.GetString(id); ok {
+			text = bpetokenizer.AppendByteLevel(text, token)
+		}
+	}
+	return strings.ToValidUTF8(string(text), "�")
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The NLP Odyssey Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package whispertokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewFromModelFolder(t *testing.T) {
+	tokenizer, err := NewFromModelFolder("testdata/dummy-whisper-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id := tokenizer.EndOfTextID(); id != 10 {
+		t.Errorf("expected end of text ID 10, actual %d", id)
+	}
+	if id, ok := tokenizer.TokenID("<|notimestamps|>"); !ok || id != 19 {
+		t.Errorf("expected no timestamps ID 19, actual %d (found: %t)", id, ok)
+	}
+	if _, ok := tokenizer.TokenID("<|fr|>"); ok {
+		t.Error("expected <|fr|> to be missing")
+	}
+
+	expectedLanguages := []string{"en", "it"}
+	if languages := tokenizer.Languages(); !reflect.DeepEqual(languages, expectedLanguages) {
+		t.Errorf("expected:\n  %#v\nactual:\n  %#v\n", expectedLanguages, languages)
+	}
+}
+
+func TestWhisperTokenizer_Decode(t *testing.T) {
+	tokenizer, err := NewFromModelFolder("testdata/dummy-whisper-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ids      []int
+		expected string
+	}{
+		{[]int{11, 12, 15, 2, 3, 0, 10}, "Hello world!"},
+		{[]int{4, 5, 6, 5, 7, 8}, " perchè caffè.\n"},
+		{[]int{2, 50364, 3, 50400}, "Hello world"}, // timestamps
+		{[]int{6, 9}, " caff�"},                    // truncated multibyte character
+	}
+	for _, tt := range tests {
+		if actual := tokenizer.Decode(tt.ids); actual != tt.expected {
+			t.Errorf("ids %v: expected %q, actual %q", tt.ids, tt.expected, actual)
+		}
+	}
+}
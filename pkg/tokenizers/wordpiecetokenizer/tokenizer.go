@@ -147,6 +147,18 @@ func IsDefaultSpecial(word string) bool {
 	}
 }
 
+// FirstSubWords returns the values of the first sub-word of each word, given
+// the values of the tokens (e.g. the logits of a token classification model).
+func FirstSubWords[T any](values []T, tokens []tokenizers.StringOffsetsPair) []T {
+	result := make([]T, 0, len(tokens))
+	for i, token := range tokens {
+		if !strings.HasPrefix(token.String, DefaultSplitPrefix) {
+			result = append(result, values[i])
+		}
+	}
+	return result
+}
+
 // GroupSubWords returns a list of tokens range each of which represents
 // the start and the end index of the tokens that form a complete word.
 func GroupSubWords(tokens []tokenizers.StringOffsetsPair) []tokenizers.StringOffsetsPair {
@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"os"
 	"sync/atomic"
@@ -53,6 +54,31 @@ func NewFromFile(path string) (*Vocabulary, error) {
 	return voc, nil
 }
 
+// NewFromJSONFile returns a new vocabulary populated with the content of a JSON file
+// mapping each term to its ID (e.g. the "vocab.json" file of byte-level BPE models).
+// The IDs are expected to be contiguous and to start from zero.
+func NewFromJSONFile(path string) (*Vocabulary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return &Vocabulary{}, err
+	}
+	defer f.Close()
+	var data map[string]int
+	if err := json.NewDecoder(f).Decode(&data); err != nil {
+		return &Vocabulary{}, err
+	}
+	terms := make([]string, len(data))
+	seen := make([]bool, len(data))
+	for term, id := range data {
+		if id < 0 || id >= len(terms) || seen[id] {
+			return &Vocabulary{}, fmt.Errorf("vocabulary: invalid or duplicate ID %d for term `%s`", id, term)
+		}
+		terms[id] = term
+		seen[id] = true
+	}
+	return New(terms), nil
+}
+
 // Items returns all items.
 func (c *Vocabulary) Items() []string {
 	return c.inverse
@@ -82,7 +108,7 @@ func (c *Vocabulary) MustID(term string) int {
 func (c *Vocabulary) Term(id int) (string, bool) {
 	size := atomic.LoadInt64(&c.maxID)
 	maxID := int(size)
-	if id >= maxID {
+	if id < 0 || id > maxID {
 		return "", false
 	}
 	return c.inverse[id], true
@@ -7,6 +7,8 @@ package vocabulary_test
 import (
 	"bytes"
 	"encoding/gob"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nlpodyssey/cybertron/pkg/vocabulary"
@@ -60,3 +62,24 @@ func TestVocabulary_Gob(t *testing.T) {
 	require.Nil(t, err)
 	assert.Equal(t, v1, v2)
 }
+
+func TestVocabulary_Term(t *testing.T) {
+	voc := vocabulary.New([]string{"foo", "bar", "baz"})
+	for i, expected := range []string{"foo", "bar", "baz"} {
+		term, ok := voc.Term(i)
+		assert.True(t, ok)
+		assert.Equal(t, expected, term)
+	}
+	_, ok := voc.Term(3)
+	assert.False(t, ok)
+}
+
+func TestNewFromJSONFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "vocab.json")
+	err := os.WriteFile(filename, []byte(`{"<s>": 0, "</s>": 2, "<pad>": 1}`), 0644)
+	require.Nil(t, err)
+
+	voc, err := vocabulary.NewFromJSONFile(filename)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"<s>", "<pad>", "</s>"}, voc.Items())
+}